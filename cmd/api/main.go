@@ -63,23 +63,18 @@ func main() {
 	router := gin.Default()
 
 	// Apply middlewares
-	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.CORSMiddleware(cfg.CORS.Origin))
 	router.Use(middleware.ErrorHandlingMiddleware())
 	router.Use(middleware.LoggingMiddleware())
 
 	// Initialize handlers
-	handlers := handler.NewHandlers(
-		db,
-		cfg.OAuth.GoogleClientID,
-		cfg.OAuth.GoogleClientSecret,
-		cfg.OAuth.FacebookAppID,
-		cfg.OAuth.FacebookAppSecret,
-		cfg.OAuth.RedirectURL,
-		cfg.JWT.Secret,
-		cfg.Admin.Secret,
-		cfg.Asaas.BaseURL,
-		cfg.Asaas.Token,
-	)
+	handlers := handler.NewHandlers(db, cfg)
+	handlers.EstatisticaService().Start()
+
+	authMiddleware := middleware.NewAuthMiddleware(handlers.AuthService())
+	requireAuth := authMiddleware.RequireAuth()
+	requireAdmin := authMiddleware.RequireRole("admin")
+	requireCorretor := authMiddleware.RequireRole("corretor", "admin")
 
 	// Swagger route
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -90,16 +85,42 @@ func main() {
 		// Health check
 		api.GET("/health", handlers.HealthCheck)
 
-		// Users routes
-		users := api.Group("/users")
+		// Users routes (admin only; students use /me)
+		users := api.Group("/users", requireAdmin)
 		{
 			users.POST("", handlers.CreateUser)
 			users.GET("", handlers.GetUsers)
 			users.GET("/:id", handlers.GetUser)
 			users.PUT("/:id", handlers.UpdateUser)
 			users.DELETE("/:id", handlers.DeleteUser)
+			users.PUT("/:id/role", handlers.UpdateUserRole)
+			users.PUT("/:id/plan", handlers.UpdateUserPlan)
+			users.POST("/:id/deactivate", handlers.DeactivateUser)
+			users.POST("/:id/reactivate", handlers.ReactivateUser)
+			users.POST("/:id/erase", handlers.EraseUser)
 		}
 
+		// Self-service profile routes
+		me := api.Group("/me", requireAuth)
+		{
+			me.GET("", handlers.GetMe)
+			me.PATCH("", handlers.UpdateMe)
+			me.DELETE("", handlers.DeleteMe)
+			me.POST("/password", handlers.ChangeMyPassword)
+			me.POST("/avatar", handlers.UploadMyAvatar)
+			me.POST("/data-export", handlers.RequestDataExport)
+			me.GET("/data-export", handlers.ListDataExports)
+			me.GET("/data-export/:id", handlers.GetDataExport)
+			me.GET("/data-export/:id/download", handlers.DownloadDataExport)
+			me.GET("/notificacoes", handlers.GetMinhasNotificacoes)
+			me.POST("/notificacoes/lidas", handlers.MarcarNotificacoesLidas)
+			me.POST("/notificacoes/:id/lida", handlers.MarcarNotificacaoLida)
+			me.GET("/perfil-estudo", handlers.GetPerfilEstudo)
+			me.PUT("/perfil-estudo", handlers.UpdatePerfilEstudo)
+		}
+
+		api.GET("/media/:id", handlers.GetMediaAsset)
+
 		// Auth routes
 		auth := api.Group("/auth")
 		{
@@ -107,7 +128,23 @@ func main() {
 			auth.POST("/login", handlers.Login)
 			auth.POST("/register", handlers.Register)
 			auth.POST("/admin/register", handlers.RegisterAdmin)
-			auth.POST("/refresh", middleware.AuthMiddleware(), handlers.RefreshToken)
+			auth.POST("/refresh", handlers.RefreshToken)
+			auth.POST("/logout", requireAuth, handlers.Logout)
+			auth.POST("/logout-all", requireAuth, handlers.LogoutAll)
+			auth.GET("/sessions", requireAuth, handlers.GetSessions)
+			auth.DELETE("/sessions/:id", requireAuth, handlers.RevokeSession)
+			auth.POST("/password/forgot", handlers.ForgotPassword)
+			auth.POST("/password/reset", handlers.ResetPassword)
+			auth.POST("/email/verify", handlers.VerifyEmail)
+			auth.POST("/email/resend", handlers.ResendVerificationEmail)
+
+			// Two-factor authentication
+			auth.GET("/2fa", requireAuth, handlers.GetTwoFactorStatus)
+			auth.POST("/2fa/setup", authMiddleware.OptionalAuth(), handlers.SetupTwoFactor)
+			auth.POST("/2fa/enable", requireAuth, handlers.EnableTwoFactor)
+			auth.POST("/2fa/disable", requireAuth, handlers.DisableTwoFactor)
+			auth.POST("/2fa/recovery-codes", requireAuth, handlers.RegenerateRecoveryCodes)
+			auth.POST("/2fa/verify", handlers.VerifyTwoFactor)
 
 			// Social auth
 			auth.POST("/social", handlers.SocialLogin)
@@ -115,6 +152,12 @@ func main() {
 			auth.GET("/google/callback", handlers.GoogleCallback)
 			auth.GET("/facebook/url", handlers.FacebookAuthURL)
 			auth.GET("/facebook/callback", handlers.FacebookCallback)
+			auth.GET("/providers", handlers.GetSocialProviders)
+			auth.GET("/:provider/url", handlers.SocialAuthURL)
+			auth.GET("/:provider/callback", handlers.SocialCallback)
+			auth.GET("/identities", requireAuth, handlers.GetIdentities)
+			auth.POST("/link/:provider", requireAuth, handlers.LinkProvider)
+			auth.POST("/unlink/:provider", requireAuth, handlers.UnlinkProvider)
 		}
 
 		plans := api.Group("/plans")
@@ -124,34 +167,34 @@ func main() {
 
 		asaas := api.Group("/asaas")
 		{
-			asaas.POST("/customers", handlers.CreateAsaasCustomer)
-			asaas.POST("/payments", handlers.CreateAsaasPayment)
-			asaas.POST("/payments/:id/payWithCreditCard", handlers.ConfirmAsaasCreditCardPayment)
+			asaas.POST("/customers", requireAuth, handlers.CreateAsaasCustomer)
+			asaas.POST("/payments", requireAuth, handlers.CreateAsaasPayment)
+			asaas.POST("/payments/:id/payWithCreditCard", requireAuth, handlers.ConfirmAsaasCreditCardPayment)
 			asaas.POST("/webhooks", handlers.CreateAsaasWebhook)
 		}
 
-		cursos := api.Group("/cursos")
+		cursos := api.Group("/cursos", authMiddleware.OptionalAuth())
 		{
 			cursos.GET("", handlers.GetCourses)
-			cursos.POST("", handlers.CreateCourse)
+			cursos.POST("", requireAuth, handlers.CreateCourse)
 			cursos.GET("/categorias", handlers.GetCourseCategories)
-			cursos.POST("/categorias", handlers.CreateCourseCategory)
-			cursos.PUT("/categorias/:id", handlers.UpdateCourseCategory)
-			cursos.DELETE("/categorias/:id", handlers.DeleteCourseCategory)
-			cursos.PUT("/:id", handlers.UpdateCourse)
-			cursos.DELETE("/:id", handlers.DeleteCourse)
+			cursos.POST("/categorias", requireAuth, handlers.CreateCourseCategory)
+			cursos.PUT("/categorias/:id", requireAuth, handlers.UpdateCourseCategory)
+			cursos.DELETE("/categorias/:id", requireAuth, handlers.DeleteCourseCategory)
+			cursos.PUT("/:id", requireAuth, handlers.UpdateCourse)
+			cursos.DELETE("/:id", requireAuth, handlers.DeleteCourse)
 		}
 
-		meusCursos := api.Group("/meus-cursos")
+		meusCursos := api.Group("/meus-cursos", authMiddleware.OptionalAuth())
 		{
 			meusCursos.GET("/modulos", handlers.GetMyModules)
-			meusCursos.POST("/modulos", handlers.CreateCourseModuleStandalone)
+			meusCursos.POST("/modulos", requireAuth, handlers.CreateCourseModuleStandalone)
 			meusCursos.GET("/itens", handlers.GetMyItems)
-			meusCursos.POST("/itens", handlers.CreateCourseItemStandalone)
-			meusCursos.PUT("/itens/:id", handlers.UpdateCourseItem)
-			meusCursos.DELETE("/itens/:id", handlers.DeleteCourseItem)
-			meusCursos.PUT("/modulos/:id", handlers.UpdateCourseModule)
-			meusCursos.DELETE("/modulos/:id", handlers.DeleteCourseModule)
+			meusCursos.POST("/itens", requireAuth, handlers.CreateCourseItemStandalone)
+			meusCursos.PUT("/itens/:id", requireAuth, handlers.UpdateCourseItem)
+			meusCursos.DELETE("/itens/:id", requireAuth, handlers.DeleteCourseItem)
+			meusCursos.PUT("/modulos/:id", requireAuth, handlers.UpdateCourseModule)
+			meusCursos.DELETE("/modulos/:id", requireAuth, handlers.DeleteCourseModule)
 		}
 
 		questoes := api.Group("/questoes")
@@ -159,108 +202,176 @@ func main() {
 			questoes.GET("", handlers.GetQuestoes)
 			questoes.GET("/filtros", handlers.GetQuestaoFilters)
 			questoes.GET("/contador", handlers.GetQuestoesCount)
-			questoes.POST("", handlers.CreateQuestao)
+			questoes.GET("/recomendadas", requireAuth, handlers.GetQuestoesRecomendadas)
+			questoes.GET("/reportes", requireAdmin, handlers.GetQuestaoReportesFila)
+			questoes.POST("", requireAdmin, handlers.CreateQuestao)
+			questoes.POST("/import", requireAdmin, handlers.ImportQuestoes)
+			questoes.GET("/export", requireAdmin, handlers.ExportQuestoes)
+			questoes.POST("/dispositivos/detectar", requireAdmin, handlers.DetectarDispositivos)
+			questoes.POST("/estatisticas/recalcular", requireAdmin, handlers.RecalcularQuestaoEstatisticas)
 			questoes.GET("/:id", handlers.GetQuestaoByID)
-			questoes.PUT("/:id", handlers.UpdateQuestao)
-			questoes.DELETE("/:id", handlers.DeleteQuestao)
+			questoes.POST("/:id/responder", requireAuth, handlers.ResponderQuestao)
+			questoes.GET("/:id/tentativas", requireAuth, handlers.GetQuestaoTentativas)
+			questoes.POST("/:id/discursivas", requireAuth, handlers.EnviarRespostaDiscursiva)
+			questoes.POST("/:id/reportar", requireAuth, handlers.ReportarQuestao)
+			questoes.GET("/:id/reportes", requireAdmin, handlers.GetQuestaoReportes)
+			questoes.POST("/:id/reportes/resolver", requireAdmin, handlers.ResolverQuestaoReportes)
+			questoes.PUT("/:id", requireAdmin, handlers.UpdateQuestao)
+			questoes.GET("/:id/historico", requireAdmin, handlers.GetQuestaoHistorico)
+			questoes.GET("/:id/dispositivos", handlers.GetQuestaoDispositivos)
+			questoes.POST("/:id/dispositivos", requireAdmin, handlers.AddQuestaoDispositivo)
+			questoes.POST("/:id/dispositivos/detectar", requireAdmin, handlers.DetectarQuestaoDispositivos)
+			questoes.DELETE("/:id/dispositivos/:vinculoId", requireAdmin, handlers.RemoveQuestaoDispositivo)
+			questoes.DELETE("/:id", requireAdmin, handlers.DeleteQuestao)
 		}
 
-		meuDesempenho := api.Group("/meu-desempenho")
+		meuDesempenho := api.Group("/meu-desempenho", requireAuth)
 		{
 			meuDesempenho.GET("", handlers.GetUserPerformance)
 			meuDesempenho.GET("/resumo", handlers.GetUserPerformanceSummary)
-			meuDesempenho.POST("", handlers.CreateUserPerformance)
+			meuDesempenho.GET("/discursivas", handlers.GetDesempenhoDiscursivo)
+		}
+
+		discursivas := api.Group("/discursivas", requireAuth)
+		{
+			discursivas.GET("", handlers.GetMinhasRespostasDiscursivas)
+			discursivas.GET("/:id", handlers.GetMinhaRespostaDiscursiva)
+		}
+
+		correcoes := api.Group("/correcoes", requireCorretor)
+		{
+			correcoes.GET("", handlers.GetFilaCorrecao)
+			correcoes.GET("/:id", handlers.GetCorrecao)
+			correcoes.POST("/:id/reservar", handlers.ReservarCorrecao)
+			correcoes.POST("/:id/liberar", handlers.LiberarCorrecao)
+			correcoes.POST("/:id/corrigir", handlers.CorrigirRespostaDiscursiva)
+		}
+
+		simulados := api.Group("/simulados", requireAuth)
+		{
+			simulados.POST("", handlers.CreateSimulado)
+			simulados.GET("", handlers.GetSimulados)
+			simulados.GET("/:id", handlers.GetSimulado)
+			simulados.PUT("/:id/questoes/:ordem", handlers.ResponderSimulado)
+			simulados.POST("/:id/finalizar", handlers.FinalizarSimulado)
+		}
+
+		cadernos := api.Group("/cadernos")
+		{
+			cadernos.GET("/compartilhados/:token", authMiddleware.OptionalAuth(), handlers.GetCadernoCompartilhado)
+			cadernos.GET("/compartilhados/:token/questoes", handlers.GetCadernoCompartilhadoQuestoes)
+			cadernos.POST("", requireAuth, handlers.CreateCaderno)
+			cadernos.GET("", requireAuth, handlers.GetCadernos)
+			cadernos.GET("/:id", requireAuth, handlers.GetCaderno)
+			cadernos.PUT("/:id", requireAuth, handlers.UpdateCaderno)
+			cadernos.DELETE("/:id", requireAuth, handlers.DeleteCaderno)
+			cadernos.GET("/:id/questoes", requireAuth, handlers.GetCadernoQuestoes)
+			cadernos.POST("/:id/questoes", requireAuth, handlers.AddCadernoQuestoes)
+			cadernos.DELETE("/:id/questoes/:questaoId", requireAuth, handlers.RemoveCadernoQuestao)
+			cadernos.POST("/:id/progresso/reiniciar", requireAuth, handlers.ResetCadernoProgresso)
+			cadernos.POST("/:id/compartilhar", requireAuth, handlers.ShareCaderno)
+			cadernos.DELETE("/:id/compartilhar", requireAuth, handlers.UnshareCaderno)
+		}
+
+		revisao := api.Group("/revisao", requireAuth)
+		{
+			revisao.GET("/hoje", handlers.GetRevisaoHoje)
+			revisao.GET("/configuracoes", handlers.GetRevisaoConfig)
+			revisao.PUT("/configuracoes", handlers.UpdateRevisaoConfig)
+			revisao.DELETE("/questoes/:questaoId", handlers.RemoveRevisaoQuestao)
 		}
 
 		vade := api.Group("/vade-mecum")
 		{
 			vade.GET("", handlers.GetVadeMecum)
-			vade.POST("", handlers.CreateVadeMecum)
+			vade.POST("", requireAdmin, handlers.CreateVadeMecum)
 			vade.GET("/:id", handlers.GetVadeMecumByID)
-			vade.PUT("/:id", handlers.UpdateVadeMecum)
-			vade.DELETE("/:id", handlers.DeleteVadeMecum)
+			vade.PUT("/:id", requireAdmin, handlers.UpdateVadeMecum)
+			vade.DELETE("/:id", requireAdmin, handlers.DeleteVadeMecum)
+			vade.GET("/dispositivos/:fonte/:id/questoes", handlers.GetDispositivoQuestoes)
+			vade.GET("/ranking", handlers.GetVadeMecumRanking)
 		}
 
 		vadeCategory := api.Group("/vade-mecum/category/:category")
 		{
 			vadeCategory.GET("", handlers.GetVadeMecumByCategory)
-			vadeCategory.POST("", handlers.CreateVadeMecumByCategory)
-			vadeCategory.PUT("/:id", handlers.UpdateVadeMecumByCategory)
-			vadeCategory.DELETE("/:id", handlers.DeleteVadeMecumByCategory)
+			vadeCategory.POST("", requireAdmin, handlers.CreateVadeMecumByCategory)
+			vadeCategory.PUT("/:id", requireAdmin, handlers.UpdateVadeMecumByCategory)
+			vadeCategory.DELETE("/:id", requireAdmin, handlers.DeleteVadeMecumByCategory)
 		}
 
 		codigos := api.Group("/vade-mecum/codigos")
 		{
 			codigos.GET("", handlers.GetCodigos)
-			codigos.POST("", handlers.CreateCodigo)
-			codigos.POST("/import", handlers.ImportCodigos)
-			codigos.POST("/import/estatuto", handlers.ImportEstatuto)
+			codigos.POST("", requireAdmin, handlers.CreateCodigo)
+			codigos.POST("/import", requireAdmin, handlers.ImportCodigos)
+			codigos.POST("/import/estatuto", requireAdmin, handlers.ImportEstatuto)
 			codigos.GET("/capas", handlers.GetCapasVadeMecumCodigo)
-			codigos.POST("/capas", handlers.CreateCapaVadeMecumCodigo)
-			codigos.PUT("/capas/:id", handlers.UpdateCapaVadeMecumCodigo)
+			codigos.POST("/capas", requireAdmin, handlers.CreateCapaVadeMecumCodigo)
+			codigos.PUT("/capas/:id", requireAdmin, handlers.UpdateCapaVadeMecumCodigo)
 			codigos.GET("/grouped", handlers.GetCodigosGrouped)
 			codigos.GET("/:id", handlers.GetCodigoByID)
-			codigos.PUT("/:id", handlers.UpdateCodigo)
-			codigos.DELETE("/:id", handlers.DeleteCodigo)
+			codigos.PUT("/:id", requireAdmin, handlers.UpdateCodigo)
+			codigos.DELETE("/:id", requireAdmin, handlers.DeleteCodigo)
 		}
 
 		estatutos := api.Group("/vade-mecum/estatutos")
 		{
 			estatutos.GET("", handlers.GetEstatutos)
 			estatutos.GET("/gruposervico", handlers.GetEstatutoGrupoServico)
-			estatutos.POST("", handlers.CreateEstatuto)
+			estatutos.POST("", requireAdmin, handlers.CreateEstatuto)
 			estatutos.GET("/:id", handlers.GetEstatutoByID)
-			estatutos.PUT("/:id", handlers.UpdateEstatuto)
-			estatutos.DELETE("/:id", handlers.DeleteEstatuto)
+			estatutos.PUT("/:id", requireAdmin, handlers.UpdateEstatuto)
+			estatutos.DELETE("/:id", requireAdmin, handlers.DeleteEstatuto)
 		}
 
 		constituicao := api.Group("/vade-mecum/constituicao")
 		{
 			constituicao.GET("", handlers.GetConstituicoes)
 			constituicao.GET("/gruposervico", handlers.GetConstituicaoGrupoServico)
-			constituicao.POST("", handlers.CreateConstituicao)
+			constituicao.POST("", requireAdmin, handlers.CreateConstituicao)
 			constituicao.GET("/:id", handlers.GetConstituicaoByID)
-			constituicao.PUT("/:id", handlers.UpdateConstituicao)
-			constituicao.DELETE("/:id", handlers.DeleteConstituicao)
-			constituicao.POST("/import", handlers.ImportConstituicao)
+			constituicao.PUT("/:id", requireAdmin, handlers.UpdateConstituicao)
+			constituicao.DELETE("/:id", requireAdmin, handlers.DeleteConstituicao)
+			constituicao.POST("/import", requireAdmin, handlers.ImportConstituicao)
 		}
 
 		leis := api.Group("/vade-mecum/leis")
 		{
 			leis.GET("", handlers.GetLeis)
 			leis.GET("/gruposervico", handlers.GetLeiGrupoServico)
-			leis.POST("", handlers.CreateLei)
+			leis.POST("", requireAdmin, handlers.CreateLei)
 			leis.GET("/:id", handlers.GetLeiByID)
-			leis.PUT("/:id", handlers.UpdateLei)
-			leis.DELETE("/:id", handlers.DeleteLei)
-			leis.POST("/import", handlers.ImportLeis)
+			leis.PUT("/:id", requireAdmin, handlers.UpdateLei)
+			leis.DELETE("/:id", requireAdmin, handlers.DeleteLei)
+			leis.POST("/import", requireAdmin, handlers.ImportLeis)
 		}
 
 		oab := api.Group("/vade-mecum/oab")
 		{
 			oab.GET("", handlers.GetVadeMecumOAB)
-			oab.POST("", handlers.CreateVadeMecumOAB)
+			oab.POST("", requireAdmin, handlers.CreateVadeMecumOAB)
 			oab.GET("/:id", handlers.GetVadeMecumOABByID)
-			oab.PUT("/:id", handlers.UpdateVadeMecumOAB)
-			oab.DELETE("/:id", handlers.DeleteVadeMecumOAB)
+			oab.PUT("/:id", requireAdmin, handlers.UpdateVadeMecumOAB)
+			oab.DELETE("/:id", requireAdmin, handlers.DeleteVadeMecumOAB)
 			oab.GET("/capas", handlers.GetCapasVadeMecumOAB)
-			oab.POST("/capas", handlers.CreateCapaVadeMecumOAB)
-			oab.PUT("/capas/:id", handlers.UpdateCapaVadeMecumOAB)
-			oab.POST("/import", handlers.ImportVadeMecumOAB)
+			oab.POST("/capas", requireAdmin, handlers.CreateCapaVadeMecumOAB)
+			oab.PUT("/capas/:id", requireAdmin, handlers.UpdateCapaVadeMecumOAB)
+			oab.POST("/import", requireAdmin, handlers.ImportVadeMecumOAB)
 		}
 
 		juris := api.Group("/vade-mecum/jurisprudencia")
 		{
 			juris.GET("", handlers.GetVadeMecumJurisprudencia)
 			juris.GET("/grouped", handlers.GetVadeMecumJurisprudenciaGrouped)
-			juris.POST("", handlers.CreateVadeMecumJurisprudencia)
+			juris.POST("", requireAdmin, handlers.CreateVadeMecumJurisprudencia)
 			juris.GET("/capas", handlers.GetCapasVadeMecumJurisprudencia)
-			juris.POST("/capas", handlers.CreateCapaVadeMecumJurisprudencia)
-			juris.PUT("/capas/:id", handlers.UpdateCapaVadeMecumJurisprudencia)
-			juris.POST("/import", handlers.ImportVadeMecumJurisprudencia)
+			juris.POST("/capas", requireAdmin, handlers.CreateCapaVadeMecumJurisprudencia)
+			juris.PUT("/capas/:id", requireAdmin, handlers.UpdateCapaVadeMecumJurisprudencia)
+			juris.POST("/import", requireAdmin, handlers.ImportVadeMecumJurisprudencia)
 			juris.GET("/:id", handlers.GetVadeMecumJurisprudenciaByID)
-			juris.PUT("/:id", handlers.UpdateVadeMecumJurisprudencia)
-			juris.DELETE("/:id", handlers.DeleteVadeMecumJurisprudencia)
+			juris.PUT("/:id", requireAdmin, handlers.UpdateVadeMecumJurisprudencia)
+			juris.DELETE("/:id", requireAdmin, handlers.DeleteVadeMecumJurisprudencia)
 		}
 	}
 
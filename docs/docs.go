@@ -242,9 +242,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/admin/register": {
+        "/auth/2fa": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Status da autenticação em dois fatores",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/disable": {
             "post": {
-                "description": "Cria uma conta de administrador usando um código secreto",
+                "description": "Desativa o 2FA mediante código TOTP ou de recuperação. Não permitido para administradores",
                 "consumes": [
                     "application/json"
                 ],
@@ -254,23 +282,26 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Registrar novo administrador",
+                "summary": "Desativar autenticação em dois fatores",
                 "parameters": [
                     {
-                        "description": "Dados do novo administrador",
+                        "description": "Código TOTP ou de recuperação",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateAdminRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -291,8 +322,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -303,37 +334,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/facebook/callback": {
-            "get": {
-                "description": "Processa retorno do Facebook após autorização",
+        "/auth/2fa/enable": {
+            "post": {
+                "description": "Confirma o segredo gerado no setup com um código TOTP e retorna os códigos de recuperação (exibidos uma única vez)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Callback do Facebook OAuth",
+                "summary": "Ativar autenticação em dois fatores",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "State token",
-                        "name": "state",
-                        "in": "query",
-                        "required": true
+                        "description": "Código TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorRecoveryCodesResponse"
                         }
                     },
                     "400": {
@@ -344,91 +373,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/auth/facebook/url": {
-            "get": {
-                "description": "Retorna URL para redirecionar usuário ao login do Facebook",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Obter URL de autenticação do Facebook",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/google/callback": {
-            "get": {
-                "description": "Processa retorno do Google após autorização",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Callback do Google OAuth",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "State token",
-                        "name": "state",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/auth/google/url": {
-            "get": {
-                "description": "Retorna URL para redirecionar usuário ao login do Google",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Obter URL de autenticação do Google",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -439,9 +395,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/auth/2fa/recovery-codes": {
             "post": {
-                "description": "Autentica usuário e retorna token JWT",
+                "description": "Invalida os códigos de recuperação atuais e gera novos",
                 "consumes": [
                     "application/json"
                 ],
@@ -451,15 +407,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Login de usuário",
+                "summary": "Gerar novos códigos de recuperação",
                 "parameters": [
                     {
-                        "description": "Credenciais de login",
+                        "description": "Código TOTP ou de recuperação",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorCodeRequest"
                         }
                     }
                 ],
@@ -467,7 +423,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorRecoveryCodesResponse"
                         }
                     },
                     "400": {
@@ -491,9 +447,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/2fa/setup": {
             "post": {
-                "description": "Cria uma nova conta de usuário com nome completo, email, senha e confirmação",
+                "description": "Gera um novo segredo TOTP e a URI otpauth:// (payload do QR code). Usuários autenticados usam o Bearer token; administradores sem 2FA usam o two_factor_token recebido no login",
                 "consumes": [
                     "application/json"
                 ],
@@ -503,27 +459,26 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Registrar novo usuário",
+                "summary": "Iniciar cadastro do TOTP",
                 "parameters": [
                     {
-                        "description": "Dados do novo usuário",
+                        "description": "Token de desafio (apenas sem Bearer token)",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateUserRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorSetupRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorSetupResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -531,8 +486,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -543,9 +498,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/social": {
+        "/auth/2fa/verify": {
             "post": {
-                "description": "Autentica usuário usando Google ou Facebook OAuth2",
+                "description": "Troca o two_factor_token do login e um código TOTP (ou de recuperação) pelos tokens de acesso. Em desafios de setup o código também ativa o 2FA e os códigos de recuperação são retornados",
                 "consumes": [
                     "application/json"
                 ],
@@ -555,15 +510,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Login com provedor social",
+                "summary": "Concluir login com segundo fator",
                 "parameters": [
                     {
-                        "description": "Token de acesso do provedor",
+                        "description": "Desafio e código",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SocialAuthRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.TwoFactorVerifyRequest"
                         }
                     }
                 ],
@@ -591,31 +546,9 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/cursos": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cursos"
-                ],
-                "summary": "Listar cursos",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
-                            }
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -623,8 +556,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -633,8 +566,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/admin/register": {
             "post": {
+                "description": "Cria uma conta de administrador usando um código secreto",
                 "consumes": [
                     "application/json"
                 ],
@@ -642,17 +578,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cursos"
+                    "auth"
                 ],
-                "summary": "Criar curso",
+                "summary": "Registrar novo administrador",
                 "parameters": [
                     {
-                        "description": "Curso",
+                        "description": "Dados do novo administrador",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateAdminRequest"
                         }
                     }
                 ],
@@ -660,7 +596,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
                         }
                     },
                     "400": {
@@ -681,6 +617,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -693,27 +638,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/cursos/categorias": {
-            "get": {
+        "/auth/email/resend": {
+            "post": {
+                "description": "Envia um novo link de confirmação, se o e-mail estiver cadastrado e ainda não confirmado",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categorias"
+                    "auth"
+                ],
+                "summary": "Reenviar e-mail de confirmação",
+                "parameters": [
+                    {
+                        "description": "E-mail da conta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResendVerificationRequest"
+                        }
+                    }
                 ],
-                "summary": "Listar categorias",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -731,8 +691,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/email/verify": {
             "post": {
+                "description": "Confirma o endereço de e-mail a partir do token recebido",
                 "consumes": [
                     "application/json"
                 ],
@@ -740,38 +703,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categorias"
+                    "auth"
                 ],
-                "summary": "Criar categoria",
+                "summary": "Confirmar e-mail",
                 "parameters": [
                     {
-                        "description": "Categoria",
+                        "description": "Token de verificação",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseCategoryRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VerifyEmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -779,8 +727,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -800,41 +748,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/cursos/categorias/{id}": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/auth/facebook/callback": {
+            "get": {
+                "description": "Processa retorno do Facebook após autorização, validando o state contra o cookie oauth_flow e PKCE",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categorias"
+                    "auth"
                 ],
-                "summary": "Atualizar categoria",
+                "summary": "Callback do Facebook OAuth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da categoria",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Categoria",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseCategoryRequest"
-                        }
+                        "type": "string",
+                        "description": "State token",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
                         }
                     },
                     "400": {
@@ -855,8 +799,31 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/facebook/url": {
+            "get": {
+                "description": "Retorna URL para redirecionar usuário ao login do Facebook (state e PKCE guardados no cookie oauth_flow)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Obter URL de autenticação do Facebook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -874,24 +841,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/google/callback": {
+            "get": {
+                "description": "Processa retorno do Google após autorização, validando o state contra o cookie oauth_flow e PKCE",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "categorias"
+                    "auth"
                 ],
-                "summary": "Remover categoria",
+                "summary": "Callback do Google OAuth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da categoria",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State token",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -911,8 +894,31 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/google/url": {
+            "get": {
+                "description": "Retorna URL para redirecionar usuário ao login do Google (state e PKCE guardados no cookie oauth_flow)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Obter URL de autenticação do Google",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -932,8 +938,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/cursos/{id}": {
-            "put": {
+        "/auth/identities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Listar provedores vinculados",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserIdentity"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/link/{provider}": {
+            "post": {
+                "description": "Vincula uma conta do provedor ao usuário autenticado. Com access_token o vínculo é imediato; sem ele, retorna a URL de autorização e o vínculo é concluído no callback",
                 "consumes": [
                     "application/json"
                 ],
@@ -941,24 +988,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cursos"
+                    "auth"
                 ],
-                "summary": "Atualizar curso",
+                "summary": "Vincular provedor social",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do curso",
-                        "name": "id",
+                        "description": "Provedor (google, facebook ou OIDC configurado)",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Curso",
+                        "description": "Token de acesso do provedor",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LinkProviderRequest"
                         }
                     }
                 ],
@@ -966,7 +1012,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LinkProviderResponse"
                         }
                     },
                     "400": {
@@ -987,17 +1033,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1006,24 +1043,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Autentica usuário e retorna token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "cursos"
+                    "auth"
                 ],
-                "summary": "Remover curso",
+                "summary": "Login de usuário",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do curso",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Credenciais de login",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1043,8 +1094,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1052,8 +1103,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1064,17 +1115,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Verifica se a API está funcionando",
+        "/auth/logout": {
+            "post": {
+                "description": "Revoga a sessão do token atual ou a sessão do refresh token informado",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "auth"
                 ],
-                "summary": "Health check",
-                "responses": {
+                "summary": "Encerrar sessão",
+                "parameters": [
+                    {
+                        "description": "Refresh token (opcional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
@@ -1083,45 +1147,41 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/meu-desempenho": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
+                "description": "Revoga todas as sessões ativas do usuário autenticado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meu-desempenho"
-                ],
-                "summary": "Listar desempenho do usuario",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Data inicial (YYYY-MM-DD ou RFC3339)",
-                        "name": "data_inicio",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Data final (YYYY-MM-DD ou RFC3339)",
-                        "name": "data_fim",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Encerrar todas as sessões",
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserPerformance"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1148,8 +1208,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password/forgot": {
             "post": {
+                "description": "Envia um link de redefinição de senha para o e-mail, se ele estiver cadastrado",
                 "consumes": [
                     "application/json"
                 ],
@@ -1157,29 +1220,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "meu-desempenho"
+                    "auth"
                 ],
-                "summary": "Registrar desempenho do usuario",
+                "summary": "Solicitar redefinição de senha",
                 "parameters": [
                     {
-                        "description": "Desempenho",
+                        "description": "E-mail da conta",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateUserPerformanceRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ForgotPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserPerformance"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1187,8 +1244,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1208,38 +1265,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/meu-desempenho/resumo": {
-            "get": {
+        "/auth/password/reset": {
+            "post": {
+                "description": "Define uma nova senha a partir do token recebido por e-mail e encerra todas as sessões",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meu-desempenho"
+                    "auth"
                 ],
-                "summary": "Resumo do desempenho do usuario",
+                "summary": "Redefinir senha",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Data inicial (YYYY-MM-DD ou RFC3339)",
-                        "name": "data_inicio",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Data final (YYYY-MM-DD ou RFC3339)",
-                        "name": "data_fim",
-                        "in": "query"
+                        "description": "Token e nova senha",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserPerformanceSummary"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1247,8 +1299,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1268,46 +1320,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/meus-cursos/itens": {
+        "/auth/providers": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Listar itens",
+                "summary": "Listar provedores de login social",
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "string"
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
+                "description": "Troca um refresh token válido por um novo access token e um novo refresh token (rotação)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1315,25 +1355,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Criar item sem modulo",
+                "summary": "Renovar tokens",
                 "parameters": [
                     {
-                        "description": "Item",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseItemRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
                         }
                     },
                     "400": {
@@ -1354,17 +1394,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1375,8 +1406,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/meus-cursos/itens/{id}": {
-            "put": {
+        "/auth/register": {
+            "post": {
+                "description": "Cria uma nova conta de usuário com nome completo, email, senha e confirmação",
                 "consumes": [
                     "application/json"
                 ],
@@ -1384,32 +1416,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Atualizar item",
+                "summary": "Registrar novo usuário",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do item",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Item",
+                        "description": "Dados do novo usuário",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseItemRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateUserRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
                         }
                     },
                     "400": {
@@ -1421,17 +1446,40 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "description": "Lista os dispositivos conectados (user agent, IP e último acesso)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Listar sessões ativas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserSessionResponse"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1449,16 +1497,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/sessions/{id}": {
             "delete": {
+                "description": "Revoga uma sessão específica do usuário autenticado",
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Remover item",
+                "summary": "Encerrar uma sessão",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do item",
+                        "description": "ID da sessão",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1494,40 +1545,43 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/meus-cursos/modulos": {
-            "get": {
+        "/auth/social": {
+            "post": {
+                "description": "Autentica usuário com o access token de um provedor registrado (google, facebook ou OIDC configurado). Provedores OIDC exigem também o id_token emitido para o client_id da API, validado pela JWKS e com o mesmo sub do userinfo",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
+                ],
+                "summary": "Login com provedor social",
+                "parameters": [
+                    {
+                        "description": "Token de acesso do provedor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SocialAuthRequest"
+                        }
+                    }
                 ],
-                "summary": "Listar modulos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
-                            }
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1535,8 +1589,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1545,34 +1608,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/unlink/{provider}": {
             "post": {
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove o vínculo do provedor com o usuário autenticado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Criar modulo",
+                "summary": "Desvincular provedor social",
                 "parameters": [
                     {
-                        "description": "Modulo",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseModuleRequest"
-                        }
+                        "type": "string",
+                        "description": "Provedor (google, facebook ou OIDC configurado)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1601,54 +1665,48 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/meus-cursos/modulos/{id}": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/auth/{provider}/callback": {
+            "get": {
+                "description": "Processa retorno do provedor validando o state contra o cookie oauth_flow do navegador, PKCE e, para OIDC, o ID token (assinatura JWKS, issuer, audience e nonce)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Atualizar modulo",
+                "summary": "Callback OAuth de um provedor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do modulo",
-                        "name": "id",
+                        "description": "Provedor",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Modulo",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseModuleRequest"
-                        }
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State token",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.LoginResponse"
                         }
                     },
                     "400": {
@@ -1669,17 +1727,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1688,36 +1737,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/{provider}/url": {
+            "get": {
+                "description": "Retorna URL de autorização de qualquer provedor registrado, incluindo provedores OpenID Connect configurados via OIDC_PROVIDERS",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "meus-cursos"
+                    "auth"
                 ],
-                "summary": "Remover modulo",
+                "summary": "Obter URL de autenticação de um provedor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do modulo",
-                        "name": "id",
+                        "description": "Provedor",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1725,8 +1768,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1746,100 +1789,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/plans": {
+        "/cadernos": {
             "get": {
-                "description": "Retorna todos os planos disponíveis e, para cada um, os usuários atrelados (quando houver)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plans"
+                    "cadernos"
                 ],
-                "summary": "Listar planos com usuários vinculados",
+                "summary": "Listar meus cadernos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Plan"
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Caderno"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/questoes": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "questoes"
-                ],
-                "summary": "Listar questoes",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Disciplina",
-                        "name": "disciplina",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Assunto",
-                        "name": "assunto",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Banca",
-                        "name": "banca",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Orgao",
-                        "name": "orgao",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cargo",
-                        "name": "cargo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Concurso",
-                        "name": "concurso",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Area de conhecimento",
-                        "name": "area_conhecimento",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1853,6 +1829,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "Um caderno guarda filtros de questoes ou uma lista ordenada de questoes, nunca os dois.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1860,17 +1837,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Criar questao",
+                "summary": "Criar caderno",
                 "parameters": [
                     {
-                        "description": "Dados da questao",
+                        "description": "Caderno",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateQuestaoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoRequest"
                         }
                     }
                 ],
@@ -1878,7 +1855,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
                         }
                     },
                     "400": {
@@ -1890,6 +1867,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1902,68 +1888,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/questoes/contador": {
+        "/cadernos/compartilhados/{token}": {
             "get": {
+                "description": "Somente leitura. Usuarios autenticados recebem o proprio progresso no caderno.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Contar questoes",
+                "summary": "Abrir caderno compartilhado",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Disciplina",
-                        "name": "disciplina",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Assunto",
-                        "name": "assunto",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Banca",
-                        "name": "banca",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Orgao",
-                        "name": "orgao",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cargo",
-                        "name": "cargo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Concurso",
-                        "name": "concurso",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Area de conhecimento",
-                        "name": "area_conhecimento",
-                        "in": "query"
+                        "description": "Token do link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoCountResponse"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1974,24 +1926,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/questoes/filtros": {
+        "/cadernos/compartilhados/{token}/questoes": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Listar filtros de questoes",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Listar questoes de caderno compartilhado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token do link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (a partir de 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFiltersResponse"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoPage"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2002,19 +1975,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/questoes/{id}": {
+        "/cadernos/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Obter questao por ID",
+                "summary": "Obter caderno com progresso",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID",
+                        "type": "string",
+                        "description": "ID do caderno",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2024,7 +1997,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
                         }
                     },
                     "400": {
@@ -2036,6 +2009,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2055,24 +2037,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Atualizar questao",
+                "summary": "Atualizar caderno",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID",
+                        "type": "string",
+                        "description": "ID do caderno",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Campos para atualizacao",
+                        "description": "Caderno",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateQuestaoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoRequest"
                         }
                     }
                 ],
@@ -2080,7 +2062,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
                         }
                     },
                     "400": {
@@ -2092,6 +2074,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2105,13 +2096,13 @@ const docTemplate = `{
             },
             "delete": {
                 "tags": [
-                    "questoes"
+                    "cadernos"
                 ],
-                "summary": "Remover questao",
+                "summary": "Excluir caderno",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID",
+                        "type": "string",
+                        "description": "ID do caderno",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2130,8 +2121,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2142,27 +2142,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum": {
-            "get": {
+        "/cadernos/{id}/compartilhar": {
+            "post": {
+                "description": "Gera (ou retorna) o link de leitura do caderno.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
+                ],
+                "summary": "Compartilhar caderno por link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do caderno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Listar itens de Vade Mecum",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2172,34 +2197,23 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
                 ],
-                "summary": "Criar item de Vade Mecum",
+                "summary": "Revogar link do caderno",
                 "parameters": [
                     {
-                        "description": "Dados do Vade Mecum",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumRequest"
-                        }
+                        "type": "string",
+                        "description": "ID do caderno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2210,8 +2224,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2222,20 +2245,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/category/{category}": {
-            "get": {
+        "/cadernos/{id}/progresso/reiniciar": {
+            "post": {
+                "description": "O progresso passa a contar apenas as respostas dadas a partir de agora.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
                 ],
-                "summary": "Listar itens por categoria",
+                "summary": "Reiniciar progresso no caderno",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Categoria",
-                        "name": "category",
+                        "description": "ID do caderno",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2244,10 +2268,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
-                            }
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoProgressoResumo"
                         }
                     },
                     "400": {
@@ -2259,8 +2280,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2269,41 +2299,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/cadernos/{id}/questoes": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
                 ],
-                "summary": "Criar item específico por categoria",
+                "summary": "Listar questoes do caderno",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Categoria",
-                        "name": "category",
+                        "description": "ID do caderno",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumRequest"
-                        }
+                        "type": "integer",
+                        "description": "Página (a partir de 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoPage"
                         }
                     },
                     "400": {
@@ -2315,8 +2347,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2325,10 +2366,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/category/{category}/{id}": {
-            "put": {
+            },
+            "post": {
+                "description": "Acrescenta as questoes ao fim da lista; questoes repetidas sao ignoradas.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2336,31 +2376,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
                 ],
-                "summary": "Atualizar item dentro de uma categoria",
+                "summary": "Adicionar questoes ao caderno",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Categoria",
-                        "name": "category",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID",
+                        "description": "ID do caderno",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados",
+                        "description": "Questoes",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoQuestoesRequest"
                         }
                     }
                 ],
@@ -2368,7 +2401,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoDetail"
                         }
                     },
                     "400": {
@@ -2380,6 +2413,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2389,8 +2431,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2399,24 +2441,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/cadernos/{id}/questoes/{questaoId}": {
             "delete": {
                 "tags": [
-                    "vade-mecum"
+                    "cadernos"
                 ],
-                "summary": "Remover item de uma categoria",
+                "summary": "Remover questao do caderno",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Categoria",
-                        "name": "category",
+                        "description": "ID do caderno",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
+                        "type": "integer",
+                        "description": "ID da questao",
+                        "name": "questaoId",
                         "in": "path",
                         "required": true
                     }
@@ -2434,39 +2478,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/codigos": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Listar codigos",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2475,34 +2506,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/correcoes": {
+            "get": {
+                "description": "Sem status, lista as respostas disponiveis e as reservadas pelo corretor, das mais antigas para as mais novas. pendente lista as disponiveis (inclusive reservas vencidas), em_correcao as reservadas pelo corretor e corrigida as que ele corrigiu, das mais recentes para as mais antigas.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-codigos"
+                    "correcoes"
                 ],
-                "summary": "Criar item na categoria codigos",
+                "summary": "Fila de correcao de respostas discursivas (corretor)",
                 "parameters": [
                     {
-                        "description": "Dados do codigo",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumCodigoRequest"
-                        }
+                        "type": "string",
+                        "description": "pendente, em_correcao ou corrigida",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplina da questao (repetível)",
+                        "name": "disciplina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID da questao",
+                        "name": "questao_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por pagina (padrao 20, maximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursivaList"
                         }
                     },
                     "400": {
@@ -2514,6 +2570,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2526,35 +2600,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/codigos/capas": {
+        "/correcoes/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-codigos"
+                    "correcoes"
                 ],
-                "summary": "Listar capas de códigos ou buscar por nome específico",
+                "summary": "Obter resposta discursiva para correcao (corretor)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filtro por nomecodigo",
-                        "name": "nomecodigo",
-                        "in": "query"
+                        "description": "ID da resposta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
-                            }
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2562,48 +2634,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Criar capa de vade-mécum código",
-                "parameters": [
-                    {
-                        "description": "Dados da capa",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumCodigoRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2611,8 +2652,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2632,8 +2673,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/codigos/capas/{id}": {
-            "put": {
+        "/correcoes/{id}/corrigir": {
+            "post": {
+                "description": "Uma nota por item da rubrica, na ordem da rubrica, entre zero e a pontuacao do item, com comentarios opcionais. A resposta precisa estar reservada pelo corretor. Com 60% ou mais dos pontos a resposta conta como acerto no desempenho do aluno, que e notificado da nota.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2641,24 +2683,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-codigos"
+                    "correcoes"
                 ],
-                "summary": "Atualizar capa de vade-mécum código",
+                "summary": "Corrigir resposta discursiva (corretor)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da capa",
+                        "description": "ID da resposta",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Campos para atualização",
+                        "description": "Correcao",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumCodigoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CorrigirRespostaDiscursivaRequest"
                         }
                     }
                 ],
@@ -2666,7 +2708,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
                         }
                     },
                     "400": {
@@ -2678,8 +2720,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2687,46 +2729,30 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/codigos/grouped": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Listar codigos agrupados por nomecodigo",
-                "parameters": [
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Prioridade dos grupos, pode ser separado por vírgula ou repetido",
-                        "name": "priority",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigoGroup"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -2742,38 +2768,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/codigos/import": {
+        "/correcoes/{id}/liberar": {
             "post": {
-                "description": "Importa registros utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "vade-mecum-codigos"
+                    "correcoes"
                 ],
-                "summary": "Importar codigos via Excel",
+                "summary": "Liberar resposta discursiva reservada (corretor)",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ID da resposta",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2781,50 +2805,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/codigos/import/estatuto": {
-            "post": {
-                "description": "Importa registros de estatuto utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Importar estatutos via Excel",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2844,19 +2844,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/codigos/{id}": {
-            "get": {
+        "/correcoes/{id}/reservar": {
+            "post": {
+                "description": "Reserva a resposta para o corretor por 24 horas; depois disso ela volta para a fila. Reservar de novo renova o prazo.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-codigos"
+                    "correcoes"
                 ],
-                "summary": "Obter codigo por ID",
+                "summary": "Reservar resposta discursiva (corretor)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID da resposta",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2866,7 +2867,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
                         }
                     },
                     "400": {
@@ -2878,55 +2879,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Atualizar codigo",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumCodigoRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2942,29 +2905,9 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "delete": {
-                "tags": [
-                    "vade-mecum-codigos"
-                ],
-                "summary": "Remover codigo",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2984,22 +2927,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/constituicao": {
+        "/cursos": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "cursos"
                 ],
-                "summary": "Listar constituições",
+                "summary": "Listar cursos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3022,17 +2974,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "cursos"
                 ],
-                "summary": "Criar constituição",
+                "summary": "Criar curso",
                 "parameters": [
                     {
-                        "description": "Dados",
+                        "description": "Curso",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumConstituicaoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseRequest"
                         }
                     }
                 ],
@@ -3040,7 +2992,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
                         }
                     },
                     "400": {
@@ -3052,6 +3004,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3064,22 +3025,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/constituicao/gruposervico": {
+        "/cursos/categorias": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "categorias"
                 ],
-                "summary": "Listar grupos de constituição por titulo (grupo servico)",
+                "summary": "Listar categorias",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicaoGrupoServico"
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3093,36 +3063,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/constituicao/import": {
+            },
             "post": {
-                "description": "Importa registros utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "categorias"
                 ],
-                "summary": "Importar constituição via Excel",
+                "summary": "Criar categoria",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Categoria",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
                         }
                     },
                     "400": {
@@ -3134,45 +3102,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/constituicao/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-constituicao"
-                ],
-                "summary": "Obter constituição por ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3180,8 +3120,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3190,7 +3130,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/cursos/categorias/{id}": {
             "put": {
                 "consumes": [
                     "application/json"
@@ -3199,24 +3141,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "categorias"
                 ],
-                "summary": "Atualizar constituição",
+                "summary": "Atualizar categoria",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID da categoria",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Campos para atualização",
+                        "description": "Categoria",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumConstituicaoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseCategoryRequest"
                         }
                     }
                 ],
@@ -3224,7 +3166,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
                         }
                     },
                     "400": {
@@ -3236,9 +3178,27 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
@@ -3249,13 +3209,13 @@ const docTemplate = `{
             },
             "delete": {
                 "tags": [
-                    "vade-mecum-constituicao"
+                    "categorias"
                 ],
-                "summary": "Remover constituição",
+                "summary": "Remover categoria",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID da categoria",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3274,34 +3234,21 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/estatutos": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-estatutos"
-                ],
-                "summary": "Listar estatutos",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3315,8 +3262,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/cursos/{id}": {
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -3324,25 +3273,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-estatutos"
+                    "cursos"
                 ],
-                "summary": "Criar estatuto",
+                "summary": "Atualizar curso",
                 "parameters": [
                     {
-                        "description": "Dados do estatuto",
+                        "type": "string",
+                        "description": "ID do curso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Curso",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumEstatutoRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
                         }
                     },
                     "400": {
@@ -3354,34 +3310,21 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/vade-mecum/estatutos/gruposervico": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-estatutos"
-                ],
-                "summary": "Listar grupos de estatutos por nomecodigo (grupo servico)",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatutoGrupoServico"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3395,32 +3338,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/estatutos/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "vade-mecum-estatutos"
+                    "cursos"
                 ],
-                "summary": "Obter estatuto por ID",
+                "summary": "Remover curso",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID do curso",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3431,6 +3366,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3439,43 +3383,60 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/discursivas": {
+            "get": {
+                "description": "Das mais recentes para as mais antigas, com a questao e, quando corrigidas, as notas por item da rubrica e os comentarios.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-estatutos"
+                    "discursivas"
                 ],
-                "summary": "Atualizar estatuto",
+                "summary": "Listar minhas respostas discursivas",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "pendente, em_correcao ou corrigida",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Campos para atualização",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumEstatutoRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID da questao",
+                        "name": "questao_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por pagina (padrao 20, maximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursivaList"
                         }
                     },
                     "400": {
@@ -3487,8 +3448,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3497,24 +3467,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/discursivas/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "vade-mecum-estatutos"
+                    "discursivas"
                 ],
-                "summary": "Remover estatuto",
+                "summary": "Obter minha resposta discursiva",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID da resposta",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3525,6 +3503,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3537,27 +3533,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/jurisprudencia": {
+        "/health": {
             "get": {
+                "description": "Verifica se a API está funcionando",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "health"
                 ],
-                "summary": "Listar jurisprudências",
+                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3566,47 +3554,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "Retorna os dados do usuário autenticado",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
-                ],
-                "summary": "Criar jurisprudência",
-                "parameters": [
-                    {
-                        "description": "Dados do registro",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumJurisprudenciaRequest"
-                        }
-                    }
+                    "me"
                 ],
+                "summary": "Obter meu perfil",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3614,8 +3582,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3624,37 +3592,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/jurisprudencia/capas": {
-            "get": {
+            },
+            "delete": {
+                "description": "Exclui a conta do usuário autenticado (eliminação LGPD): dados pessoais são anonimizados ou removidos e os registros financeiros são mantidos sem dados pessoais. Contas com senha precisam confirmá-la; contas sem senha precisam de login feito há até 5 minutos",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Listar capas de jurisprudência ou buscar por nome específico",
+                "summary": "Excluir minha conta",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filtro por nomecodigo",
-                        "name": "nomecodigo",
-                        "in": "query"
+                        "description": "Confirmação de senha",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DeleteAccountRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3662,8 +3634,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3673,7 +3654,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
+                "description": "Atualiza nome e/ou e-mail do usuário autenticado. Trocar o e-mail exige current_password (ou, em contas sem senha, login feito há até 5 minutos) e reinicia a verificação",
                 "consumes": [
                     "application/json"
                 ],
@@ -3681,25 +3663,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Criar capa para jurisprudência",
+                "summary": "Atualizar meu perfil",
                 "parameters": [
                     {
-                        "description": "Dados da capa",
+                        "description": "Campos a atualizar",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumJurisprudenciaRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateProfileRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
                         }
                     },
                     "400": {
@@ -3711,8 +3693,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3720,8 +3702,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3732,41 +3723,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/jurisprudencia/capas/{id}": {
-            "put": {
+        "/me/avatar": {
+            "post": {
+                "description": "Envia uma imagem (JPEG, PNG, WebP ou GIF, até 2MB) armazenada como MediaAsset",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Atualizar capa de jurisprudência",
+                "summary": "Enviar meu avatar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Identificador da capa",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Imagem do avatar",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
-                    },
-                    {
-                        "description": "Campos para atualização",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumJurisprudenciaRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
                         }
                     },
                     "400": {
@@ -3778,17 +3761,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3799,28 +3773,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/jurisprudencia/grouped": {
+        "/me/data-export": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Listar jurisprudencias agrupadas por nomecodigo",
-                "operationId": "GetVadeMecumJurisprudenciaGrouped",
+                "summary": "Listar minhas exportações de dados",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudenciaGroup"
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DataExport"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3829,49 +3802,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/jurisprudencia/import": {
+            },
             "post": {
-                "description": "Recebe um arquivo .xlsx com colunas específicas (vademecum_Jurisprudencia)",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Inicia a geração assíncrona de um ZIP com arquivos JSON contendo todos os dados ligados ao usuário (LGPD). Se já houver uma exportação em andamento ela é retornada",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
-                ],
-                "summary": "Importar jurisprudências via Excel",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "me"
                 ],
+                "summary": "Solicitar exportação dos meus dados",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DataExport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3882,19 +3831,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/jurisprudencia/{id}": {
+        "/me/data-export/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Buscar jurisprudência por ID",
+                "summary": "Status da exportação de dados",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "Export ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3904,7 +3853,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DataExport"
                         }
                     },
                     "400": {
@@ -3916,8 +3865,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3925,8 +3874,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3935,41 +3884,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/me/data-export/{id}/download": {
+            "get": {
+                "description": "Retorna o arquivo ZIP de uma exportação concluída e ainda não expirada",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "vade-mecum-jurisprudencia"
+                    "me"
                 ],
-                "summary": "Atualizar jurisprudência",
+                "summary": "Baixar exportação de dados",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "Export ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Campos para atualização",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumJurisprudenciaRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -3981,8 +3921,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3990,37 +3930,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "delete": {
-                "tags": [
-                    "vade-mecum-jurisprudencia"
-                ],
-                "summary": "Remover jurisprudência",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4028,8 +3948,56 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notificacoes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Listar minhas notificacoes",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Apenas nao lidas",
+                        "name": "nao_lidas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por pagina (padrao 20, maximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.NotificacaoList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4049,22 +4017,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/leis": {
-            "get": {
+        "/me/notificacoes/lidas": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "me"
                 ],
-                "summary": "Listar leis",
+                "summary": "Marcar todas as notificacoes como lidas",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -4078,8 +4056,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/me/notificacoes/{id}/lida": {
+            "post": {
+                "tags": [
+                    "me"
+                ],
+                "summary": "Marcar notificacao como lida",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da notificacao",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/me/password": {
             "post": {
+                "description": "Altera a senha conferindo a senha atual (contas sem senha precisam de login feito há até 5 minutos); as demais sessões são encerradas",
                 "consumes": [
                     "application/json"
                 ],
@@ -4087,25 +4117,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "me"
                 ],
-                "summary": "Criar lei",
+                "summary": "Alterar minha senha",
                 "parameters": [
                     {
-                        "description": "Dados da lei",
+                        "description": "Senha atual e nova senha",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumLeiRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ChangePasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -4117,8 +4150,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4126,8 +4159,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4138,22 +4171,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/leis/gruposervico": {
+        "/me/perfil-estudo": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "me"
                 ],
-                "summary": "Listar grupos de leis por nomecodigo (grupo servico)",
+                "summary": "Obter perfil de estudo",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLeiGrupoServico"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.PerfilEstudo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -4167,36 +4206,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/leis/import": {
-            "post": {
-                "description": "Recebe um arquivo .xlsx e realiza o upsert das leis no banco",
+            },
+            "put": {
+                "description": "Bancas, concursos, cargos e orgaos alvo; listas vazias nao restringem as recomendacoes.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "me"
                 ],
-                "summary": "Importar leis via Excel",
+                "summary": "Atualizar perfil de estudo",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Perfil",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdatePerfilEstudoRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.PerfilEstudo"
                         }
                     },
                     "400": {
@@ -4208,6 +4246,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4220,19 +4267,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/leis/{id}": {
+        "/media/{id}": {
             "get": {
+                "description": "Retorna o conteúdo binário de um MediaAsset (ex: avatar)",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "media"
                 ],
-                "summary": "Obter lei por ID",
+                "summary": "Obter arquivo de mídia",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "Media asset ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4242,7 +4290,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -4264,41 +4312,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/meu-desempenho": {
+            "get": {
+                "description": "Um registro por dia, calculado a partir das respostas em /questoes/{id}/responder.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "meu-desempenho"
                 ],
-                "summary": "Atualizar lei",
+                "summary": "Listar desempenho do usuario",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data inicial (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_inicio",
+                        "in": "query"
                     },
                     {
-                        "description": "Campos para atualização",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumLeiRequest"
-                        }
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_fim",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserPerformance"
+                            }
                         }
                     },
                     "400": {
@@ -4310,8 +4357,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4329,24 +4376,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/meu-desempenho/discursivas": {
+            "get": {
+                "description": "Respostas discursivas corrigidas no periodo (pela data de envio), no geral e por disciplina, com as aprovadas (60% ou mais dos pontos) e o percentual medio dos pontos. Elas tambem entram nos totais diarios de /meu-desempenho.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "vade-mecum-leis"
+                    "meu-desempenho"
                 ],
-                "summary": "Remover lei",
+                "summary": "Desempenho nas questoes discursivas",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data inicial (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_inicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_fim",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DesempenhoDiscursivoResumo"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4357,8 +4418,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4378,63 +4439,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/oab": {
+        "/meu-desempenho/resumo": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meu-desempenho"
                 ],
-                "summary": "Listar conteúdo OAB",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vade-mecum-oab"
-                ],
-                "summary": "Criar registro OAB",
+                "summary": "Resumo do desempenho do usuario",
                 "parameters": [
                     {
-                        "description": "Dados do registro",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumOABRequest"
-                        }
+                        "type": "string",
+                        "description": "Data inicial (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_inicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (YYYY-MM-DD ou RFC3339)",
+                        "name": "data_fim",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserPerformanceSummary"
                         }
                     },
                     "400": {
@@ -4446,8 +4478,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4467,21 +4499,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/oab/capas": {
+        "/meus-cursos/itens": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Listar nomecodigo da OAB",
+                "summary": "Listar itens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
                                 "type": "string"
                             }
                         }
@@ -4505,17 +4546,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Criar capa para vade-mécum OAB",
+                "summary": "Criar item sem modulo",
                 "parameters": [
                     {
-                        "description": "Dados da capa",
+                        "description": "Item",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumOABRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseItemRequest"
                         }
                     }
                 ],
@@ -4523,7 +4564,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumOAB"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
                         }
                     },
                     "400": {
@@ -4535,8 +4576,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4556,7 +4606,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/oab/capas/{id}": {
+        "/meus-cursos/itens/{id}": {
             "put": {
                 "consumes": [
                     "application/json"
@@ -4565,24 +4615,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Atualizar capa OAB",
+                "summary": "Atualizar item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da capa",
+                        "description": "ID do item",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Campos para atualização",
+                        "description": "Item",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumOABRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseItemRequest"
                         }
                     }
                 ],
@@ -4590,7 +4640,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumOAB"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseItem"
                         }
                     },
                     "400": {
@@ -4602,6 +4652,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4621,40 +4680,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/oab/import": {
-            "post": {
-                "description": "Recebe um arquivo .xlsx para importar registros do Vade Mecum OAB",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Importar conteúdo OAB via Excel",
+                "summary": "Remover item",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Arquivo Excel (.xlsx)",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ID do item",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4674,42 +4738,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/vade-mecum/oab/{id}": {
+        "/meus-cursos/modulos": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
-                ],
-                "summary": "Obter registro OAB por ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "meus-cursos"
                 ],
+                "summary": "Listar modulos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4728,7 +4777,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -4736,32 +4785,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Atualizar registro OAB",
+                "summary": "Criar modulo",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Campos para atualização",
+                        "description": "Modulo",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumOABRequest"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCourseModuleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
                         }
                     },
                     "400": {
@@ -4773,6 +4815,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4792,26 +4843,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/meus-cursos/modulos/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "vade-mecum-oab"
+                    "meus-cursos"
                 ],
-                "summary": "Remover registro OAB",
+                "summary": "Atualizar modulo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID do modulo",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
+                    {
+                        "description": "Modulo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCourseModuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                        }
+                    },
+                    "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "object",
@@ -4820,6 +4891,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4839,32 +4919,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/vade-mecum/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "tags": [
-                    "vade-mecum"
+                    "meus-cursos"
                 ],
-                "summary": "Obter Vade Mecum por ID",
+                "summary": "Remover modulo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
+                        "description": "ID do modulo",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4875,6 +4947,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4883,43 +4964,240 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
+            }
+        },
+        "/plans": {
+            "get": {
+                "description": "Retorna todos os planos disponíveis e, para cada um, os usuários atrelados (quando houver)",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "plans"
+                ],
+                "summary": "Listar planos com usuários vinculados",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Plan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes": {
+            "get": {
+                "description": "Lista questoes paginadas. Filtros repetidos (banca=FGV\u0026banca=CESPE) combinam valores com OU. Com q, a busca textual (sem acentos) cobre titulo, enunciado, alternativas e comentario, ordena por relevancia e retorna trechos destacados",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vade-mecum"
+                    "questoes"
                 ],
-                "summary": "Atualizar Vade Mecum",
+                "summary": "Listar questoes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Busca textual (aceita aspas para frase exata, OR e -termo)",
+                        "name": "q",
+                        "in": "query"
                     },
                     {
-                        "description": "Dados para atualização",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumRequest"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplina (repetível)",
+                        "name": "disciplina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Assunto (repetível)",
+                        "name": "assunto",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Banca (repetível)",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Orgao (repetível)",
+                        "name": "orgao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cargo (repetível)",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Concurso (repetível)",
+                        "name": "concurso",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Area de conhecimento (repetível)",
+                        "name": "area_conhecimento",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tipo de questao (repetível)",
+                        "name": "tipo_questao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Nivel (repetível)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Dificuldade (repetível)",
+                        "name": "dificuldade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo",
+                        "name": "ano_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos minimo",
+                        "name": "acertos_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos maximo",
+                        "name": "acertos_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Anulada",
+                        "name": "anulada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Desatualizada",
+                        "name": "desatualizada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Questao oculta",
+                        "name": "questao_oculta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "relevancia (padrão com q), id, ano, dificuldade, acertos_percentual ou quantidade_resolucoes",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc ou desc (padrão asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (a partir de 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoPage"
                         }
                     },
                     "400": {
@@ -4931,15 +5209,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4951,23 +5220,221 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "vade-mecum"
+                    "questoes"
                 ],
-                "summary": "Remover Vade Mecum",
+                "summary": "Criar questao",
                 "parameters": [
+                    {
+                        "description": "Dados da questao",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateQuestaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/contador": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Contar questoes",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplina (repetível)",
+                        "name": "disciplina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Assunto (repetível)",
+                        "name": "assunto",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Banca (repetível)",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Orgao (repetível)",
+                        "name": "orgao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cargo (repetível)",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Concurso (repetível)",
+                        "name": "concurso",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Area de conhecimento (repetível)",
+                        "name": "area_conhecimento",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tipo de questao (repetível)",
+                        "name": "tipo_questao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Nivel (repetível)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Dificuldade (repetível)",
+                        "name": "dificuldade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo",
+                        "name": "ano_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos minimo",
+                        "name": "acertos_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos maximo",
+                        "name": "acertos_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Anulada",
+                        "name": "anulada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Desatualizada",
+                        "name": "desatualizada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Questao oculta",
+                        "name": "questao_oculta",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Busca textual",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoCountResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4989,534 +5456,8142 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_thepantheon_api_internal_model.AsaasCreditCard": {
-            "type": "object",
-            "required": [
-                "ccv",
-                "expiryMonth",
-                "expiryYear",
-                "holderName",
-                "number"
-            ],
-            "properties": {
-                "ccv": {
-                    "type": "string"
-                },
-                "expiryMonth": {
-                    "type": "string"
-                },
-                "expiryYear": {
-                    "type": "string"
-                },
-                "holderName": {
-                    "type": "string"
-                },
-                "number": {
-                    "type": "string"
+        },
+        "/questoes/dispositivos/detectar": {
+            "post": {
+                "description": "Roda em segundo plano; use apos importar o vade-mecum.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Detectar dispositivos em todas as questoes (admin)",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.AsaasCreditCardHolderInfo": {
-            "type": "object",
-            "required": [
-                "addressNumber",
-                "cpfCnpj",
-                "email",
-                "name",
-                "phone",
-                "postalCode"
-            ],
-            "properties": {
-                "addressNumber": {
-                    "type": "string"
-                },
-                "cpfCnpj": {
+        "/questoes/estatisticas/recalcular": {
+            "post": {
+                "description": "Roda em segundo plano; normalmente o recalculo e periodico e so considera as questoes respondidas ou recorrigidas desde a ultima execucao.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Recalcular as estatisticas da plataforma de todas as questoes (admin)",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/export": {
+            "get": {
+                "description": "Baixa as questoes que atendem aos filtros, ordenadas por id, em .jsonl (todos os campos) ou .xlsx (colunas da importacao mais id; celulas acima de 32767 caracteres ficam vazias). O arquivo pode ser reimportado em /questoes/import.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Exportar questoes (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "xlsx (padrao) ou jsonl",
+                        "name": "formato",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Busca textual",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplina (repetível)",
+                        "name": "disciplina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Assunto (repetível)",
+                        "name": "assunto",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Banca (repetível)",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Orgao (repetível)",
+                        "name": "orgao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cargo (repetível)",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Concurso (repetível)",
+                        "name": "concurso",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Area de conhecimento (repetível)",
+                        "name": "area_conhecimento",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tipo de questao (repetível)",
+                        "name": "tipo_questao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Nivel (repetível)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Dificuldade (repetível)",
+                        "name": "dificuldade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo",
+                        "name": "ano_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos minimo",
+                        "name": "acertos_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos maximo",
+                        "name": "acertos_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Anulada",
+                        "name": "anulada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Desatualizada",
+                        "name": "desatualizada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Questao oculta",
+                        "name": "questao_oculta",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/filtros": {
+            "get": {
+                "description": "Retorna os valores ainda alcancaveis a partir dos filtros informados, com o total de questoes de cada um. Cada faceta ignora o proprio filtro.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Listar filtros de questoes com contagens",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplina (repetível)",
+                        "name": "disciplina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Assunto (repetível)",
+                        "name": "assunto",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Banca (repetível)",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Orgao (repetível)",
+                        "name": "orgao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cargo (repetível)",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Concurso (repetível)",
+                        "name": "concurso",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Area de conhecimento (repetível)",
+                        "name": "area_conhecimento",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tipo de questao (repetível)",
+                        "name": "tipo_questao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Nivel (repetível)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Dificuldade (repetível)",
+                        "name": "dificuldade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo",
+                        "name": "ano_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos minimo",
+                        "name": "acertos_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Percentual de acertos maximo",
+                        "name": "acertos_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Anulada",
+                        "name": "anulada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Desatualizada",
+                        "name": "desatualizada",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Questao oculta",
+                        "name": "questao_oculta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Busca textual",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFiltersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/import": {
+            "post": {
+                "description": "Recebe um arquivo .xlsx, .csv ou .jsonl com as colunas (ou campos) de CreateQuestaoRequest e faz o upsert por id_questao ou, na falta dele, id_questao_original. Celulas vazias nao alteram a questao. Cada linha e salva separadamente e as que falharem sao listadas em erros.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Importar questoes em lote (admin)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo (.xlsx, .csv ou .jsonl)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "xlsx, csv ou jsonl (padrao: extensao do arquivo)",
+                        "name": "formato",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/recomendadas": {
+            "get": {
+                "description": "Questoes nao respondidas nos ultimos 30 dias dos assuntos em que o acerto do usuario mais fica abaixo do esperado para a dificuldade das questoes, dentro do perfil de estudo (/me/perfil-estudo). O que faltar e completado com questoes do perfil.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Questoes recomendadas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quantidade (padrao 10, maximo 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Disciplinas",
+                        "name": "disciplina",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestoesRecomendadas"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/reportes": {
+            "get": {
+                "description": "Questoes reportadas agrupadas, das mais reportadas para as menos e, no empate, das mais antigas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Fila de moderacao de reportes (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pendente (padrao), resolvido ou rejeitado",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Motivo do reporte",
+                        "name": "motivo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por pagina (padrao 20, maximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoReporteFila"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}": {
+            "get": {
+                "description": "acertos_percentual e quantidade_resolucoes vem da fonte importada; estatisticas_plataforma traz o acerto e a distribuicao das respostas dos alunos da plataforma (primeira resposta de cada um), recalculados periodicamente.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Obter questao por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Atualizar questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualizacao",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateQuestaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Remover questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/discursivas": {
+            "post": {
+                "description": "O texto vai para a fila de correcao com a rubrica da questao. As linhas sao contadas como na folha de resposta (cada quebra de linha inicia uma linha e linhas longas quebram a cada 80 caracteres) e nao podem passar de linhas_maximas. So e possivel ter uma resposta aguardando correcao por questao; o usuario e notificado quando ela for corrigida.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Enviar resposta a questao discursiva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resposta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.EnviarRespostaDiscursivaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/dispositivos": {
+            "get": {
+                "description": "Artigos e sumulas vinculados a questao, detectados no enunciado e no comentario ou adicionados pela equipe, com o texto de cada um.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Dispositivos do vade-mecum citados pela questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoDispositivoItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Fontes: codigo, lei, estatuto, constituicao, oab ou jurisprudencia; dispositivo_id e o id do registro na fonte. Um vinculo removido volta como manual.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Vincular dispositivo a questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dispositivo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateQuestaoDispositivoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoDispositivo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/dispositivos/detectar": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Detectar dispositivos citados pela questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoDispositivoItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/dispositivos/{vinculoId}": {
+            "delete": {
+                "description": "Vinculos detectados ficam descartados e nao voltam em novas deteccoes.",
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Desvincular dispositivo da questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do vinculo",
+                        "name": "vinculoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/historico": {
+            "get": {
+                "description": "Versoes da questao, da mais recente para a mais antiga, com os campos alterados (valor anterior e novo), o editor e quantas respostas foram recorrigidas por mudanca de gabarito ou anulacao.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Historico de edicoes da questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoVersao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/reportar": {
+            "post": {
+                "description": "Motivos: gabarito_errado, desatualizada, formatacao, enunciado ou outro. O usuario e notificado quando o reporte for analisado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Reportar erro em questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reporte",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ReportarQuestaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoReporte"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/reportes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Listar reportes de uma questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoReporte"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/reportes/resolver": {
+            "post": {
+                "description": "Fecha os reportes pendentes da questao (ou apenas reporte_ids) como resolvido ou rejeitado. Ao resolver, anulada e desatualizada atualizam a questao; outras correcoes sao feitas em PUT /questoes/{id}. Cada usuario que reportou recebe uma notificacao.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Resolver reportes de uma questao (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolucao",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResolverReportesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResolverReportesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/responder": {
+            "post": {
+                "description": "Corrige a resposta pelo gabarito, registra a tentativa e retorna o comentario e a resolucao da banca. Questoes anuladas contam como acerto.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Responder questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resposta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResponderQuestaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResponderQuestaoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/questoes/{id}/tentativas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questoes"
+                ],
+                "summary": "Listar minhas tentativas na questao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestionAttempt"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/revisao/configuracoes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisao"
+                ],
+                "summary": "Obter configuracoes da revisao",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RevisaoConfig"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisao"
+                ],
+                "summary": "Atualizar configuracoes da revisao",
+                "parameters": [
+                    {
+                        "description": "Configuracoes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateRevisaoConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RevisaoConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/revisao/hoje": {
+            "get": {
+                "description": "Questoes erradas agendadas (SM-2) para revisar ate o fim do dia, respeitando o limite diario. A revisao e feita respondendo a questao em /questoes/{id}/responder.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisao"
+                ],
+                "summary": "Revisao do dia",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RevisaoHoje"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/revisao/questoes/{questaoId}": {
+            "delete": {
+                "description": "A questao volta para a revisao se for respondida errada de novo.",
+                "tags": [
+                    "revisao"
+                ],
+                "summary": "Tirar questao da revisao",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da questao",
+                        "name": "questaoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/simulados": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulados"
+                ],
+                "summary": "Listar meus simulados",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Simulado"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Sorteia as questoes de cada bloco (sem repetir questoes ja respondidas, anuladas, desatualizadas ou ocultas) e inicia o cronometro.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulados"
+                ],
+                "summary": "Gerar simulado",
+                "parameters": [
+                    {
+                        "description": "Blocos do simulado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateSimuladoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/simulados/{id}": {
+            "get": {
+                "description": "O gabarito e os comentarios so aparecem depois de finalizado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulados"
+                ],
+                "summary": "Obter simulado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do simulado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/simulados/{id}/finalizar": {
+            "post": {
+                "description": "Corrige o simulado, registra as respostas no desempenho e retorna o resultado por disciplina. Pode ser chamado de novo para obter o resultado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulados"
+                ],
+                "summary": "Finalizar simulado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do simulado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/simulados/{id}/questoes/{ordem}": {
+            "put": {
+                "description": "Grava ou troca a resposta da questao na posicao informada. Respostas depois do prazo sao recusadas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulados"
+                ],
+                "summary": "Responder questao do simulado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do simulado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Posicao da questao (a partir de 1)",
+                        "name": "ordem",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resposta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ResponderSimuladoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Lista usuários com busca por nome/e-mail, filtros, ordenação e totais",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Listar usuários (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Busca por nome ou e-mail",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Papel (user, admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do plano ou none para usuários sem plano",
+                        "name": "plan_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ativo",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provedor de login (local, google, facebook, ...)",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, updated_at, full_name, email ou role",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc ou desc (padrão desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/deactivate": {
+            "post": {
+                "description": "Desativa a conta e encerra todas as sessões; contas inativas não conseguem fazer login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Desativar usuário (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/erase": {
+            "post": {
+                "description": "Anonimiza os dados pessoais do usuário e dos clientes Asaas, remove o conteúdo pessoal e mantém os registros financeiros. Usado para atender solicitações de titulares recebidas fora da plataforma",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Eliminar dados de um usuário (LGPD)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.EraseUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.ErasureReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/plan": {
+            "put": {
+                "description": "Define o plano do usuário; plan_id nulo remove o plano",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Atribuir plano ao usuário (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Plano",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateUserPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reactivate": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reativar usuário (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "description": "Promove ou rebaixa um usuário (user, corretor, admin). As sessões do usuário são encerradas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Alterar papel do usuário (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo papel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Listar itens de Vade Mecum",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Criar item de Vade Mecum",
+                "parameters": [
+                    {
+                        "description": "Dados do Vade Mecum",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/category/{category}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Listar itens por categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Criar item específico por categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/category/{category}/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Atualizar item dentro de uma categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Remover item de uma categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Categoria",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos": {
+            "get": {
+                "description": "incidencia e o numero de questoes que citam o artigo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Listar codigos",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destas bancas na incidencia",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destes cargos na incidencia",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo das questoes contadas",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo das questoes contadas",
+                        "name": "ano_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Criar item na categoria codigos",
+                "parameters": [
+                    {
+                        "description": "Dados do codigo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumCodigoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/capas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Listar capas de códigos ou buscar por nome específico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro por nomecodigo",
+                        "name": "nomecodigo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Criar capa de vade-mécum código",
+                "parameters": [
+                    {
+                        "description": "Dados da capa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumCodigoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/capas/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Atualizar capa de vade-mécum código",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da capa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumCodigoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/grouped": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Listar codigos agrupados por nomecodigo",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Prioridade dos grupos, pode ser separado por vírgula ou repetido",
+                        "name": "priority",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigoGroup"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/import": {
+            "post": {
+                "description": "Importa registros utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Importar codigos via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/import/estatuto": {
+            "post": {
+                "description": "Importa registros de estatuto utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Importar estatutos via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/codigos/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Obter codigo por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Atualizar codigo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumCodigoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumCodigo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-codigos"
+                ],
+                "summary": "Remover codigo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/constituicao": {
+            "get": {
+                "description": "incidencia e o numero de questoes que citam o artigo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Listar constituições",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destas bancas na incidencia",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destes cargos na incidencia",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo das questoes contadas",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo das questoes contadas",
+                        "name": "ano_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Criar constituição",
+                "parameters": [
+                    {
+                        "description": "Dados",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumConstituicaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/constituicao/gruposervico": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Listar grupos de constituição por titulo (grupo servico)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicaoGrupoServico"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/constituicao/import": {
+            "post": {
+                "description": "Importa registros utilizando um arquivo Excel (.xlsx) com cabeçalho padrão",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Importar constituição via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/constituicao/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Obter constituição por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Atualizar constituição",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumConstituicaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumConstituicao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-constituicao"
+                ],
+                "summary": "Remover constituição",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/dispositivos/{fonte}/{id}/questoes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Questoes que citam um dispositivo do vade-mecum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "codigo, lei, estatuto, constituicao, oab ou jurisprudencia",
+                        "name": "fonte",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do registro na fonte",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (a partir de 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 20, máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/estatutos": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Listar estatutos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Criar estatuto",
+                "parameters": [
+                    {
+                        "description": "Dados do estatuto",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumEstatutoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/estatutos/gruposervico": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Listar grupos de estatutos por nomecodigo (grupo servico)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatutoGrupoServico"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/estatutos/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Obter estatuto por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Atualizar estatuto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumEstatutoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumEstatuto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-estatutos"
+                ],
+                "summary": "Remover estatuto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Listar jurisprudências",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Criar jurisprudência",
+                "parameters": [
+                    {
+                        "description": "Dados do registro",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumJurisprudenciaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia/capas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Listar capas de jurisprudência ou buscar por nome específico",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro por nomecodigo",
+                        "name": "nomecodigo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Criar capa para jurisprudência",
+                "parameters": [
+                    {
+                        "description": "Dados da capa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumJurisprudenciaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia/capas/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Atualizar capa de jurisprudência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da capa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumJurisprudenciaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia/grouped": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Listar jurisprudencias agrupadas por nomecodigo",
+                "operationId": "GetVadeMecumJurisprudenciaGrouped",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudenciaGroup"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia/import": {
+            "post": {
+                "description": "Recebe um arquivo .xlsx com colunas específicas (vademecum_Jurisprudencia)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Importar jurisprudências via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/jurisprudencia/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Buscar jurisprudência por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Atualizar jurisprudência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumJurisprudenciaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumJurisprudencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-jurisprudencia"
+                ],
+                "summary": "Remover jurisprudência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/leis": {
+            "get": {
+                "description": "incidencia e o numero de questoes que citam o artigo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Listar leis",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destas bancas na incidencia",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Conta so questoes destes cargos na incidencia",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo das questoes contadas",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo das questoes contadas",
+                        "name": "ano_max",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Criar lei",
+                "parameters": [
+                    {
+                        "description": "Dados da lei",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumLeiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/leis/gruposervico": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Listar grupos de leis por nomecodigo (grupo servico)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLeiGrupoServico"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/leis/import": {
+            "post": {
+                "description": "Recebe um arquivo .xlsx e realiza o upsert das leis no banco",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Importar leis via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/leis/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Obter lei por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Atualizar lei",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumLeiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumLei"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-leis"
+                ],
+                "summary": "Remover lei",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/oab": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Listar conteúdo OAB",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Criar registro OAB",
+                "parameters": [
+                    {
+                        "description": "Dados do registro",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateVadeMecumOABRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/oab/capas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Listar nomecodigo da OAB",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Criar capa para vade-mécum OAB",
+                "parameters": [
+                    {
+                        "description": "Dados da capa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CreateCapaVadeMecumOABRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumOAB"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/oab/capas/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Atualizar capa OAB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da capa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateCapaVadeMecumOABRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CapaVadeMecumOAB"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/oab/import": {
+            "post": {
+                "description": "Recebe um arquivo .xlsx para importar registros do Vade Mecum OAB",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Importar conteúdo OAB via Excel",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Arquivo Excel (.xlsx)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/oab/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Obter registro OAB por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Atualizar registro OAB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumOABRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumOAB"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum-oab"
+                ],
+                "summary": "Remover registro OAB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/ranking": {
+            "get": {
+                "description": "Ordena os dispositivos do vade-mecum pelo numero de questoes que os citam. Sem fonte, todas as fontes entram no mesmo ranking; diploma (nomecodigo) exige fonte.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Artigos mais cobrados",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "codigo, lei, estatuto, constituicao, oab ou jurisprudencia",
+                        "name": "fonte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do codigo ou da lei",
+                        "name": "diploma",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Bancas",
+                        "name": "banca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cargos",
+                        "name": "cargo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano minimo",
+                        "name": "ano_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano maximo",
+                        "name": "ano_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens (padrao 20, maximo 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deslocamento",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumRanking"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vade-mecum/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Obter Vade Mecum por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Atualizar Vade Mecum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UpdateVadeMecumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecum"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "vade-mecum"
+                ],
+                "summary": "Remover Vade Mecum",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_thepantheon_api_internal_model.AsaasCreditCard": {
+            "type": "object",
+            "required": [
+                "ccv",
+                "expiryMonth",
+                "expiryYear",
+                "holderName",
+                "number"
+            ],
+            "properties": {
+                "ccv": {
+                    "type": "string"
+                },
+                "expiryMonth": {
+                    "type": "string"
+                },
+                "expiryYear": {
+                    "type": "string"
+                },
+                "holderName": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AsaasCreditCardHolderInfo": {
+            "type": "object",
+            "required": [
+                "addressNumber",
+                "cpfCnpj",
+                "email",
+                "name",
+                "phone",
+                "postalCode"
+            ],
+            "properties": {
+                "addressNumber": {
+                    "type": "string"
+                },
+                "cpfCnpj": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "postalCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AsaasCustomerRequest": {
+            "type": "object",
+            "required": [
+                "cpfCnpj",
+                "email",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "cpfCnpj": {
+                    "type": "string",
+                    "example": "616.236.260-48"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "guilherme.matossouza@gmail.com"
+                },
+                "externalReference": {
+                    "type": "string"
+                },
+                "mobilePhone": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "teste "
+                },
+                "notificationDisabled": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "(22) 2 2222-2222"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AsaasPaymentConfirmationRequest": {
+            "type": "object",
+            "required": [
+                "creditCard",
+                "creditCardHolderInfo"
+            ],
+            "properties": {
+                "creditCard": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AsaasCreditCard"
+                },
+                "creditCardHolderInfo": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AsaasCreditCardHolderInfo"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AsaasPaymentRequest": {
+            "type": "object",
+            "required": [
+                "customer",
+                "dueDate",
+                "value"
+            ],
+            "properties": {
+                "billingType": {
+                    "type": "string"
+                },
+                "customer": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dueDate": {
+                    "type": "string"
+                },
+                "externalReference": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AsaasWebhookRequest": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "events",
+                "interrupted",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "authToken": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "interrupted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sendType": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.AssuntoDesempenho": {
+            "type": "object",
+            "properties": {
+                "acerto_esperado": {
+                    "type": "number"
+                },
+                "acerto_percentual": {
+                    "type": "number"
+                },
+                "acertos": {
+                    "type": "integer"
+                },
+                "assunto": {
+                    "type": "string"
+                },
+                "deficit": {
+                    "type": "number"
+                },
+                "disciplina": {
+                    "type": "string"
+                },
+                "tentativas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Caderno": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "filtros": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoQuestao"
+                    }
+                },
+                "share_token": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CadernoDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "filtros": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "progresso": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoProgressoResumo"
+                },
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CadernoQuestao"
+                    }
+                },
+                "share_token": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CadernoProgressoResumo": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "erros": {
+                    "type": "integer"
+                },
+                "iniciado_em": {
+                    "type": "string"
+                },
+                "percentual": {
+                    "type": "number"
+                },
+                "proxima_questao_id": {
+                    "type": "integer"
+                },
+                "respondidas": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CadernoQuestao": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ordem": {
+                    "type": "integer"
+                },
+                "questao_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CadernoQuestoesRequest": {
+            "type": "object",
+            "required": [
+                "questao_ids"
+            ],
+            "properties": {
+                "questao_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CadernoRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "filtros": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFilters"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "questao_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo": {
+            "type": "object",
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia": {
+            "type": "object",
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CapaVadeMecumOAB": {
+            "type": "object",
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "confirm",
+                "password"
+            ],
+            "properties": {
+                "confirm": {
+                    "type": "string"
+                },
+                "current_password": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CorrigirRespostaDiscursivaRequest": {
+            "type": "object",
+            "required": [
+                "notas"
+            ],
+            "properties": {
+                "comentario": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "notas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RubricaNotaRequest"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Course": {
+            "type": "object",
+            "properties": {
+                "categoria": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
+                },
+                "categoria_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imagem": {
+                    "type": "string"
+                },
+                "modulos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                    }
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CourseCategory": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "cursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imagem": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CourseItem": {
+            "type": "object",
+            "properties": {
+                "conteudo": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "modulo_id": {
+                    "type": "string"
+                },
+                "modulos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                    }
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CourseModule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "modulo": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateAdminRequest": {
+            "type": "object",
+            "required": [
+                "admin_secret",
+                "confirm",
+                "email",
+                "full_name",
+                "password"
+            ],
+            "properties": {
+                "admin_secret": {
+                    "type": "string"
+                },
+                "confirm": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumCodigoRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumJurisprudenciaRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumOABRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCourseCategoryRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "cursos_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imagem": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string",
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCourseItemRequest": {
+            "type": "object",
+            "required": [
+                "conteudo",
+                "tipo",
+                "titulo"
+            ],
+            "properties": {
+                "conteudo": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "modulo_id": {
+                    "type": "string"
+                },
+                "modulos_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tipo": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "titulo": {
+                    "type": "string",
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCourseModuleRequest": {
+            "type": "object",
+            "required": [
+                "modulo"
+            ],
+            "properties": {
+                "curso_id": {
+                    "type": "string"
+                },
+                "itens_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "modulo": {
+                    "type": "string",
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateCourseRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "categoria_id": {
+                    "type": "string"
+                },
+                "imagem": {
+                    "type": "string"
+                },
+                "modulos_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nome": {
+                    "type": "string",
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateQuestaoDispositivoRequest": {
+            "type": "object",
+            "required": [
+                "dispositivo_id",
+                "fonte"
+            ],
+            "properties": {
+                "dispositivo_id": {
+                    "type": "string"
+                },
+                "fonte": {
+                    "type": "string"
+                },
+                "referencia": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateQuestaoRequest": {
+            "type": "object",
+            "properties": {
+                "acertos_percentual": {
+                    "type": "number"
+                },
+                "alternativa_a": {
+                    "type": "string"
+                },
+                "alternativa_b": {
+                    "type": "string"
+                },
+                "alternativa_c": {
+                    "type": "string"
+                },
+                "alternativa_d": {
+                    "type": "string"
+                },
+                "alternativa_e": {
+                    "type": "string"
+                },
+                "ano": {
+                    "type": "integer"
+                },
+                "anulada": {
+                    "type": "boolean"
+                },
+                "area_conhecimento": {
+                    "type": "string"
+                },
+                "assunto": {
+                    "type": "string"
+                },
+                "banca": {
+                    "type": "string"
+                },
+                "campos_json": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cargo": {
+                    "type": "string"
+                },
+                "comentario": {
+                    "type": "string"
+                },
+                "concurso": {
+                    "type": "string"
+                },
+                "correcao_questao": {
+                    "type": "boolean"
+                },
+                "data_atualizacao": {
+                    "type": "string"
+                },
+                "data_captura": {
+                    "type": "string"
+                },
+                "desatualizada": {
+                    "type": "boolean"
+                },
+                "dificuldade": {
+                    "type": "string"
+                },
+                "disciplina": {
+                    "type": "string"
+                },
+                "enunciado": {
+                    "type": "string"
+                },
+                "formato_questao": {
+                    "type": "string"
+                },
+                "gabarito": {
+                    "type": "string"
+                },
+                "gabarito_preliminar": {
+                    "type": "boolean"
+                },
+                "html_completo": {
+                    "type": "string"
+                },
+                "id_questao": {
+                    "type": "string"
+                },
+                "id_questao_original": {
+                    "type": "string"
+                },
+                "instituicao": {
+                    "type": "string"
+                },
+                "linhas_maximas": {
+                    "type": "integer"
+                },
+                "localizacao": {
+                    "type": "string"
+                },
+                "nivel": {
+                    "type": "string"
+                },
+                "numero_alternativa_correta": {
+                    "type": "integer"
+                },
+                "orgao": {
+                    "type": "string"
+                },
+                "possui_resolucao_banca": {
+                    "type": "boolean"
+                },
+                "quantidade_resolucoes": {
+                    "type": "integer"
+                },
+                "questao_id": {
+                    "type": "integer"
+                },
+                "questao_oculta": {
+                    "type": "boolean"
+                },
+                "resolucao_banca": {
+                    "type": "string"
+                },
+                "resposta_correta": {
+                    "type": "string"
+                },
+                "rubrica": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRubricaItem"
+                    }
+                },
+                "tipo_prova": {
+                    "type": "string"
+                },
+                "tipo_questao": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateSimuladoRequest": {
+            "type": "object",
+            "required": [
+                "blocos",
+                "duracao_minutos"
+            ],
+            "properties": {
+                "blocos": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoBloco"
+                    }
+                },
+                "duracao_minutos": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 1
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "confirm",
+                "email",
+                "full_name",
+                "password"
+            ],
+            "properties": {
+                "confirm": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumCodigoRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "Normativo": {
+                    "type": "string"
+                },
+                "Ordem": {
+                    "type": "string"
+                },
+                "PARTE": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "capitulotexto": {
+                    "type": "string"
+                },
+                "idcapitulo": {
+                    "type": "string"
+                },
+                "idcodigo": {
+                    "type": "string"
+                },
+                "idlivro": {
+                    "type": "string"
+                },
+                "idsecao": {
+                    "type": "string"
+                },
+                "idsubsecao": {
+                    "type": "string"
+                },
+                "idsubtitulo": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "idtitulo": {
+                    "type": "string"
+                },
+                "livro": {
+                    "type": "string"
+                },
+                "livrotexto": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                },
+                "num_artigo": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "secaotexto": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "subtitulo": {
+                    "type": "string"
+                },
+                "subtitulotexto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "titulotexto": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumConstituicaoRequest": {
+            "type": "object",
+            "properties": {
+                "Normativo": {
+                    "type": "string"
+                },
+                "cabecalho": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "idcapitulo": {
+                    "type": "string"
+                },
+                "idsecao": {
+                    "type": "string"
+                },
+                "idsubsecao": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "idtitulo": {
+                    "type": "string"
+                },
+                "registro_id": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "textocapitulo": {
+                    "type": "string"
+                },
+                "textodotitulo": {
+                    "type": "string"
+                },
+                "textosecao": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumEstatutoRequest": {
+            "type": "object",
+            "properties": {
+                "Artigos": {
+                    "type": "string"
+                },
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "Ordem": {
+                    "type": "string"
+                },
+                "PARTE": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "capitulotexto": {
+                    "type": "string"
+                },
+                "idcapitulo": {
+                    "type": "string"
+                },
+                "idcodigo": {
+                    "type": "string"
+                },
+                "idlivro": {
+                    "type": "string"
+                },
+                "idsecao": {
+                    "type": "string"
+                },
+                "idsubsecao": {
+                    "type": "string"
+                },
+                "idsubtitulo": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "idtitulo": {
+                    "type": "string"
+                },
+                "livro": {
+                    "type": "string"
+                },
+                "livrotexto": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                },
+                "num_artigo": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "secaotexto": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "subtitulo": {
+                    "type": "string"
+                },
+                "subtitulotexto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "titulotexto": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumJurisprudenciaRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "Enunciado": {
+                    "type": "string"
+                },
+                "Normativo": {
+                    "type": "string"
+                },
+                "Ordem": {
+                    "type": "string"
+                },
+                "Tipo": {
+                    "type": "string"
+                },
+                "assuntotexto": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idassunto": {
+                    "type": "string"
+                },
+                "idcodigo": {
+                    "type": "string"
+                },
+                "idenunciado": {
+                    "type": "string"
+                },
+                "idramo": {
+                    "type": "string"
+                },
+                "idsecao": {
+                    "type": "string"
+                },
+                "idsubsecao": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                },
+                "num_artigo": {
+                    "type": "string"
+                },
+                "ramotexto": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "secaotexto": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumLeiRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Artigos": {
+                    "type": "string"
+                },
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "Ordem": {
+                    "type": "string"
+                },
+                "PARTE": {
+                    "type": "string"
+                },
+                "PARTETEXTO": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "capitulotexto": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idPARTE": {
+                    "type": "string"
+                },
+                "idcapitulo": {
+                    "type": "string"
+                },
+                "idsecao": {
+                    "type": "string"
+                },
+                "idsubsecao": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "idtitulo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                },
+                "num_artigo": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "secaotexto": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "titulotexto": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumOABRequest": {
+            "type": "object",
+            "required": [
+                "nomecodigo"
+            ],
+            "properties": {
+                "Artigos": {
+                    "type": "string"
+                },
+                "Cabecalho": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "capitulo_label": {
+                    "type": "string"
+                },
+                "capitulotexto": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idtipo": {
+                    "type": "string"
+                },
+                "nomecodigo": {
+                    "type": "string"
+                },
+                "num_artigo": {
+                    "type": "string"
+                },
+                "secao": {
+                    "type": "string"
+                },
+                "secao_label": {
+                    "type": "string"
+                },
+                "secaotexto": {
+                    "type": "string"
+                },
+                "subsecao": {
+                    "type": "string"
+                },
+                "subsecao_label": {
+                    "type": "string"
+                },
+                "subsecaotexto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "titulo_label": {
+                    "type": "string"
+                },
+                "titulotexto": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.CreateVadeMecumRequest": {
+            "type": "object",
+            "required": [
+                "cabecalho",
+                "capitulo",
+                "category",
+                "description",
+                "idcapitulo",
+                "idtitulo",
+                "textocapitulo",
+                "textodotitulo",
+                "title",
+                "titulo"
+            ],
+            "properties": {
+                "cabecalho": {
+                    "type": "string"
+                },
+                "capitulo": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "idcapitulo": {
+                    "type": "string"
+                },
+                "idtitulo": {
+                    "type": "string"
+                },
+                "textocapitulo": {
+                    "type": "string"
+                },
+                "textodotitulo": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.DataExport": {
+            "type": "object",
+            "properties": {
+                "concluido_em": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "erro": {
+                    "type": "string"
+                },
+                "expira_em": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tamanho": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.DeleteAccountRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.DesempenhoDiscursivo": {
+            "type": "object",
+            "properties": {
+                "aprovadas": {
+                    "type": "integer"
+                },
+                "corrigidas": {
+                    "type": "integer"
+                },
+                "disciplina": {
+                    "type": "string"
+                },
+                "percentual_medio": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.DesempenhoDiscursivoResumo": {
+            "type": "object",
+            "properties": {
+                "disciplinas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DesempenhoDiscursivo"
+                    }
+                },
+                "geral": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.DesempenhoDiscursivo"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.EnviarRespostaDiscursivaRequest": {
+            "type": "object",
+            "required": [
+                "texto"
+            ],
+            "properties": {
+                "texto": {
+                    "type": "string",
+                    "maxLength": 20000
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.EraseUserRequest": {
+            "type": "object",
+            "properties": {
+                "motivo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ErasureReport": {
+            "type": "object",
+            "properties": {
+                "clientes_anonimizados": {
+                    "type": "integer"
+                },
+                "pagamentos_retidos": {
+                    "type": "integer"
+                },
+                "removidos": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.LinkProviderRequest": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "id_token": {
+                    "description": "IDToken is required by OIDC providers.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.LinkProviderResponse": {
+            "type": "object",
+            "properties": {
+                "identity": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserIdentity"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                },
+                "two_factor_setup_required": {
+                    "type": "boolean"
+                },
+                "two_factor_token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Notificacao": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lida_em": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.NotificacaoList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Notificacao"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nao_lidas": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.PerfilEstudo": {
+            "type": "object",
+            "properties": {
+                "bancas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cargos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "concursos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "orgaos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Plan": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Questao": {
+            "type": "object",
+            "properties": {
+                "acertos_percentual": {
+                    "type": "number"
+                },
+                "alternativa_a": {
+                    "type": "string"
+                },
+                "alternativa_b": {
+                    "type": "string"
+                },
+                "alternativa_c": {
+                    "type": "string"
+                },
+                "alternativa_d": {
+                    "type": "string"
+                },
+                "alternativa_e": {
+                    "type": "string"
+                },
+                "ano": {
+                    "type": "integer"
+                },
+                "anulada": {
+                    "type": "boolean"
+                },
+                "area_conhecimento": {
+                    "type": "string"
+                },
+                "assunto": {
+                    "type": "string"
+                },
+                "banca": {
+                    "type": "string"
+                },
+                "campos_json": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cargo": {
+                    "type": "string"
+                },
+                "comentario": {
+                    "type": "string"
+                },
+                "concurso": {
+                    "type": "string"
+                },
+                "correcao_questao": {
+                    "type": "boolean"
+                },
+                "data_atualizacao": {
+                    "type": "string"
+                },
+                "data_captura": {
+                    "type": "string"
+                },
+                "desatualizada": {
+                    "type": "boolean"
+                },
+                "dificuldade": {
+                    "type": "string"
+                },
+                "disciplina": {
+                    "type": "string"
+                },
+                "enunciado": {
+                    "type": "string"
+                },
+                "estatisticas_plataforma": {
+                    "description": "EstatisticasPlataforma are the statistics of the platform's own\nanswers; only GetQuestaoByID fills them.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoEstatisticasPlataforma"
+                        }
+                    ]
+                },
+                "formato_questao": {
+                    "type": "string"
+                },
+                "gabarito": {
+                    "type": "string"
+                },
+                "gabarito_preliminar": {
+                    "type": "boolean"
+                },
+                "html_completo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "id_questao": {
+                    "type": "string"
+                },
+                "id_questao_original": {
+                    "type": "string"
+                },
+                "instituicao": {
+                    "type": "string"
+                },
+                "linhas_maximas": {
+                    "type": "integer"
+                },
+                "localizacao": {
+                    "type": "string"
+                },
+                "nivel": {
+                    "type": "string"
+                },
+                "numero_alternativa_correta": {
+                    "type": "integer"
+                },
+                "orgao": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "possui_resolucao_banca": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "quantidade_resolucoes": {
+                    "type": "integer"
                 },
-                "phone": {
+                "questao_id": {
+                    "type": "integer"
+                },
+                "questao_oculta": {
+                    "type": "boolean"
+                },
+                "resolucao_banca": {
                     "type": "string"
                 },
-                "postalCode": {
+                "resposta_correta": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.AsaasCustomerRequest": {
-            "type": "object",
-            "required": [
-                "cpfCnpj",
-                "email",
-                "name",
-                "phone"
-            ],
-            "properties": {
-                "cpfCnpj": {
-                    "type": "string",
-                    "example": "616.236.260-48"
                 },
-                "email": {
-                    "type": "string",
-                    "example": "guilherme.matossouza@gmail.com"
+                "rubrica": {
+                    "description": "Rubrica and LinhasMaximas only apply to discursive questions.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRubricaItem"
+                    }
                 },
-                "externalReference": {
+                "tipo_prova": {
                     "type": "string"
                 },
-                "mobilePhone": {
+                "tipo_questao": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "teste "
-                },
-                "notificationDisabled": {
-                    "type": "boolean"
+                "titulo": {
+                    "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "(22) 2 2222-2222"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.AsaasPaymentConfirmationRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoCountResponse": {
             "type": "object",
-            "required": [
-                "creditCard",
-                "creditCardHolderInfo"
-            ],
             "properties": {
-                "creditCard": {
-                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AsaasCreditCard"
-                },
-                "creditCardHolderInfo": {
-                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AsaasCreditCardHolderInfo"
+                "count": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.AsaasPaymentRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoDispositivo": {
             "type": "object",
-            "required": [
-                "customer",
-                "dueDate",
-                "value"
-            ],
             "properties": {
-                "billingType": {
+                "created_at": {
                     "type": "string"
                 },
-                "customer": {
+                "dispositivo_id": {
                     "type": "string"
                 },
-                "description": {
+                "fonte": {
                     "type": "string"
                 },
-                "dueDate": {
+                "id": {
                     "type": "string"
                 },
-                "externalReference": {
+                "origem": {
                     "type": "string"
                 },
-                "value": {
-                    "type": "number"
+                "questao_id": {
+                    "type": "integer"
+                },
+                "referencia": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.AsaasWebhookRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoDispositivoItem": {
             "type": "object",
-            "required": [
-                "enabled",
-                "events",
-                "interrupted",
-                "name",
-                "url"
-            ],
             "properties": {
-                "authToken": {
+                "artigo": {
                     "type": "string"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
-                },
-                "events": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "interrupted": {
-                    "type": "boolean"
+                "diploma": {
+                    "type": "string"
                 },
-                "name": {
+                "dispositivo_id": {
                     "type": "string"
                 },
-                "sendType": {
+                "fonte": {
                     "type": "string"
                 },
-                "url": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.CapaVadeMecumCodigo": {
-            "type": "object",
-            "properties": {
-                "Cabecalho": {
+                },
+                "origem": {
                     "type": "string"
                 },
-                "grupo": {
+                "questao_id": {
+                    "type": "integer"
+                },
+                "referencia": {
                     "type": "string"
                 },
-                "id": {
+                "texto": {
                     "type": "string"
                 },
-                "nomecodigo": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CapaVadeMecumJurisprudencia": {
+        "github_com_thepantheon_api_internal_model.QuestaoEstatisticasPlataforma": {
             "type": "object",
             "properties": {
-                "Cabecalho": {
-                    "type": "string"
+                "acertos": {
+                    "type": "integer"
                 },
-                "grupo": {
-                    "type": "string"
+                "acertos_percentual": {
+                    "type": "number"
                 },
-                "id": {
+                "atualizado_em": {
                     "type": "string"
                 },
-                "nomecodigo": {
-                    "type": "string"
+                "distribuicao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRespostaDistribuicao"
+                    }
+                },
+                "resolucoes": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CapaVadeMecumOAB": {
+        "github_com_thepantheon_api_internal_model.QuestaoFacetValue": {
             "type": "object",
             "properties": {
-                "Cabecalho": {
-                    "type": "string"
-                },
-                "grupo": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 },
-                "nomecodigo": {
+                "valor": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.Course": {
+        "github_com_thepantheon_api_internal_model.QuestaoFilters": {
             "type": "object",
             "properties": {
-                "categoria": {
-                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseCategory"
+                "acertos_max": {
+                    "type": "number"
                 },
-                "categoria_id": {
-                    "type": "string"
+                "acertos_min": {
+                    "type": "number"
                 },
-                "created_at": {
+                "ano_max": {
+                    "type": "integer"
+                },
+                "ano_min": {
+                    "type": "integer"
+                },
+                "anulada": {
+                    "type": "boolean"
+                },
+                "area_conhecimento": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "assunto": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "banca": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cargo": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "concurso": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "desatualizada": {
+                    "type": "boolean"
+                },
+                "dificuldade": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "disciplina": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nivel": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "order": {
                     "type": "string"
                 },
-                "id": {
+                "orgao": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "q": {
                     "type": "string"
                 },
-                "imagem": {
+                "questao_oculta": {
+                    "type": "boolean"
+                },
+                "sort": {
                     "type": "string"
                 },
-                "modulos": {
+                "tipo_questao": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.QuestaoFiltersResponse": {
+            "type": "object",
+            "properties": {
+                "ano": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
+                },
+                "area_conhecimento": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
+                },
+                "assunto": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
                     }
                 },
-                "nome": {
-                    "type": "string"
+                "banca": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "cargo": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.CourseCategory": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "concurso": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "cursos": {
+                "dificuldade": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Course"
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
                     }
                 },
-                "id": {
-                    "type": "string"
+                "disciplina": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "imagem": {
-                    "type": "string"
+                "nivel": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "nome": {
-                    "type": "string"
+                "orgao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "tipo_questao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoFacetValue"
+                    }
                 },
-                "user_id": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CourseItem": {
+        "github_com_thepantheon_api_internal_model.QuestaoImportErro": {
             "type": "object",
             "properties": {
-                "conteudo": {
+                "chave": {
                     "type": "string"
                 },
-                "created_at": {
+                "erro": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "linha": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.QuestaoImportReport": {
+            "type": "object",
+            "properties": {
+                "atualizadas": {
+                    "type": "integer"
                 },
-                "modulo_id": {
-                    "type": "string"
+                "criadas": {
+                    "type": "integer"
                 },
-                "modulos": {
+                "erros": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.CourseModule"
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoImportErro"
                     }
                 },
-                "tipo": {
-                    "type": "string"
-                },
-                "titulo": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+                "linhas": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CourseModule": {
+        "github_com_thepantheon_api_internal_model.QuestaoPage": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoResult"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "modulo": {
-                    "type": "string"
+                "page_size": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "total_pages": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateAdminRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoRecomendada": {
             "type": "object",
-            "required": [
-                "admin_secret",
-                "confirm",
-                "email",
-                "full_name",
-                "password"
-            ],
             "properties": {
-                "admin_secret": {
-                    "type": "string"
-                },
-                "confirm": {
-                    "type": "string"
+                "fraqueza": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AssuntoDesempenho"
                 },
-                "email": {
+                "motivo": {
                     "type": "string"
                 },
-                "full_name": {
-                    "type": "string",
-                    "minLength": 3
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "questao": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumCodigoRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoReporte": {
             "type": "object",
-            "required": [
-                "nomecodigo"
-            ],
             "properties": {
-                "Cabecalho": {
+                "created_at": {
                     "type": "string"
                 },
-                "grupo": {
+                "descricao": {
                     "type": "string"
                 },
-                "nomecodigo": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumJurisprudenciaRequest": {
-            "type": "object",
-            "required": [
-                "nomecodigo"
-            ],
-            "properties": {
-                "Cabecalho": {
+                },
+                "motivo": {
                     "type": "string"
                 },
-                "grupo": {
+                "questao_id": {
+                    "type": "integer"
+                },
+                "resolvido_em": {
                     "type": "string"
                 },
-                "nomecodigo": {
+                "resposta": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.CreateCapaVadeMecumOABRequest": {
-            "type": "object",
-            "required": [
-                "nomecodigo"
-            ],
-            "properties": {
-                "Cabecalho": {
+                },
+                "status": {
                     "type": "string"
                 },
-                "grupo": {
+                "updated_at": {
                     "type": "string"
                 },
-                "nomecodigo": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateCourseCategoryRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoReporteFila": {
             "type": "object",
-            "required": [
-                "nome"
-            ],
             "properties": {
-                "cursos_ids": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoReporteFilaItem"
                     }
                 },
-                "imagem": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "nome": {
-                    "type": "string",
-                    "minLength": 2
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateCourseItemRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoReporteFilaItem": {
             "type": "object",
-            "required": [
-                "conteudo",
-                "tipo",
-                "titulo"
-            ],
             "properties": {
-                "conteudo": {
-                    "type": "string",
-                    "minLength": 2
+                "ano": {
+                    "type": "integer"
                 },
-                "modulo_id": {
+                "anulada": {
+                    "type": "boolean"
+                },
+                "assunto": {
                     "type": "string"
                 },
-                "modulos_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "banca": {
+                    "type": "string"
                 },
-                "tipo": {
-                    "type": "string",
-                    "minLength": 2
+                "desatualizada": {
+                    "type": "boolean"
                 },
-                "titulo": {
-                    "type": "string",
-                    "minLength": 2
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.CreateCourseModuleRequest": {
-            "type": "object",
-            "required": [
-                "modulo"
-            ],
-            "properties": {
-                "curso_id": {
+                "disciplina": {
                     "type": "string"
                 },
-                "itens_ids": {
+                "motivos": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "modulo": {
-                    "type": "string",
-                    "minLength": 2
+                "primeiro_reporte": {
+                    "type": "string"
+                },
+                "questao_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "ultimo_reporte": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateCourseRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoRespostaDistribuicao": {
             "type": "object",
-            "required": [
-                "nome"
-            ],
             "properties": {
-                "categoria_id": {
-                    "type": "string"
+                "percentual": {
+                    "type": "number"
                 },
-                "imagem": {
+                "resposta": {
                     "type": "string"
                 },
-                "modulos_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "nome": {
-                    "type": "string",
-                    "minLength": 2
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateQuestaoRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoResult": {
             "type": "object",
             "properties": {
                 "acertos_percentual": {
@@ -5588,6 +13663,14 @@ const docTemplate = `{
                 "enunciado": {
                     "type": "string"
                 },
+                "estatisticas_plataforma": {
+                    "description": "EstatisticasPlataforma are the statistics of the platform's own\nanswers; only GetQuestaoByID fills them.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoEstatisticasPlataforma"
+                        }
+                    ]
+                },
                 "formato_questao": {
                     "type": "string"
                 },
@@ -5600,6 +13683,9 @@ const docTemplate = `{
                 "html_completo": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "id_questao": {
                     "type": "string"
                 },
@@ -5609,6 +13695,9 @@ const docTemplate = `{
                 "instituicao": {
                     "type": "string"
                 },
+                "linhas_maximas": {
+                    "type": "integer"
+                },
                 "localizacao": {
                     "type": "string"
                 },
@@ -5633,12 +13722,22 @@ const docTemplate = `{
                 "questao_oculta": {
                     "type": "boolean"
                 },
+                "rank": {
+                    "type": "number"
+                },
                 "resolucao_banca": {
                     "type": "string"
                 },
                 "resposta_correta": {
                     "type": "string"
                 },
+                "rubrica": {
+                    "description": "Rubrica and LinhasMaximas only apply to discursive questions.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRubricaItem"
+                    }
+                },
                 "tipo_prova": {
                     "type": "string"
                 },
@@ -5648,627 +13747,611 @@ const docTemplate = `{
                 "titulo": {
                     "type": "string"
                 },
+                "trecho": {
+                    "type": "string"
+                },
                 "url": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateUserPerformanceRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoRubricaItem": {
             "type": "object",
             "properties": {
-                "data_gravacao": {
+                "descricao": {
                     "type": "string"
                 },
-                "questoes_corretas": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "questoes_erradas": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "total_questoes": {
-                    "type": "integer",
-                    "minimum": 0
+                "pontuacao": {
+                    "type": "number"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateUserRequest": {
+        "github_com_thepantheon_api_internal_model.QuestaoVersao": {
             "type": "object",
-            "required": [
-                "confirm",
-                "email",
-                "full_name",
-                "password"
-            ],
             "properties": {
-                "confirm": {
+                "alteracoes": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "editor_id": {
                     "type": "string"
                 },
-                "full_name": {
-                    "type": "string",
-                    "minLength": 3
+                "id": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "questao_id": {
+                    "type": "integer"
+                },
+                "tentativas_recorrigidas": {
+                    "type": "integer"
+                },
+                "versao": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumCodigoRequest": {
+        "github_com_thepantheon_api_internal_model.QuestionAttempt": {
             "type": "object",
-            "required": [
-                "nomecodigo"
-            ],
             "properties": {
-                "Cabecalho": {
-                    "type": "string"
-                },
-                "Normativo": {
-                    "type": "string"
-                },
-                "Ordem": {
-                    "type": "string"
-                },
-                "PARTE": {
-                    "type": "string"
-                },
-                "capitulo": {
-                    "type": "string"
-                },
-                "capitulotexto": {
-                    "type": "string"
-                },
-                "idcapitulo": {
-                    "type": "string"
-                },
-                "idcodigo": {
-                    "type": "string"
-                },
-                "idlivro": {
-                    "type": "string"
-                },
-                "idsecao": {
-                    "type": "string"
-                },
-                "idsubsecao": {
-                    "type": "string"
-                },
-                "idsubtitulo": {
-                    "type": "string"
-                },
-                "idtipo": {
-                    "type": "string"
-                },
-                "idtitulo": {
-                    "type": "string"
-                },
-                "livro": {
-                    "type": "string"
-                },
-                "livrotexto": {
-                    "type": "string"
-                },
-                "nomecodigo": {
-                    "type": "string"
-                },
-                "num_artigo": {
-                    "type": "string"
-                },
-                "secao": {
-                    "type": "string"
+                "correta": {
+                    "type": "boolean"
                 },
-                "secaotexto": {
+                "created_at": {
                     "type": "string"
                 },
-                "subsecao": {
+                "gabarito": {
                     "type": "string"
                 },
-                "subsecaotexto": {
+                "id": {
                     "type": "string"
                 },
-                "subtitulo": {
-                    "type": "string"
+                "questao_id": {
+                    "type": "integer"
                 },
-                "subtitulotexto": {
+                "respondida_em": {
                     "type": "string"
                 },
-                "tipo": {
+                "resposta": {
                     "type": "string"
                 },
-                "titulo": {
-                    "type": "string"
+                "tempo_segundos": {
+                    "type": "integer"
                 },
-                "titulotexto": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumConstituicaoRequest": {
+        "github_com_thepantheon_api_internal_model.QuestoesRecomendadas": {
             "type": "object",
             "properties": {
-                "Normativo": {
-                    "type": "string"
-                },
-                "cabecalho": {
-                    "type": "string"
-                },
-                "capitulo": {
-                    "type": "string"
-                },
-                "idcapitulo": {
-                    "type": "string"
-                },
-                "idsecao": {
-                    "type": "string"
-                },
-                "idsubsecao": {
-                    "type": "string"
-                },
-                "idtipo": {
-                    "type": "string"
-                },
-                "idtitulo": {
-                    "type": "string"
-                },
-                "registro_id": {
-                    "type": "string"
-                },
-                "secao": {
-                    "type": "string"
-                },
-                "subsecao": {
-                    "type": "string"
-                },
-                "subsecaotexto": {
-                    "type": "string"
-                },
-                "textocapitulo": {
-                    "type": "string"
-                },
-                "textodotitulo": {
-                    "type": "string"
-                },
-                "textosecao": {
-                    "type": "string"
+                "fraquezas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.AssuntoDesempenho"
+                    }
                 },
-                "tipo": {
-                    "type": "string"
+                "perfil": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.PerfilEstudo"
                 },
-                "titulo": {
-                    "type": "string"
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRecomendada"
+                    }
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumEstatutoRequest": {
+        "github_com_thepantheon_api_internal_model.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "Artigos": {
-                    "type": "string"
-                },
-                "Cabecalho": {
-                    "type": "string"
-                },
-                "Ordem": {
-                    "type": "string"
-                },
-                "PARTE": {
-                    "type": "string"
-                },
-                "capitulo": {
-                    "type": "string"
-                },
-                "capitulotexto": {
-                    "type": "string"
-                },
-                "idcapitulo": {
-                    "type": "string"
-                },
-                "idcodigo": {
-                    "type": "string"
-                },
-                "idlivro": {
-                    "type": "string"
-                },
-                "idsecao": {
+                "refresh_token": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ReportarQuestaoRequest": {
+            "type": "object",
+            "required": [
+                "descricao",
+                "motivo"
+            ],
+            "properties": {
+                "descricao": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "idsubsecao": {
+                "motivo": {
                     "type": "string"
-                },
-                "idsubtitulo": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
-                },
-                "idtipo": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "confirm",
+                "password",
+                "token"
+            ],
+            "properties": {
+                "confirm": {
                     "type": "string"
                 },
-                "idtitulo": {
-                    "type": "string"
+                "password": {
+                    "type": "string",
+                    "minLength": 8
                 },
-                "livro": {
+                "token": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResolverReportesRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "anulada": {
+                    "type": "boolean"
                 },
-                "livrotexto": {
-                    "type": "string"
+                "desatualizada": {
+                    "type": "boolean"
                 },
-                "nomecodigo": {
-                    "type": "string"
+                "reporte_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "num_artigo": {
-                    "type": "string"
+                "resposta": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "secao": {
+                "status": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResolverReportesResponse": {
+            "type": "object",
+            "properties": {
+                "questao": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
                 },
-                "secaotexto": {
+                "reportes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoReporte"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResponderQuestaoRequest": {
+            "type": "object",
+            "required": [
+                "resposta"
+            ],
+            "properties": {
+                "resposta": {
                     "type": "string"
                 },
-                "subsecao": {
-                    "type": "string"
+                "tempo_segundos": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.ResponderQuestaoResponse": {
+            "type": "object",
+            "properties": {
+                "anulada": {
+                    "type": "boolean"
                 },
-                "subsecaotexto": {
+                "comentario": {
                     "type": "string"
                 },
-                "subtitulo": {
-                    "type": "string"
+                "correta": {
+                    "type": "boolean"
                 },
-                "subtitulotexto": {
+                "gabarito": {
                     "type": "string"
                 },
-                "tipo": {
+                "resolucao_banca": {
                     "type": "string"
                 },
-                "titulo": {
+                "resposta": {
                     "type": "string"
                 },
-                "titulotexto": {
+                "tentativa_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumJurisprudenciaRequest": {
+        "github_com_thepantheon_api_internal_model.ResponderSimuladoRequest": {
             "type": "object",
             "required": [
-                "nomecodigo"
+                "resposta"
             ],
             "properties": {
-                "Cabecalho": {
-                    "type": "string"
-                },
-                "Enunciado": {
-                    "type": "string"
-                },
-                "Normativo": {
+                "resposta": {
                     "type": "string"
-                },
-                "Ordem": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.RespostaDiscursiva": {
+            "type": "object",
+            "properties": {
+                "comentario": {
                     "type": "string"
                 },
-                "Tipo": {
+                "corrigida_em": {
                     "type": "string"
                 },
-                "assuntotexto": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "idassunto": {
-                    "type": "string"
-                },
-                "idcodigo": {
-                    "type": "string"
+                "linhas": {
+                    "type": "integer"
                 },
-                "idenunciado": {
-                    "type": "string"
+                "nota": {
+                    "type": "number"
                 },
-                "idramo": {
-                    "type": "string"
+                "nota_maxima": {
+                    "type": "number"
                 },
-                "idsecao": {
-                    "type": "string"
+                "questao": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
                 },
-                "idsubsecao": {
-                    "type": "string"
+                "questao_id": {
+                    "type": "integer"
                 },
-                "idtipo": {
+                "reservada_em": {
                     "type": "string"
                 },
-                "nomecodigo": {
-                    "type": "string"
+                "rubrica": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RubricaNota"
+                    }
                 },
-                "num_artigo": {
+                "status": {
                     "type": "string"
                 },
-                "ramotexto": {
+                "texto": {
                     "type": "string"
                 },
-                "secao": {
+                "updated_at": {
                     "type": "string"
                 },
-                "secaotexto": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.RespostaDiscursivaList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RespostaDiscursiva"
+                    }
                 },
-                "subsecao": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "subsecaotexto": {
-                    "type": "string"
+                "offset": {
+                    "type": "integer"
                 },
-                "tipo": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumLeiRequest": {
+        "github_com_thepantheon_api_internal_model.RevisaoConfig": {
             "type": "object",
-            "required": [
-                "nomecodigo"
-            ],
             "properties": {
-                "Artigos": {
-                    "type": "string"
-                },
-                "Cabecalho": {
-                    "type": "string"
+                "intervalo_maximo_dias": {
+                    "type": "integer"
                 },
-                "Ordem": {
-                    "type": "string"
+                "limite_diario": {
+                    "type": "integer"
                 },
-                "PARTE": {
-                    "type": "string"
+                "primeiro_intervalo_dias": {
+                    "type": "integer"
                 },
-                "PARTETEXTO": {
-                    "type": "string"
+                "segundo_intervalo_dias": {
+                    "type": "integer"
                 },
-                "capitulo": {
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.RevisaoHoje": {
+            "type": "object",
+            "properties": {
+                "limite": {
+                    "type": "integer"
                 },
-                "capitulotexto": {
-                    "type": "string"
+                "pendentes": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.RevisaoItem"
+                    }
                 },
-                "idPARTE": {
+                "revisadas_hoje": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.RevisaoItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "idcapitulo": {
-                    "type": "string"
+                "fator_facilidade": {
+                    "type": "number"
                 },
-                "idsecao": {
-                    "type": "string"
+                "intervalo_dias": {
+                    "type": "integer"
                 },
-                "idsubsecao": {
-                    "type": "string"
+                "lapsos": {
+                    "type": "integer"
                 },
-                "idtipo": {
+                "proxima_revisao": {
                     "type": "string"
                 },
-                "idtitulo": {
-                    "type": "string"
+                "questao": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Questao"
                 },
-                "nomecodigo": {
-                    "type": "string"
+                "questao_id": {
+                    "type": "integer"
                 },
-                "num_artigo": {
-                    "type": "string"
+                "repeticoes": {
+                    "type": "integer"
                 },
-                "secao": {
-                    "type": "string"
+                "revisoes": {
+                    "type": "integer"
                 },
-                "secaotexto": {
+                "ultima_revisao": {
                     "type": "string"
                 },
-                "subsecao": {
+                "updated_at": {
                     "type": "string"
-                },
-                "subsecaotexto": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.RubricaNota": {
+            "type": "object",
+            "properties": {
+                "comentario": {
                     "type": "string"
                 },
-                "tipo": {
+                "descricao": {
                     "type": "string"
                 },
-                "titulo": {
-                    "type": "string"
+                "nota": {
+                    "type": "number"
                 },
-                "titulotexto": {
-                    "type": "string"
+                "pontuacao": {
+                    "type": "number"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumOABRequest": {
+        "github_com_thepantheon_api_internal_model.RubricaNotaRequest": {
             "type": "object",
             "required": [
-                "nomecodigo"
+                "nota"
             ],
             "properties": {
-                "Artigos": {
-                    "type": "string"
-                },
-                "Cabecalho": {
-                    "type": "string"
-                },
-                "capitulo": {
-                    "type": "string"
-                },
-                "capitulo_label": {
-                    "type": "string"
-                },
-                "capitulotexto": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "idtipo": {
-                    "type": "string"
-                },
-                "nomecodigo": {
-                    "type": "string"
+                "comentario": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "num_artigo": {
-                    "type": "string"
+                "nota": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.Simulado": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
                 },
-                "secao": {
+                "created_at": {
                     "type": "string"
                 },
-                "secao_label": {
-                    "type": "string"
+                "duracao_minutos": {
+                    "type": "integer"
                 },
-                "secaotexto": {
+                "expira_em": {
                     "type": "string"
                 },
-                "subsecao": {
+                "finalizado_em": {
                     "type": "string"
                 },
-                "subsecao_label": {
+                "id": {
                     "type": "string"
                 },
-                "subsecaotexto": {
+                "iniciado_em": {
                     "type": "string"
                 },
-                "tipo": {
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoQuestao"
+                    }
+                },
+                "status": {
                     "type": "string"
                 },
                 "titulo": {
                     "type": "string"
                 },
-                "titulo_label": {
+                "total_questoes": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "titulotexto": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.CreateVadeMecumRequest": {
+        "github_com_thepantheon_api_internal_model.SimuladoBloco": {
             "type": "object",
             "required": [
-                "cabecalho",
-                "capitulo",
-                "category",
-                "description",
-                "idcapitulo",
-                "idtitulo",
-                "textocapitulo",
-                "textodotitulo",
-                "title",
-                "titulo"
+                "quantidade"
             ],
             "properties": {
-                "cabecalho": {
-                    "type": "string"
+                "assunto": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "capitulo": {
-                    "type": "string"
+                "banca": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "category": {
-                    "type": "string"
+                "disciplina": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "content": {
-                    "type": "string"
+                "quantidade": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.SimuladoDetail": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
                 },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "file_url": {
-                    "type": "string"
+                "duracao_minutos": {
+                    "type": "integer"
                 },
-                "idcapitulo": {
+                "expira_em": {
                     "type": "string"
                 },
-                "idtitulo": {
+                "finalizado_em": {
                     "type": "string"
                 },
-                "textocapitulo": {
+                "id": {
                     "type": "string"
                 },
-                "textodotitulo": {
+                "iniciado_em": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "minLength": 3
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoQuestaoView"
+                    }
+                },
+                "status": {
+                    "type": "string"
                 },
                 "titulo": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_thepantheon_api_internal_model.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                },
+                "total_questoes": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "password": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.LoginResponse": {
+        "github_com_thepantheon_api_internal_model.SimuladoDisciplinaResultado": {
             "type": "object",
             "properties": {
-                "expires_in": {
+                "acertos": {
                     "type": "integer"
                 },
-                "token": {
+                "disciplina": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                "em_branco": {
+                    "type": "integer"
+                },
+                "erros": {
+                    "type": "integer"
+                },
+                "percentual": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.Plan": {
+        "github_com_thepantheon_api_internal_model.SimuladoQuestao": {
             "type": "object",
             "properties": {
-                "active": {
+                "correta": {
                     "type": "boolean"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "duration": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "ordem": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "questao_id": {
+                    "type": "integer"
                 },
-                "phase": {
+                "respondida_em": {
                     "type": "string"
                 },
-                "updated_at": {
+                "resposta": {
                     "type": "string"
-                },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
-                    }
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.Questao": {
+        "github_com_thepantheon_api_internal_model.SimuladoQuestaoView": {
             "type": "object",
             "properties": {
-                "acertos_percentual": {
-                    "type": "number"
-                },
                 "alternativa_a": {
                     "type": "string"
                 },
@@ -6290,190 +14373,183 @@ const docTemplate = `{
                 "anulada": {
                     "type": "boolean"
                 },
-                "area_conhecimento": {
-                    "type": "string"
-                },
                 "assunto": {
                     "type": "string"
                 },
                 "banca": {
                     "type": "string"
                 },
-                "campos_json": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "cargo": {
-                    "type": "string"
-                },
                 "comentario": {
                     "type": "string"
                 },
-                "concurso": {
-                    "type": "string"
-                },
-                "correcao_questao": {
-                    "type": "boolean"
-                },
-                "data_atualizacao": {
-                    "type": "string"
-                },
-                "data_captura": {
-                    "type": "string"
-                },
-                "desatualizada": {
+                "correta": {
                     "type": "boolean"
                 },
-                "dificuldade": {
-                    "type": "string"
-                },
                 "disciplina": {
                     "type": "string"
                 },
                 "enunciado": {
                     "type": "string"
                 },
-                "formato_questao": {
-                    "type": "string"
-                },
                 "gabarito": {
                     "type": "string"
                 },
-                "gabarito_preliminar": {
-                    "type": "boolean"
-                },
-                "html_completo": {
-                    "type": "string"
+                "ordem": {
+                    "type": "integer"
                 },
-                "id": {
+                "questao_id": {
                     "type": "integer"
                 },
-                "id_questao": {
+                "resolucao_banca": {
                     "type": "string"
                 },
-                "id_questao_original": {
+                "respondida_em": {
                     "type": "string"
                 },
-                "instituicao": {
+                "resposta": {
                     "type": "string"
                 },
-                "localizacao": {
+                "tipo_questao": {
                     "type": "string"
                 },
-                "nivel": {
+                "titulo": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.SimuladoReport": {
+            "type": "object",
+            "properties": {
+                "geral": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoResultado"
                 },
-                "numero_alternativa_correta": {
-                    "type": "integer"
-                },
-                "orgao": {
-                    "type": "string"
+                "por_disciplina": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoDisciplinaResultado"
+                    }
                 },
-                "possui_resolucao_banca": {
-                    "type": "boolean"
+                "questoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.SimuladoQuestaoView"
+                    }
                 },
-                "quantidade_resolucoes": {
+                "simulado": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.Simulado"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.SimuladoResultado": {
+            "type": "object",
+            "properties": {
+                "acertos": {
                     "type": "integer"
                 },
-                "questao_id": {
+                "em_branco": {
                     "type": "integer"
                 },
-                "questao_oculta": {
-                    "type": "boolean"
+                "erros": {
+                    "type": "integer"
                 },
-                "resolucao_banca": {
-                    "type": "string"
+                "percentual": {
+                    "type": "number"
                 },
-                "resposta_correta": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.SocialAuthRequest": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "provider"
+            ],
+            "properties": {
+                "access_token": {
                     "type": "string"
                 },
-                "tipo_prova": {
+                "id_token": {
+                    "description": "IDToken is required by OIDC providers.",
                     "type": "string"
                 },
-                "tipo_questao": {
+                "provider": {
                     "type": "string"
-                },
-                "titulo": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
                     "type": "string"
-                },
-                "url": {
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.TwoFactorRecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.TwoFactorSetupRequest": {
+            "type": "object",
+            "properties": {
+                "two_factor_token": {
+                    "description": "TwoFactorToken is only needed when an admin enrolls during login,\nbefore holding an access token.",
                     "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.QuestaoCountResponse": {
+        "github_com_thepantheon_api_internal_model.TwoFactorSetupResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "otpauth_url": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.QuestaoFiltersResponse": {
+        "github_com_thepantheon_api_internal_model.TwoFactorStatusResponse": {
             "type": "object",
             "properties": {
-                "area_conhecimento": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "assunto": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "banca": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "cargo": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "enabled": {
+                    "type": "boolean"
                 },
-                "concurso": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "enabled_at": {
+                    "type": "string"
                 },
-                "disciplina": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "recovery_codes_remaining": {
+                    "type": "integer"
                 },
-                "orgao": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "required": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_thepantheon_api_internal_model.SocialAuthRequest": {
+        "github_com_thepantheon_api_internal_model.TwoFactorVerifyRequest": {
             "type": "object",
             "required": [
-                "access_token",
-                "provider"
+                "code",
+                "two_factor_token"
             ],
             "properties": {
-                "access_token": {
+                "code": {
                     "type": "string"
                 },
-                "provider": {
-                    "type": "string",
-                    "enum": [
-                        "google",
-                        "facebook"
-                    ]
+                "two_factor_token": {
+                    "type": "string"
                 }
             }
         },
@@ -6590,6 +14666,54 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_thepantheon_api_internal_model.UpdatePerfilEstudoRequest": {
+            "type": "object",
+            "properties": {
+                "bancas": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cargos": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "concursos": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "orgaos": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
         "github_com_thepantheon_api_internal_model.UpdateQuestaoRequest": {
             "type": "object",
             "properties": {
@@ -6683,6 +14807,9 @@ const docTemplate = `{
                 "instituicao": {
                     "type": "string"
                 },
+                "linhas_maximas": {
+                    "type": "integer"
+                },
                 "localizacao": {
                     "type": "string"
                 },
@@ -6713,6 +14840,12 @@ const docTemplate = `{
                 "resposta_correta": {
                     "type": "string"
                 },
+                "rubrica": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.QuestaoRubricaItem"
+                    }
+                },
                 "tipo_prova": {
                     "type": "string"
                 },
@@ -6727,6 +14860,56 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_thepantheon_api_internal_model.UpdateRevisaoConfigRequest": {
+            "type": "object",
+            "required": [
+                "intervalo_maximo_dias",
+                "limite_diario",
+                "primeiro_intervalo_dias",
+                "segundo_intervalo_dias"
+            ],
+            "properties": {
+                "intervalo_maximo_dias": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                },
+                "limite_diario": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "primeiro_intervalo_dias": {
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 1
+                },
+                "segundo_intervalo_dias": {
+                    "type": "integer",
+                    "maximum": 90,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UpdateUserPlanRequest": {
+            "type": "object",
+            "properties": {
+                "plan_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UpdateUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_thepantheon_api_internal_model.UpdateVadeMecumCodigoRequest": {
             "type": "object",
             "properties": {
@@ -7210,6 +15393,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified_at": {
+                    "type": "string"
+                },
                 "full_name": {
                     "type": "string"
                 },
@@ -7232,8 +15418,60 @@ const docTemplate = `{
                 "role": {
                     "type": "string"
                 },
+                "two_factor_enabled_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UserIdentity": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_user_id": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.User"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "$ref": "#/definitions/github_com_thepantheon_api_internal_model.UserTotals"
                 }
             }
         },
@@ -7286,6 +15524,53 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_thepantheon_api_internal_model.UserSessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.UserTotals": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "by_role": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "inactive": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_thepantheon_api_internal_model.VadeMecum": {
             "type": "object",
             "properties": {
@@ -7387,6 +15672,10 @@ const docTemplate = `{
                 "idtitulo": {
                     "type": "string"
                 },
+                "incidencia": {
+                    "description": "Incidencia counts the questions citing the article; only the listing fills it.",
+                    "type": "integer"
+                },
                 "livro": {
                     "type": "string"
                 },
@@ -7481,6 +15770,10 @@ const docTemplate = `{
                 "idtitulo": {
                     "type": "string"
                 },
+                "incidencia": {
+                    "description": "Incidencia counts the questions citing the dispositivo; only the listing fills it.",
+                    "type": "integer"
+                },
                 "registro_id": {
                     "type": "string"
                 },
@@ -7763,6 +16056,10 @@ const docTemplate = `{
                 "idtitulo": {
                     "type": "string"
                 },
+                "incidencia": {
+                    "description": "Incidencia counts the questions citing the article; only the listing fills it.",
+                    "type": "integer"
+                },
                 "nomecodigo": {
                     "type": "string"
                 },
@@ -7870,6 +16167,60 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "github_com_thepantheon_api_internal_model.VadeMecumRanking": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_thepantheon_api_internal_model.VadeMecumRankingItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.VadeMecumRankingItem": {
+            "type": "object",
+            "properties": {
+                "artigo": {
+                    "type": "string"
+                },
+                "diploma": {
+                    "type": "string"
+                },
+                "dispositivo_id": {
+                    "type": "string"
+                },
+                "fonte": {
+                    "type": "string"
+                },
+                "incidencia": {
+                    "type": "integer"
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_thepantheon_api_internal_model.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,10 @@ type Config struct {
 	OAuth    OAuthConfig
 	Admin    AdminConfig
 	Asaas    AsaasConfig
+	Mail     MailConfig
+	Throttle LoginThrottleConfig
+	Privacy  PrivacyConfig
+	Questao  QuestaoConfig
 }
 
 type OAuthConfig struct {
@@ -23,6 +28,16 @@ type OAuthConfig struct {
 	FacebookAppID      string
 	FacebookAppSecret  string
 	RedirectURL        string
+	OIDCProviders      []OIDCProviderConfig
+}
+
+// OIDCProviderConfig describes a generic OpenID Connect login provider.
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
 }
 
 type DatabaseConfig struct {
@@ -43,8 +58,9 @@ type ServerConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration string
+	Secret            string
+	Expiration        string
+	RefreshExpiration string
 }
 
 type CORSConfig struct {
@@ -55,11 +71,46 @@ type AdminConfig struct {
 	Secret string
 }
 
+// LoginThrottleConfig configures brute-force protection of credential checks.
+// Store is "postgres" (shared by every instance) or "memory".
+type LoginThrottleConfig struct {
+	Store           string
+	MaxAttempts     string
+	IPMaxAttempts   string
+	LockoutDuration string
+}
+
+// PrivacyConfig configures the LGPD flows. ExportTTL is how long a finished
+// data export stays available for download.
+type PrivacyConfig struct {
+	ExportTTL string
+}
+
+// QuestaoConfig configures the question bank. FacetsTTL is how long the facet
+// counts of /questoes/filtros are cached and how often the questao_facets
+// materialized view may be refreshed. EstatisticasInterval is how often the
+// platform statistics of the answered questions are recomputed.
+type QuestaoConfig struct {
+	FacetsTTL            string
+	EstatisticasInterval string
+}
+
 type AsaasConfig struct {
 	BaseURL string
 	Token   string
 }
 
+type MailConfig struct {
+	Driver      string
+	Host        string
+	Port        string
+	Username    string
+	Password    string
+	From        string
+	OutputDir   string
+	FrontendURL string
+}
+
 func LoadConfig() (*Config, error) {
 	// Load .env file
 	_ = godotenv.Load()
@@ -81,8 +132,9 @@ func LoadConfig() (*Config, error) {
 			Scheme: getEnv("SERVER_SCHEME", "http"),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your_secret_key"),
-			Expiration: getEnv("JWT_EXPIRATION", "24h"),
+			Secret:            getEnv("JWT_SECRET", "your_secret_key"),
+			Expiration:        getEnv("JWT_EXPIRATION", "15m"),
+			RefreshExpiration: getEnv("JWT_REFRESH_EXPIRATION", "720h"),
 		},
 		CORS: CORSConfig{
 			Origin: getEnv("CORS_ORIGIN", "http://localhost:3000"),
@@ -93,6 +145,7 @@ func LoadConfig() (*Config, error) {
 			FacebookAppID:      getEnv("FACEBOOK_APP_ID", ""),
 			FacebookAppSecret:  getEnv("FACEBOOK_APP_SECRET", ""),
 			RedirectURL:        getEnv("OAUTH_REDIRECT_URL", "http://localhost:8080/api/v1"),
+			OIDCProviders:      loadOIDCProviders(),
 		},
 		Admin: AdminConfig{
 			Secret: getEnv("ADMIN_SECRET", ""),
@@ -101,6 +154,29 @@ func LoadConfig() (*Config, error) {
 			BaseURL: getEnv("ASAAS_BASE_URL", "https://api-sandbox.asaas.com/"),
 			Token:   getEnv("ASAAS_TOKEN", ""),
 		},
+		Mail: MailConfig{
+			Driver:      getEnv("MAIL_DRIVER", "log"),
+			Host:        getEnv("SMTP_HOST", "localhost"),
+			Port:        getEnv("SMTP_PORT", "1025"),
+			Username:    getEnv("SMTP_USERNAME", ""),
+			Password:    getEnv("SMTP_PASSWORD", ""),
+			From:        getEnv("MAIL_FROM", "Pantheon Concursos <no-reply@pantheonconcursos.com.br>"),
+			OutputDir:   getEnv("MAIL_OUTPUT_DIR", ""),
+			FrontendURL: getEnv("APP_FRONTEND_URL", "http://localhost:3000"),
+		},
+		Throttle: LoginThrottleConfig{
+			Store:           getEnv("LOGIN_THROTTLE_STORE", "postgres"),
+			MaxAttempts:     getEnv("LOGIN_MAX_ATTEMPTS", "10"),
+			IPMaxAttempts:   getEnv("LOGIN_IP_MAX_ATTEMPTS", "100"),
+			LockoutDuration: getEnv("LOGIN_LOCKOUT_DURATION", "15m"),
+		},
+		Privacy: PrivacyConfig{
+			ExportTTL: getEnv("DATA_EXPORT_TTL", "168h"),
+		},
+		Questao: QuestaoConfig{
+			FacetsTTL:            getEnv("QUESTAO_FACETS_TTL", "5m"),
+			EstatisticasInterval: getEnv("QUESTAO_ESTATISTICAS_INTERVAL", "5m"),
+		},
 	}
 
 	return cfg, nil
@@ -119,6 +195,29 @@ func (c *DatabaseConfig) GetDSN() string {
 	)
 }
 
+// loadOIDCProviders reads OIDC_PROVIDERS (comma separated names) and, for each
+// name, OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and
+// the optional OIDC_<NAME>_SCOPES.
+func loadOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -30,6 +30,54 @@ func InitDB(cfg *Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// questaoSearchStatements mirror migrations/0035_questoes_full_text_search.sql.
+var questaoSearchStatements = []string{
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'pt_unaccent') THEN
+			CREATE TEXT SEARCH CONFIGURATION pt_unaccent (COPY = portuguese);
+			ALTER TEXT SEARCH CONFIGURATION pt_unaccent
+				ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+		END IF;
+	END
+	$$`,
+	`ALTER TABLE questoes ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('pt_unaccent', coalesce(titulo, '')), 'A') ||
+			setweight(to_tsvector('pt_unaccent', coalesce(enunciado, '')), 'A') ||
+			setweight(to_tsvector('pt_unaccent',
+				coalesce(alternativa_a, '') || ' ' || coalesce(alternativa_b, '') || ' ' ||
+				coalesce(alternativa_c, '') || ' ' || coalesce(alternativa_d, '') || ' ' ||
+				coalesce(alternativa_e, '')), 'B') ||
+			setweight(to_tsvector('pt_unaccent', coalesce(comentario, '')), 'C')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_questoes_search_vector ON questoes USING GIN (search_vector)`,
+}
+
+// questaoFacetStatements mirror migrations/0036_questao_facets.sql.
+var questaoFacetStatements = []string{
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS questao_facets AS
+	SELECT
+		md5(ROW(disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+			tipo_questao, nivel, dificuldade, ano,
+			COALESCE(anulada, false), COALESCE(desatualizada, false), COALESCE(questao_oculta, false))::text) AS facet_key,
+		disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+		tipo_questao, nivel, dificuldade, ano,
+		COALESCE(anulada, false) AS anulada,
+		COALESCE(desatualizada, false) AS desatualizada,
+		COALESCE(questao_oculta, false) AS questao_oculta,
+		COUNT(*) AS total
+	FROM questoes
+	WHERE erro_captura IS NULL OR erro_captura = false
+	GROUP BY disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+		tipo_questao, nivel, dificuldade, ano,
+		COALESCE(anulada, false), COALESCE(desatualizada, false), COALESCE(questao_oculta, false)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_questao_facets_key ON questao_facets (facet_key)`,
+	`CREATE INDEX IF NOT EXISTS idx_questao_facets_disciplina ON questao_facets (disciplina)`,
+	`CREATE INDEX IF NOT EXISTS idx_questao_facets_banca ON questao_facets (banca)`,
+}
+
 func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(
 		&model.AsaasCustomer{},
@@ -52,7 +100,31 @@ func AutoMigrate(db *gorm.DB) error {
 		&model.CourseItem{},
 		&model.CourseModuleItem{},
 		&model.UserPerformance{},
+		&model.QuestionAttempt{},
+		&model.Simulado{},
+		&model.SimuladoQuestao{},
+		&model.Caderno{},
+		&model.CadernoQuestao{},
+		&model.CadernoProgresso{},
+		&model.RevisaoCard{},
+		&model.RevisaoConfig{},
+		&model.PerfilEstudo{},
+		&model.QuestaoReporte{},
+		&model.Notificacao{},
+		&model.QuestaoVersao{},
+		&model.QuestaoDispositivo{},
+		&model.VadeMecumIncidencia{},
+		&model.QuestaoEstatistica{},
+		&model.RespostaDiscursiva{},
 		&model.User{},
+		&model.UserSession{},
+		&model.UserToken{},
+		&model.UserIdentity{},
+		&model.UserRecoveryCode{},
+		&model.LoginAttempt{},
+		&model.AuditLog{},
+		&model.MediaAsset{},
+		&model.DataExport{},
 		// Add more models here as needed
 	); err != nil {
 		return err
@@ -268,6 +340,39 @@ func AutoMigrate(db *gorm.DB) error {
 		}
 	}
 
+	// Backfill identities for social accounts created before user_identities existed.
+	if err := db.Exec(`INSERT INTO user_identities (id, user_id, provider, provider_user_id, email, created_at, updated_at)
+		SELECT gen_random_uuid(), id, provider, provider_id, email, NOW(), NOW()
+		FROM users
+		WHERE provider IS NOT NULL AND provider <> '' AND provider <> 'local'
+		  AND provider_id IS NOT NULL AND provider_id <> ''
+		ON CONFLICT (provider, provider_user_id) DO NOTHING`).Error; err != nil {
+		return err
+	}
+
+	// Full-text search over questoes (migration 0035) for databases where the
+	// table was created after that migration ran.
+	if !migrator.HasColumn(&model.Questao{}, "search_vector") {
+		for _, statement := range questaoSearchStatements {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	// Facet counts over questoes (migration 0036), same reason as above.
+	var facetView *string
+	if err := db.Raw("SELECT to_regclass('public.questao_facets')::text").Scan(&facetView).Error; err != nil {
+		return err
+	}
+	if facetView == nil {
+		for _, statement := range questaoFacetStatements {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+
 	if migrator.HasColumn(&model.VadeMecum{}, "category") {
 		if err := db.Model(&model.VadeMecum{}).
 			Where("category IS NULL OR category = ''").
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+)
+
+// ForgotPassword godoc
+// @Summary      Solicitar redefinição de senha
+// @Description  Envia um link de redefinição de senha para o e-mail, se ele estiver cadastrado
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ForgotPasswordRequest  true  "E-mail da conta"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /auth/password/forgot [post]
+func (h *Handlers) ForgotPassword(c *gin.Context) {
+	var req model.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.ForgotPassword(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Se o e-mail estiver cadastrado, enviaremos as instruções"})
+}
+
+// ResetPassword godoc
+// @Summary      Redefinir senha
+// @Description  Define uma nova senha a partir do token recebido por e-mail e encerra todas as sessões
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ResetPasswordRequest  true  "Token e nova senha"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /auth/password/reset [post]
+func (h *Handlers) ResetPassword(c *gin.Context) {
+	var req model.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.ResetPassword(req.Token, req.Password); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated successfully"})
+}
+
+// VerifyEmail godoc
+// @Summary      Confirmar e-mail
+// @Description  Confirma o endereço de e-mail a partir do token recebido
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.VerifyEmailRequest  true  "Token de verificação"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /auth/email/verify [post]
+func (h *Handlers) VerifyEmail(c *gin.Context) {
+	var req model.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.VerifyEmail(req.Token); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerificationEmail godoc
+// @Summary      Reenviar e-mail de confirmação
+// @Description  Envia um novo link de confirmação, se o e-mail estiver cadastrado e ainda não confirmado
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ResendVerificationRequest  true  "E-mail da conta"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /auth/email/resend [post]
+func (h *Handlers) ResendVerificationEmail(c *gin.Context) {
+	var req model.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.ResendVerification(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Se o e-mail estiver cadastrado, enviaremos as instruções"})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/thepantheon/api/internal/model"
 	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
 )
 
 // @Summary      Criar cliente Asaas
@@ -56,6 +57,9 @@ func (h *Handlers) CreateAsaasCustomer(c *gin.Context) {
 		Phone:        req.Phone,
 		ResponseJSON: string(respJSON),
 	}
+	if userID, ok := middleware.GetUserID(c); ok {
+		customer.UserID = &userID
+	}
 
 	if err := h.asaasCustomerService.Create(&customer); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -1,10 +1,16 @@
 package handler
 
 import (
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
 )
 
 // Login godoc
@@ -17,6 +23,8 @@ import (
 // @Success      200      {object}  model.LoginResponse
 // @Failure      400      {object}  map[string]string
 // @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      429      {object}  map[string]string
 // @Router       /auth/login [post]
 func (h *Handlers) Login(c *gin.Context) {
 	var req model.LoginRequest
@@ -25,8 +33,11 @@ func (h *Handlers) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	response, err := h.authService.Login(&req, clientInfo(c))
 	if err != nil {
+		if respondThrottled(c, err) || respondAccountDisabled(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -58,6 +69,8 @@ func (h *Handlers) Register(c *gin.Context) {
 		return
 	}
 
+	h.accountService.SendVerificationEmailAsync(user)
+
 	c.JSON(http.StatusCreated, user)
 }
 
@@ -71,6 +84,7 @@ func (h *Handlers) Register(c *gin.Context) {
 // @Success      201      {object}  model.User
 // @Failure      400      {object}  map[string]string
 // @Failure      401      {object}  map[string]string
+// @Failure      429      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /auth/admin/register [post]
 func (h *Handlers) RegisterAdmin(c *gin.Context) {
@@ -80,7 +94,14 @@ func (h *Handlers) RegisterAdmin(c *gin.Context) {
 		return
 	}
 
-	if h.adminSecret == "" || req.AdminSecret != h.adminSecret {
+	ip := c.ClientIP()
+	if err := h.loginThrottle.Allow("", ip); err != nil {
+		respondThrottled(c, err)
+		return
+	}
+
+	if h.adminSecret == "" || subtle.ConstantTimeCompare([]byte(req.AdminSecret), []byte(h.adminSecret)) != 1 {
+		h.loginThrottle.RegisterFailure("", ip, model.AuditAdminSecretLockout)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid admin secret"})
 		return
 	}
@@ -101,6 +122,18 @@ func (h *Handlers) RegisterAdmin(c *gin.Context) {
 	c.JSON(http.StatusCreated, user)
 }
 
+// RefreshToken godoc
+// @Summary      Renovar tokens
+// @Description  Troca um refresh token válido por um novo access token e um novo refresh token (rotação)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.RefreshTokenRequest  true  "Refresh token"
+// @Success      200      {object}  model.LoginResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Router       /auth/refresh [post]
 func (h *Handlers) RefreshToken(c *gin.Context) {
 	var req model.RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -108,26 +141,149 @@ func (h *Handlers) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	claims, err := h.authService.ValidateToken(req.Token)
+	response, err := h.authService.Refresh(req.RefreshToken, clientInfo(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		if respondAccountDisabled(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Logout godoc
+// @Summary      Encerrar sessão
+// @Description  Revoga a sessão do token atual ou a sessão do refresh token informado
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.LogoutRequest  false  "Refresh token (opcional)"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /auth/logout [post]
+func (h *Handlers) Logout(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.authService.Logout(userID, middleware.GetSessionID(c), req.RefreshToken); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, err := h.userService.GetUserByEmail(claims.Email)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll godoc
+// @Summary      Encerrar todas as sessões
+// @Description  Revoga todas as sessões ativas do usuário autenticado
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/logout-all [post]
+func (h *Handlers) LogoutAll(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All sessions revoked"})
+}
+
+// GetSessions godoc
+// @Summary      Listar sessões ativas
+// @Description  Lista os dispositivos conectados (user agent, IP e último acesso)
+// @Tags         auth
+// @Produce      json
+// @Success      200  {array}   model.UserSessionResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/sessions [get]
+func (h *Handlers) GetSessions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authService.GetSessions(userID, middleware.GetSessionID(c))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	newToken, expiresIn, err := h.authService.GenerateToken(user)
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary      Encerrar uma sessão
+// @Description  Revoga uma sessão específica do usuário autenticado
+// @Tags         auth
+// @Param        id   path      string  true  "ID da sessão"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /auth/sessions/{id} [delete]
+func (h *Handlers) RevokeSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":      newToken,
-		"expires_in": expiresIn,
-	})
+	if err := h.authService.RevokeSession(userID, sessionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondThrottled writes a 429 with Retry-After when err comes from the
+// login throttle and reports whether it did.
+func respondThrottled(c *gin.Context, err error) bool {
+	var throttled *service.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error()})
+	return true
+}
+
+// respondAccountDisabled answers logins of deactivated accounts with 403.
+func respondAccountDisabled(c *gin.Context, err error) bool {
+	if !errors.Is(err, service.ErrAccountDisabled) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	return true
 }
@@ -0,0 +1,393 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// CreateCaderno godoc
+// @Summary      Criar caderno
+// @Description  Um caderno guarda filtros de questoes ou uma lista ordenada de questoes, nunca os dois.
+// @Tags         cadernos
+// @Accept       json
+// @Produce      json
+// @Param        request body model.CadernoRequest true "Caderno"
+// @Success      201 {object} model.CadernoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /cadernos [post]
+func (h *Handlers) CreateCaderno(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.CadernoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	caderno, err := h.cadernoService.Create(userID, &req)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, caderno)
+}
+
+// GetCadernos godoc
+// @Summary      Listar meus cadernos
+// @Tags         cadernos
+// @Produce      json
+// @Success      200 {array} model.Caderno
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /cadernos [get]
+func (h *Handlers) GetCadernos(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.cadernoService.List(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// GetCaderno godoc
+// @Summary      Obter caderno com progresso
+// @Tags         cadernos
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Success      200 {object} model.CadernoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id} [get]
+func (h *Handlers) GetCaderno(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	caderno, err := h.cadernoService.Get(userID, id)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, caderno)
+}
+
+// UpdateCaderno godoc
+// @Summary      Atualizar caderno
+// @Tags         cadernos
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Param        request body model.CadernoRequest true "Caderno"
+// @Success      200 {object} model.CadernoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id} [put]
+func (h *Handlers) UpdateCaderno(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	var req model.CadernoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	caderno, err := h.cadernoService.Update(userID, id, &req)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, caderno)
+}
+
+// DeleteCaderno godoc
+// @Summary      Excluir caderno
+// @Tags         cadernos
+// @Param        id path string true "ID do caderno"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id} [delete]
+func (h *Handlers) DeleteCaderno(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cadernoService.Delete(userID, id); err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCadernoQuestoes godoc
+// @Summary      Listar questoes do caderno
+// @Tags         cadernos
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Param        page query int false "Página (a partir de 1)"
+// @Param        page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success      200 {object} model.QuestaoPage
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id}/questoes [get]
+func (h *Handlers) GetCadernoQuestoes(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.cadernoService.Questoes(userID, id, parseInt(c.Query("page"), 1), parseInt(c.Query("page_size"), 0))
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// AddCadernoQuestoes godoc
+// @Summary      Adicionar questoes ao caderno
+// @Description  Acrescenta as questoes ao fim da lista; questoes repetidas sao ignoradas.
+// @Tags         cadernos
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Param        request body model.CadernoQuestoesRequest true "Questoes"
+// @Success      200 {object} model.CadernoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /cadernos/{id}/questoes [post]
+func (h *Handlers) AddCadernoQuestoes(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	var req model.CadernoQuestoesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	caderno, err := h.cadernoService.AddQuestoes(userID, id, req.QuestaoIDs)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, caderno)
+}
+
+// RemoveCadernoQuestao godoc
+// @Summary      Remover questao do caderno
+// @Tags         cadernos
+// @Param        id path string true "ID do caderno"
+// @Param        questaoId path int true "ID da questao"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /cadernos/{id}/questoes/{questaoId} [delete]
+func (h *Handlers) RemoveCadernoQuestao(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+	questaoID, err := strconv.Atoi(c.Param("questaoId"))
+	if err != nil || questaoID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da questao invalido"})
+		return
+	}
+
+	if err := h.cadernoService.RemoveQuestao(userID, id, questaoID); err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetCadernoProgresso godoc
+// @Summary      Reiniciar progresso no caderno
+// @Description  O progresso passa a contar apenas as respostas dadas a partir de agora.
+// @Tags         cadernos
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Success      200 {object} model.CadernoProgressoResumo
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id}/progresso/reiniciar [post]
+func (h *Handlers) ResetCadernoProgresso(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	progress, err := h.cadernoService.ResetProgress(userID, id)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// ShareCaderno godoc
+// @Summary      Compartilhar caderno por link
+// @Description  Gera (ou retorna) o link de leitura do caderno.
+// @Tags         cadernos
+// @Produce      json
+// @Param        id path string true "ID do caderno"
+// @Success      200 {object} model.CadernoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id}/compartilhar [post]
+func (h *Handlers) ShareCaderno(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	caderno, err := h.cadernoService.Share(userID, id)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, caderno)
+}
+
+// UnshareCaderno godoc
+// @Summary      Revogar link do caderno
+// @Tags         cadernos
+// @Param        id path string true "ID do caderno"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/{id}/compartilhar [delete]
+func (h *Handlers) UnshareCaderno(c *gin.Context) {
+	userID, id, ok := cadernoTarget(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cadernoService.Unshare(userID, id); err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCadernoCompartilhado godoc
+// @Summary      Abrir caderno compartilhado
+// @Description  Somente leitura. Usuarios autenticados recebem o proprio progresso no caderno.
+// @Tags         cadernos
+// @Produce      json
+// @Param        token path string true "Token do link"
+// @Success      200 {object} model.CadernoDetail
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/compartilhados/{token} [get]
+func (h *Handlers) GetCadernoCompartilhado(c *gin.Context) {
+	var viewerID *uuid.UUID
+	if userID, ok := middleware.GetUserID(c); ok {
+		viewerID = &userID
+	}
+
+	caderno, err := h.cadernoService.GetShared(c.Param("token"), viewerID)
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, caderno)
+}
+
+// GetCadernoCompartilhadoQuestoes godoc
+// @Summary      Listar questoes de caderno compartilhado
+// @Tags         cadernos
+// @Produce      json
+// @Param        token path string true "Token do link"
+// @Param        page query int false "Página (a partir de 1)"
+// @Param        page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success      200 {object} model.QuestaoPage
+// @Failure      404 {object} map[string]string
+// @Router       /cadernos/compartilhados/{token}/questoes [get]
+func (h *Handlers) GetCadernoCompartilhadoQuestoes(c *gin.Context) {
+	page, err := h.cadernoService.SharedQuestoes(c.Param("token"), parseInt(c.Query("page"), 1), parseInt(c.Query("page_size"), 0))
+	if err != nil {
+		respondCadernoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// cadernoTarget reads the signed-in user and the caderno id, writing the
+// error response when either is missing.
+func cadernoTarget(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func respondCadernoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "caderno nao encontrado"})
+	case errors.Is(err, service.ErrCadernoQuestaoNotInList):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCadernoNotLista):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCadernoConteudo), errors.Is(err, service.ErrCadernoTooLarge),
+		errors.Is(err, service.ErrCadernoQuestaoNotFound), errors.Is(err, service.ErrInvalidQuestaoSort):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
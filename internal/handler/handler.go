@@ -1,40 +1,88 @@
 package handler
 
 import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/config"
+	"github.com/thepantheon/api/internal/model"
 	"github.com/thepantheon/api/internal/repository"
 	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
 	"gorm.io/gorm"
 )
 
 type Handlers struct {
-	userService           *service.UserService
-	authService           *service.AuthService
-	socialAuthService     *service.SocialAuthService
-	planService           *service.PlanService
-	adminSecret           string
-	questaoService        *service.QuestaoService
+	userService            *service.UserService
+	adminUserService       *service.AdminUserService
+	authService            *service.AuthService
+	accountService         *service.AccountService
+	socialAuthService      *service.SocialAuthService
+	twoFactorService       *service.TwoFactorService
+	loginThrottle          *service.LoginThrottle
+	profileService         *service.ProfileService
+	privacyService         *service.PrivacyService
+	mediaAssetService      *service.MediaAssetService
+	planService            *service.PlanService
+	adminSecret            string
+	secureCookies          bool
+	questaoService         *service.QuestaoService
+	questionAttemptService *service.QuestionAttemptService
+	simuladoService        *service.SimuladoService
+	cadernoService         *service.CadernoService
+	revisaoService         *service.RevisaoService
+	recomendacaoService    *service.RecomendacaoService
+	notificacaoService     *service.NotificacaoService
+	questaoReporteService  *service.QuestaoReporteService
+	discursivaService      *service.RespostaDiscursivaService
+	dispositivoService     *service.QuestaoDispositivoService
+	estatisticaService     *service.QuestaoEstatisticaService
+	incidenciaService      *service.VadeMecumIncidenciaService
 	userPerformanceService *service.UserPerformanceService
-	courseService         *service.CourseService
-	vadeMecumService      *service.VadeMecumService
-	codigoService         *service.VadeMecumCodigoService
-	leisService           *service.VadeMecumLeiService
-	capaCodigoService     *service.CapaVadeMecumCodigoService
-	oabService            *service.VadeMecumOABService
-	capaOABService        *service.CapaVadeMecumOABService
-	jurisprudenciaService *service.VadeMecumJurisprudenciaService
-	capaJurisService      *service.CapaVadeMecumJurisprudenciaService
-	estatutoService       *service.VadeMecumEstatutoService
-	constituicaoService   *service.VadeMecumConstituicaoService
-	asaasService          *service.AsaasService
-	asaasCustomerService  *service.AsaasCustomerService
-	asaasPaymentService   *service.AsaasPaymentService
+	courseService          *service.CourseService
+	vadeMecumService       *service.VadeMecumService
+	codigoService          *service.VadeMecumCodigoService
+	leisService            *service.VadeMecumLeiService
+	capaCodigoService      *service.CapaVadeMecumCodigoService
+	oabService             *service.VadeMecumOABService
+	capaOABService         *service.CapaVadeMecumOABService
+	jurisprudenciaService  *service.VadeMecumJurisprudenciaService
+	capaJurisService       *service.CapaVadeMecumJurisprudenciaService
+	estatutoService        *service.VadeMecumEstatutoService
+	constituicaoService    *service.VadeMecumConstituicaoService
+	asaasService           *service.AsaasService
+	asaasCustomerService   *service.AsaasCustomerService
+	asaasPaymentService    *service.AsaasPaymentService
 }
 
-func NewHandlers(db *gorm.DB, googleClientID, googleClientSecret, facebookAppID, facebookAppSecret, redirectURL, jwtSecret, adminSecret, asaasBaseURL, asaasToken string) *Handlers {
+func NewHandlers(db *gorm.DB, cfg *config.Config) *Handlers {
 	userRepo := repository.NewUserRepository(db)
+	userSessionRepo := repository.NewUserSessionRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	userIdentityRepo := repository.NewUserIdentityRepository(db)
+	userRecoveryCodeRepo := repository.NewUserRecoveryCodeRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	mediaAssetRepo := repository.NewMediaAssetRepository(db)
+	privacyRepo := repository.NewPrivacyRepository(db)
+	dataExportRepo := repository.NewDataExportRepository(db)
 	planRepo := repository.NewPlanRepository(db)
 	questaoRepo := repository.NewQuestaoRepository(db)
 	userPerformanceRepo := repository.NewUserPerformanceRepository(db)
+	questionAttemptRepo := repository.NewQuestionAttemptRepository(db)
+	simuladoRepo := repository.NewSimuladoRepository(db)
+	cadernoRepo := repository.NewCadernoRepository(db)
+	revisaoRepo := repository.NewRevisaoRepository(db)
+	recomendacaoRepo := repository.NewRecomendacaoRepository(db)
+	notificacaoRepo := repository.NewNotificacaoRepository(db)
+	questaoReporteRepo := repository.NewQuestaoReporteRepository(db)
+	respostaDiscursivaRepo := repository.NewRespostaDiscursivaRepository(db)
+	questaoDispositivoRepo := repository.NewQuestaoDispositivoRepository(db)
+	questaoEstatisticaRepo := repository.NewQuestaoEstatisticaRepository(db)
+	incidenciaRepo := repository.NewVadeMecumIncidenciaRepository(db)
 	courseRepo := repository.NewCourseRepository(db)
 	vadeMecumRepo := repository.NewVadeMecumRepository(db)
 	codigoRepo := repository.NewVadeMecumCodigoRepository(db)
@@ -49,10 +97,43 @@ func NewHandlers(db *gorm.DB, googleClientID, googleClientSecret, facebookAppID,
 	asaasCustomerRepo := repository.NewAsaasCustomerRepository(db)
 	asaasPaymentRepo := repository.NewAsaasPaymentRepository(db)
 	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userService, jwtSecret)
-	socialAuthService := service.NewSocialAuthService(userService, googleClientID, googleClientSecret, facebookAppID, facebookAppSecret, redirectURL)
+	twoFactorService := service.NewTwoFactorService(userRepo, userRecoveryCodeRepo, cfg.JWT.Secret)
+	auditService := service.NewAuditService(auditLogRepo)
+	loginThrottle := newLoginThrottle(db, cfg.Throttle, auditService)
+	authService := service.NewAuthService(
+		userService,
+		userSessionRepo,
+		twoFactorService,
+		loginThrottle,
+		cfg.JWT.Secret,
+		parseDuration(cfg.JWT.Expiration, 15*time.Minute),
+		parseDuration(cfg.JWT.RefreshExpiration, 30*24*time.Hour),
+	)
+	mailer := newMailer(cfg.Mail)
+	accountService := service.NewAccountService(userRepo, userTokenRepo, userSessionRepo, mailer, cfg.Mail.FrontendURL)
+	mediaAssetService := service.NewMediaAssetService(mediaAssetRepo)
+	privacyService := service.NewPrivacyService(privacyRepo, dataExportRepo, auditService, parseDuration(cfg.Privacy.ExportTTL, 7*24*time.Hour))
+	profileService := service.NewProfileService(userRepo, userSessionRepo, mediaAssetService, accountService, privacyService)
+	socialAuthService := service.NewSocialAuthService(
+		userService,
+		userIdentityRepo,
+		cfg.JWT.Secret,
+		oauthProviders(cfg.OAuth)...,
+	)
 	planService := service.NewPlanService(planRepo)
-	questaoService := service.NewQuestaoService(questaoRepo)
+	adminUserService := service.NewAdminUserService(userRepo, userSessionRepo, planRepo, auditService)
+	dispositivoService := service.NewQuestaoDispositivoService(questaoDispositivoRepo, questaoRepo)
+	incidenciaService := service.NewVadeMecumIncidenciaService(incidenciaRepo, questaoDispositivoRepo)
+	questaoService := service.NewQuestaoService(questaoRepo, dispositivoService, parseDuration(cfg.Questao.FacetsTTL, 5*time.Minute))
+	estatisticaService := service.NewQuestaoEstatisticaService(questaoEstatisticaRepo, parseDuration(cfg.Questao.EstatisticasInterval, 5*time.Minute))
+	revisaoService := service.NewRevisaoService(revisaoRepo, questaoRepo)
+	recomendacaoService := service.NewRecomendacaoService(recomendacaoRepo)
+	questionAttemptService := service.NewQuestionAttemptService(questionAttemptRepo, questaoRepo, revisaoService)
+	simuladoService := service.NewSimuladoService(simuladoRepo, questaoRepo, revisaoService)
+	cadernoService := service.NewCadernoService(cadernoRepo, questaoRepo, cfg.Mail.FrontendURL)
+	notificacaoService := service.NewNotificacaoService(notificacaoRepo, userRepo, mailer, cfg.Mail.FrontendURL)
+	questaoReporteService := service.NewQuestaoReporteService(questaoReporteRepo, questaoService, notificacaoService)
+	discursivaService := service.NewRespostaDiscursivaService(respostaDiscursivaRepo, questaoRepo, revisaoService, notificacaoService)
 	userPerformanceService := service.NewUserPerformanceService(userPerformanceRepo)
 	courseService := service.NewCourseService(courseRepo)
 	vadeMecumService := service.NewVadeMecumService(vadeMecumRepo)
@@ -65,31 +146,147 @@ func NewHandlers(db *gorm.DB, googleClientID, googleClientSecret, facebookAppID,
 	capaOABService := service.NewCapaVadeMecumOABService(capaOABRepo)
 	jurisprudenciaService := service.NewVadeMecumJurisprudenciaService(jurisRepo)
 	capaJurisService := service.NewCapaVadeMecumJurisprudenciaService(capaJurisRepo)
-	asaasService := service.NewAsaasService(asaasBaseURL, asaasToken)
+	asaasService := service.NewAsaasService(cfg.Asaas.BaseURL, cfg.Asaas.Token)
 	asaasCustomerService := service.NewAsaasCustomerService(asaasCustomerRepo)
 	asaasPaymentService := service.NewAsaasPaymentService(asaasPaymentRepo)
 
 	return &Handlers{
-		userService:           userService,
-		authService:           authService,
-		socialAuthService:     socialAuthService,
-		planService:           planService,
-		questaoService:        questaoService,
+		userService:            userService,
+		adminUserService:       adminUserService,
+		authService:            authService,
+		accountService:         accountService,
+		socialAuthService:      socialAuthService,
+		twoFactorService:       twoFactorService,
+		loginThrottle:          loginThrottle,
+		profileService:         profileService,
+		privacyService:         privacyService,
+		mediaAssetService:      mediaAssetService,
+		planService:            planService,
+		questaoService:         questaoService,
+		questionAttemptService: questionAttemptService,
+		simuladoService:        simuladoService,
+		cadernoService:         cadernoService,
+		revisaoService:         revisaoService,
+		recomendacaoService:    recomendacaoService,
+		notificacaoService:     notificacaoService,
+		questaoReporteService:  questaoReporteService,
+		discursivaService:      discursivaService,
+		dispositivoService:     dispositivoService,
+		estatisticaService:     estatisticaService,
+		incidenciaService:      incidenciaService,
 		userPerformanceService: userPerformanceService,
-		courseService:         courseService,
-		vadeMecumService:      vadeMecumService,
-		codigoService:         codigoService,
-		leisService:           leisService,
-		adminSecret:           adminSecret,
-		capaCodigoService:     capaCodigoService,
-		oabService:            oabService,
-		capaOABService:        capaOABService,
-		jurisprudenciaService: jurisprudenciaService,
-		capaJurisService:      capaJurisService,
-		estatutoService:       estatutoService,
-		constituicaoService:   constituicaoService,
-		asaasService:          asaasService,
-		asaasCustomerService:  asaasCustomerService,
-		asaasPaymentService:   asaasPaymentService,
+		courseService:          courseService,
+		vadeMecumService:       vadeMecumService,
+		codigoService:          codigoService,
+		leisService:            leisService,
+		adminSecret:            cfg.Admin.Secret,
+		secureCookies:          cfg.Server.Scheme == "https",
+		capaCodigoService:      capaCodigoService,
+		oabService:             oabService,
+		capaOABService:         capaOABService,
+		jurisprudenciaService:  jurisprudenciaService,
+		capaJurisService:       capaJurisService,
+		estatutoService:        estatutoService,
+		constituicaoService:    constituicaoService,
+		asaasService:           asaasService,
+		asaasCustomerService:   asaasCustomerService,
+		asaasPaymentService:    asaasPaymentService,
+	}
+}
+
+// AuthService exposes the token service so routes can be wired with the auth middlewares.
+func (h *Handlers) AuthService() *service.AuthService {
+	return h.authService
+}
+
+// EstatisticaService exposes the question statistics worker so main can
+// start it.
+func (h *Handlers) EstatisticaService() *service.QuestaoEstatisticaService {
+	return h.estatisticaService
+}
+
+// currentUserID reads the user ID placed in the context by the auth middlewares.
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func clientInfo(c *gin.Context) model.ClientInfo {
+	return model.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+func newMailer(cfg config.MailConfig) service.Mailer {
+	if cfg.Driver == "smtp" {
+		return service.NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From)
+	}
+	return service.NewLogMailer(cfg.OutputDir, cfg.From)
+}
+
+// oauthProviders builds the social login registry: Google and Facebook plus
+// every OpenID Connect provider configured through OIDC_PROVIDERS.
+func oauthProviders(cfg config.OAuthConfig) []service.OAuthProvider {
+	providers := []service.OAuthProvider{
+		service.NewGoogleProvider(cfg.GoogleClientID, cfg.GoogleClientSecret, cfg.RedirectURL),
+		service.NewFacebookProvider(cfg.FacebookAppID, cfg.FacebookAppSecret, cfg.RedirectURL),
+	}
+	for _, oidc := range cfg.OIDCProviders {
+		providers = append(providers, service.NewOIDCProvider(oidc.Name, oidc.Issuer, oidc.ClientID, oidc.ClientSecret, oidc.Scopes, cfg.RedirectURL))
+	}
+	return providers
+}
+
+// newLoginThrottle builds the brute-force protection: accounts get a few free
+// attempts, then exponential backoff and a lockout; IPs get a larger budget.
+func newLoginThrottle(db *gorm.DB, cfg config.LoginThrottleConfig, audit *service.AuditService) *service.LoginThrottle {
+	var store service.LoginAttemptStore = repository.NewLoginAttemptRepository(db)
+	if cfg.Store == "memory" {
+		store = service.NewMemoryLoginAttemptStore()
+	}
+
+	lockout := parseDuration(cfg.LockoutDuration, 15*time.Minute)
+	accountPolicy := service.ThrottlePolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        lockout,
+		LockoutAfter:    parseInt(cfg.MaxAttempts, 10),
+		LockoutDuration: lockout,
+	}
+	ipPolicy := service.ThrottlePolicy{
+		FreeAttempts:    20,
+		BaseDelay:       time.Second,
+		MaxDelay:        lockout,
+		LockoutAfter:    parseInt(cfg.IPMaxAttempts, 100),
+		LockoutDuration: 4 * lockout,
+	}
+	return service.NewLoginThrottle(store, audit, accountPolicy, ipPolicy, time.Hour)
+}
+
+func parseInt(value string, fallback int) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		if value != "" {
+			log.Printf("invalid integer %q, using %d", value, fallback)
+		}
+		return fallback
+	}
+	return parsed
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("invalid duration %q, using %s", value, fallback)
+		return fallback
 	}
+	return parsed
 }
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
+)
+
+// GetMe godoc
+// @Summary      Obter meu perfil
+// @Description  Retorna os dados do usuário autenticado
+// @Tags         me
+// @Produce      json
+// @Success      200  {object}  model.User
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /me [get]
+func (h *Handlers) GetMe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.profileService.GetProfile(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe godoc
+// @Summary      Atualizar meu perfil
+// @Description  Atualiza nome e/ou e-mail do usuário autenticado. Trocar o e-mail exige current_password (ou, em contas sem senha, login feito há até 5 minutos) e reinicia a verificação
+// @Tags         me
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.UpdateProfileRequest  true  "Campos a atualizar"
+// @Success      200      {object}  model.User
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /me [patch]
+func (h *Handlers) UpdateMe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.profileService.UpdateProfile(userID, middleware.GetSessionID(c), &req)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangeMyPassword godoc
+// @Summary      Alterar minha senha
+// @Description  Altera a senha conferindo a senha atual (contas sem senha precisam de login feito há até 5 minutos); as demais sessões são encerradas
+// @Tags         me
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ChangePasswordRequest  true  "Senha atual e nova senha"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Router       /me/password [post]
+func (h *Handlers) ChangeMyPassword(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.profileService.ChangePassword(userID, middleware.GetSessionID(c), &req); err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// UploadMyAvatar godoc
+// @Summary      Enviar meu avatar
+// @Description  Envia uma imagem (JPEG, PNG, WebP ou GIF, até 2MB) armazenada como MediaAsset
+// @Tags         me
+// @Accept       mpfd
+// @Produce      json
+// @Param        file  formData  file  true  "Imagem do avatar"
+// @Success      200   {object}  model.User
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Router       /me/avatar [post]
+func (h *Handlers) UploadMyAvatar(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado"})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Não foi possível abrir o arquivo"})
+		return
+	}
+	defer src.Close()
+
+	// Read one byte past the limit so oversized files are detected without
+	// loading them entirely.
+	data, err := io.ReadAll(io.LimitReader(src, 2<<20+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Não foi possível ler o arquivo"})
+		return
+	}
+
+	user, err := h.profileService.UpdateAvatar(userID, file.Filename, data)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteMe godoc
+// @Summary      Excluir minha conta
+// @Description  Exclui a conta do usuário autenticado (eliminação LGPD): dados pessoais são anonimizados ou removidos e os registros financeiros são mantidos sem dados pessoais. Contas com senha precisam confirmá-la; contas sem senha precisam de login feito há até 5 minutos
+// @Tags         me
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.DeleteAccountRequest  false  "Confirmação de senha"
+// @Success      200      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /me [delete]
+func (h *Handlers) DeleteMe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.DeleteAccountRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.profileService.DeleteAccount(userID, middleware.GetSessionID(c), req.Password, c.ClientIP()); err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
+
+// GetMediaAsset godoc
+// @Summary      Obter arquivo de mídia
+// @Description  Retorna o conteúdo binário de um MediaAsset (ex: avatar)
+// @Tags         media
+// @Produce      octet-stream
+// @Param        id   path      string  true  "Media asset ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /media/{id} [get]
+func (h *Handlers) GetMediaAsset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+		return
+	}
+
+	asset, err := h.mediaAssetService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media not found"})
+		return
+	}
+
+	// Assets are immutable: a new upload always gets a new ID.
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, asset.ContentType, asset.Data)
+}
+
+func respondProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCurrentPassword):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRecentLoginRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrEmailInUse),
+		errors.Is(err, service.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidAvatar),
+		errors.Is(err, service.ErrAvatarTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetMinhasNotificacoes godoc
+// @Summary      Listar minhas notificacoes
+// @Tags         me
+// @Produce      json
+// @Param        nao_lidas query bool false "Apenas nao lidas"
+// @Param        limit query int false "Itens por pagina (padrao 20, maximo 100)"
+// @Param        offset query int false "Deslocamento"
+// @Success      200 {object} model.NotificacaoList
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/notificacoes [get]
+func (h *Handlers) GetMinhasNotificacoes(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	naoLidas, _ := strconv.ParseBool(c.Query("nao_lidas"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	list, err := h.notificacaoService.List(userID, naoLidas, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// MarcarNotificacaoLida godoc
+// @Summary      Marcar notificacao como lida
+// @Tags         me
+// @Param        id path string true "ID da notificacao"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /me/notificacoes/{id}/lida [post]
+func (h *Handlers) MarcarNotificacaoLida(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da notificacao invalido"})
+		return
+	}
+
+	if err := h.notificacaoService.MarkRead(userID, id); err != nil {
+		if errors.Is(err, service.ErrNotificacaoNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarcarNotificacoesLidas godoc
+// @Summary      Marcar todas as notificacoes como lidas
+// @Tags         me
+// @Produce      json
+// @Success      200 {object} map[string]int64
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/notificacoes/lidas [post]
+func (h *Handlers) MarcarNotificacoesLidas(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.notificacaoService.MarkAllRead(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"marcadas": count})
+}
@@ -2,68 +2,12 @@ package handler
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
 )
 
-func (h *Handlers) getUserIDFromRequest(c *gin.Context) (uuid.UUID, bool) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authorization header"})
-		return uuid.Nil, false
-	}
-
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-		return uuid.Nil, false
-	}
-
-	claims, err := h.authService.ValidateToken(parts[1])
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		return uuid.Nil, false
-	}
-
-	userID, err := uuid.Parse(claims.UserID)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user id"})
-		return uuid.Nil, false
-	}
-
-	return userID, true
-}
-
-func (h *Handlers) getUserIDFromRequestOptional(c *gin.Context) (uuid.UUID, bool, bool) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		return uuid.Nil, false, true
-	}
-
-	parts := strings.SplitN(authHeader, " ", 2)
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-		return uuid.Nil, false, false
-	}
-
-	claims, err := h.authService.ValidateToken(parts[1])
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		return uuid.Nil, false, false
-	}
-
-	userID, err := uuid.Parse(claims.UserID)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user id"})
-		return uuid.Nil, false, false
-	}
-
-	return userID, true, true
-}
-
 // GetMyModules godoc
 // @Summary      Listar modulos
 // @Tags         meus-cursos
@@ -73,11 +17,6 @@ func (h *Handlers) getUserIDFromRequestOptional(c *gin.Context) (uuid.UUID, bool
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/modulos [get]
 func (h *Handlers) GetMyModules(c *gin.Context) {
-	_, _, ok := h.getUserIDFromRequestOptional(c)
-	if !ok {
-		return
-	}
-
 	modules, err := h.courseService.GetAllModules()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -96,11 +35,6 @@ func (h *Handlers) GetMyModules(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos [get]
 func (h *Handlers) GetCourses(c *gin.Context) {
-	_, _, ok := h.getUserIDFromRequestOptional(c)
-	if !ok {
-		return
-	}
-
 	courses, err := h.courseService.GetAllCourses()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -119,11 +53,6 @@ func (h *Handlers) GetCourses(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/categorias [get]
 func (h *Handlers) GetCourseCategories(c *gin.Context) {
-	_, _, ok := h.getUserIDFromRequestOptional(c)
-	if !ok {
-		return
-	}
-
 	categories, err := h.courseService.GetAllCategories()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -145,7 +74,7 @@ func (h *Handlers) GetCourseCategories(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos [post]
 func (h *Handlers) CreateCourse(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -183,7 +112,7 @@ func (h *Handlers) CreateCourse(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/{id} [put]
 func (h *Handlers) UpdateCourse(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -224,7 +153,7 @@ func (h *Handlers) UpdateCourse(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/{id} [delete]
 func (h *Handlers) DeleteCourse(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -256,7 +185,7 @@ func (h *Handlers) DeleteCourse(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/categorias [post]
 func (h *Handlers) CreateCourseCategory(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -294,7 +223,7 @@ func (h *Handlers) CreateCourseCategory(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/categorias/{id} [put]
 func (h *Handlers) UpdateCourseCategory(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -335,7 +264,7 @@ func (h *Handlers) UpdateCourseCategory(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /cursos/categorias/{id} [delete]
 func (h *Handlers) DeleteCourseCategory(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -367,7 +296,7 @@ func (h *Handlers) DeleteCourseCategory(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/modulos [post]
 func (h *Handlers) CreateCourseModuleStandalone(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -401,7 +330,7 @@ func (h *Handlers) CreateCourseModuleStandalone(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/modulos/{id} [put]
 func (h *Handlers) UpdateCourseModule(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -438,7 +367,7 @@ func (h *Handlers) UpdateCourseModule(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/modulos/{id} [delete]
 func (h *Handlers) DeleteCourseModule(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -466,11 +395,6 @@ func (h *Handlers) DeleteCourseModule(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/itens [get]
 func (h *Handlers) GetMyItems(c *gin.Context) {
-	_, _, ok := h.getUserIDFromRequestOptional(c)
-	if !ok {
-		return
-	}
-
 	items, err := h.courseService.GetAllItems()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -493,7 +417,7 @@ func (h *Handlers) GetMyItems(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/itens [post]
 func (h *Handlers) CreateCourseItemStandalone(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -527,7 +451,7 @@ func (h *Handlers) CreateCourseItemStandalone(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/itens/{id} [put]
 func (h *Handlers) UpdateCourseItem(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -564,7 +488,7 @@ func (h *Handlers) UpdateCourseItem(c *gin.Context) {
 // @Failure      500 {object} map[string]string
 // @Router       /meus-cursos/itens/{id} [delete]
 func (h *Handlers) DeleteCourseItem(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
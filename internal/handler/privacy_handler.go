@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// RequestDataExport godoc
+// @Summary      Solicitar exportação dos meus dados
+// @Description  Inicia a geração assíncrona de um ZIP com arquivos JSON contendo todos os dados ligados ao usuário (LGPD). Se já houver uma exportação em andamento ela é retornada
+// @Tags         me
+// @Produce      json
+// @Success      202  {object}  model.DataExport
+// @Failure      401  {object}  map[string]string
+// @Router       /me/data-export [post]
+func (h *Handlers) RequestDataExport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.privacyService.RequestExport(userID, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// ListDataExports godoc
+// @Summary      Listar minhas exportações de dados
+// @Tags         me
+// @Produce      json
+// @Success      200  {array}   model.DataExport
+// @Failure      401  {object}  map[string]string
+// @Router       /me/data-export [get]
+func (h *Handlers) ListDataExports(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	exports, err := h.privacyService.ListExports(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exports)
+}
+
+// GetDataExport godoc
+// @Summary      Status da exportação de dados
+// @Tags         me
+// @Produce      json
+// @Param        id   path      string  true  "Export ID"
+// @Success      200  {object}  model.DataExport
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /me/data-export/{id} [get]
+func (h *Handlers) GetDataExport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return
+	}
+
+	export, err := h.privacyService.GetExport(userID, id)
+	if err != nil {
+		respondPrivacyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// DownloadDataExport godoc
+// @Summary      Baixar exportação de dados
+// @Description  Retorna o arquivo ZIP de uma exportação concluída e ainda não expirada
+// @Tags         me
+// @Produce      application/zip
+// @Param        id   path      string  true  "Export ID"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      410  {object}  map[string]string
+// @Router       /me/data-export/{id}/download [get]
+func (h *Handlers) DownloadDataExport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return
+	}
+
+	export, err := h.privacyService.DownloadExport(userID, id)
+	if err != nil {
+		respondPrivacyError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="meus-dados-%s.zip"`, export.CreatedAt.Format("20060102")))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", export.Data)
+}
+
+// EraseUser godoc
+// @Summary      Eliminar dados de um usuário (LGPD)
+// @Description  Anonimiza os dados pessoais do usuário e dos clientes Asaas, remove o conteúdo pessoal e mantém os registros financeiros. Usado para atender solicitações de titulares recebidas fora da plataforma
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true   "User ID"
+// @Param        request  body      model.EraseUserRequest  false  "Motivo"
+// @Success      200      {object}  model.ErasureReport
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /users/{id}/erase [post]
+func (h *Handlers) EraseUser(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req model.EraseUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = "admin_request"
+	}
+
+	report, err := h.privacyService.EraseUser(actorID, id, c.ClientIP(), req.Reason)
+	if err != nil {
+		respondPrivacyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func respondPrivacyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, service.ErrCannotChangeSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDataExportNotReady),
+		errors.Is(err, service.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDataExportExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
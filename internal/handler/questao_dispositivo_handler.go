@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// GetQuestaoDispositivos godoc
+// @Summary      Dispositivos do vade-mecum citados pela questao
+// @Description  Artigos e sumulas vinculados a questao, detectados no enunciado e no comentario ou adicionados pela equipe, com o texto de cada um.
+// @Tags         questoes
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {array} model.QuestaoDispositivoItem
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/dispositivos [get]
+func (h *Handlers) GetQuestaoDispositivos(c *gin.Context) {
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.dispositivoService.List(id)
+	if err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddQuestaoDispositivo godoc
+// @Summary      Vincular dispositivo a questao (admin)
+// @Description  Fontes: codigo, lei, estatuto, constituicao, oab ou jurisprudencia; dispositivo_id e o id do registro na fonte. Um vinculo removido volta como manual.
+// @Tags         questoes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID"
+// @Param        request body model.CreateQuestaoDispositivoRequest true "Dispositivo"
+// @Success      201 {object} model.QuestaoDispositivo
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/dispositivos [post]
+func (h *Handlers) AddQuestaoDispositivo(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	var req model.CreateQuestaoDispositivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.dispositivoService.Add(adminID, id, &req)
+	if err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// RemoveQuestaoDispositivo godoc
+// @Summary      Desvincular dispositivo da questao (admin)
+// @Description  Vinculos detectados ficam descartados e nao voltam em novas deteccoes.
+// @Tags         questoes
+// @Param        id path int true "ID"
+// @Param        vinculoId path string true "ID do vinculo"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/dispositivos/{vinculoId} [delete]
+func (h *Handlers) RemoveQuestaoDispositivo(c *gin.Context) {
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+	vinculoID, err := uuid.Parse(c.Param("vinculoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de vinculo invalido"})
+		return
+	}
+
+	if err := h.dispositivoService.Remove(id, vinculoID); err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DetectarQuestaoDispositivos godoc
+// @Summary      Detectar dispositivos citados pela questao (admin)
+// @Tags         questoes
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {array} model.QuestaoDispositivoItem
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/dispositivos/detectar [post]
+func (h *Handlers) DetectarQuestaoDispositivos(c *gin.Context) {
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.dispositivoService.DetectQuestao(id)
+	if err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// DetectarDispositivos godoc
+// @Summary      Detectar dispositivos em todas as questoes (admin)
+// @Description  Roda em segundo plano; use apos importar o vade-mecum.
+// @Tags         questoes
+// @Produce      json
+// @Success      202 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /questoes/dispositivos/detectar [post]
+func (h *Handlers) DetectarDispositivos(c *gin.Context) {
+	if err := h.dispositivoService.DetectAll(); err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "deteccao iniciada"})
+}
+
+// GetDispositivoQuestoes godoc
+// @Summary      Questoes que citam um dispositivo do vade-mecum
+// @Tags         vade-mecum
+// @Produce      json
+// @Param        fonte path string true "codigo, lei, estatuto, constituicao, oab ou jurisprudencia"
+// @Param        id path string true "ID do registro na fonte"
+// @Param        page query int false "Página (a partir de 1)"
+// @Param        page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success      200 {object} model.QuestaoPage
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /vade-mecum/dispositivos/{fonte}/{id}/questoes [get]
+func (h *Handlers) GetDispositivoQuestoes(c *gin.Context) {
+	page, err := h.dispositivoService.Questoes(c.Param("fonte"), c.Param("id"), parseInt(c.Query("page"), 1), parseInt(c.Query("page_size"), 0))
+	if err != nil {
+		respondQuestaoDispositivoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func respondQuestaoDispositivoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+	case errors.Is(err, service.ErrDispositivoNotFound), errors.Is(err, service.ErrQuestaoDispositivoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidDispositivoFonte):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDeteccaoEmAndamento):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
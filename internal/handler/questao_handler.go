@@ -1,54 +1,103 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
 )
 
 // GetQuestoes godoc
 // @Summary      Listar questoes
 // @Tags         questoes
 // @Produce      json
-// @Param        disciplina query string false "Disciplina"
-// @Param        assunto query string false "Assunto"
-// @Param        banca query string false "Banca"
-// @Param        orgao query string false "Orgao"
-// @Param        cargo query string false "Cargo"
-// @Param        concurso query string false "Concurso"
-// @Param        area_conhecimento query string false "Area de conhecimento"
-// @Success      200 {array} model.Questao
+// @Description  Lista questoes paginadas. Filtros repetidos (banca=FGV&banca=CESPE) combinam valores com OU. Com q, a busca textual (sem acentos) cobre titulo, enunciado, alternativas e comentario, ordena por relevancia e retorna trechos destacados
+// @Param        q query string false "Busca textual (aceita aspas para frase exata, OR e -termo)"
+// @Param        disciplina query []string false "Disciplina (repetível)" collectionFormat(multi)
+// @Param        assunto query []string false "Assunto (repetível)" collectionFormat(multi)
+// @Param        banca query []string false "Banca (repetível)" collectionFormat(multi)
+// @Param        orgao query []string false "Orgao (repetível)" collectionFormat(multi)
+// @Param        cargo query []string false "Cargo (repetível)" collectionFormat(multi)
+// @Param        concurso query []string false "Concurso (repetível)" collectionFormat(multi)
+// @Param        area_conhecimento query []string false "Area de conhecimento (repetível)" collectionFormat(multi)
+// @Param        tipo_questao query []string false "Tipo de questao (repetível)" collectionFormat(multi)
+// @Param        nivel query []string false "Nivel (repetível)" collectionFormat(multi)
+// @Param        dificuldade query []string false "Dificuldade (repetível)" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo"
+// @Param        ano_max query int false "Ano maximo"
+// @Param        acertos_min query number false "Percentual de acertos minimo"
+// @Param        acertos_max query number false "Percentual de acertos maximo"
+// @Param        anulada query bool false "Anulada"
+// @Param        desatualizada query bool false "Desatualizada"
+// @Param        questao_oculta query bool false "Questao oculta"
+// @Param        sort query string false "relevancia (padrão com q), id, ano, dificuldade, acertos_percentual ou quantidade_resolucoes"
+// @Param        order query string false "asc ou desc (padrão asc)"
+// @Param        page query int false "Página (a partir de 1)"
+// @Param        page_size query int false "Itens por página (padrão 20, máximo 100)"
+// @Success      200 {object} model.QuestaoPage
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /questoes [get]
 func (h *Handlers) GetQuestoes(c *gin.Context) {
-	filters := buildQuestaoFilters(c)
-	items, err := h.questaoService.GetAll(filters)
+	filters, err := buildQuestaoFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.questaoService.Search(filters)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidQuestaoSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, items)
+	c.JSON(http.StatusOK, page)
 }
 
 // GetQuestoesCount godoc
 // @Summary      Contar questoes
 // @Tags         questoes
 // @Produce      json
-// @Param        disciplina query string false "Disciplina"
-// @Param        assunto query string false "Assunto"
-// @Param        banca query string false "Banca"
-// @Param        orgao query string false "Orgao"
-// @Param        cargo query string false "Cargo"
-// @Param        concurso query string false "Concurso"
-// @Param        area_conhecimento query string false "Area de conhecimento"
+// @Param        disciplina query []string false "Disciplina (repetível)" collectionFormat(multi)
+// @Param        assunto query []string false "Assunto (repetível)" collectionFormat(multi)
+// @Param        banca query []string false "Banca (repetível)" collectionFormat(multi)
+// @Param        orgao query []string false "Orgao (repetível)" collectionFormat(multi)
+// @Param        cargo query []string false "Cargo (repetível)" collectionFormat(multi)
+// @Param        concurso query []string false "Concurso (repetível)" collectionFormat(multi)
+// @Param        area_conhecimento query []string false "Area de conhecimento (repetível)" collectionFormat(multi)
+// @Param        tipo_questao query []string false "Tipo de questao (repetível)" collectionFormat(multi)
+// @Param        nivel query []string false "Nivel (repetível)" collectionFormat(multi)
+// @Param        dificuldade query []string false "Dificuldade (repetível)" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo"
+// @Param        ano_max query int false "Ano maximo"
+// @Param        acertos_min query number false "Percentual de acertos minimo"
+// @Param        acertos_max query number false "Percentual de acertos maximo"
+// @Param        anulada query bool false "Anulada"
+// @Param        desatualizada query bool false "Desatualizada"
+// @Param        questao_oculta query bool false "Questao oculta"
+// @Param        q query string false "Busca textual"
 // @Success      200 {object} model.QuestaoCountResponse
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /questoes/contador [get]
 func (h *Handlers) GetQuestoesCount(c *gin.Context) {
-	filters := buildQuestaoFilters(c)
+	filters, err := buildQuestaoFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	count, err := h.questaoService.Count(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -58,14 +107,39 @@ func (h *Handlers) GetQuestoesCount(c *gin.Context) {
 }
 
 // GetQuestaoFilters godoc
-// @Summary      Listar filtros de questoes
+// @Summary      Listar filtros de questoes com contagens
+// @Description  Retorna os valores ainda alcancaveis a partir dos filtros informados, com o total de questoes de cada um. Cada faceta ignora o proprio filtro.
 // @Tags         questoes
 // @Produce      json
+// @Param        disciplina query []string false "Disciplina (repetível)" collectionFormat(multi)
+// @Param        assunto query []string false "Assunto (repetível)" collectionFormat(multi)
+// @Param        banca query []string false "Banca (repetível)" collectionFormat(multi)
+// @Param        orgao query []string false "Orgao (repetível)" collectionFormat(multi)
+// @Param        cargo query []string false "Cargo (repetível)" collectionFormat(multi)
+// @Param        concurso query []string false "Concurso (repetível)" collectionFormat(multi)
+// @Param        area_conhecimento query []string false "Area de conhecimento (repetível)" collectionFormat(multi)
+// @Param        tipo_questao query []string false "Tipo de questao (repetível)" collectionFormat(multi)
+// @Param        nivel query []string false "Nivel (repetível)" collectionFormat(multi)
+// @Param        dificuldade query []string false "Dificuldade (repetível)" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo"
+// @Param        ano_max query int false "Ano maximo"
+// @Param        acertos_min query number false "Percentual de acertos minimo"
+// @Param        acertos_max query number false "Percentual de acertos maximo"
+// @Param        anulada query bool false "Anulada"
+// @Param        desatualizada query bool false "Desatualizada"
+// @Param        questao_oculta query bool false "Questao oculta"
+// @Param        q query string false "Busca textual"
 // @Success      200 {object} model.QuestaoFiltersResponse
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /questoes/filtros [get]
 func (h *Handlers) GetQuestaoFilters(c *gin.Context) {
-	items, err := h.questaoService.GetFilterOptions()
+	filters, err := buildQuestaoFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	items, err := h.questaoService.GetFacets(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -75,12 +149,14 @@ func (h *Handlers) GetQuestaoFilters(c *gin.Context) {
 
 // GetQuestaoByID godoc
 // @Summary      Obter questao por ID
+// @Description  acertos_percentual e quantidade_resolucoes vem da fonte importada; estatisticas_plataforma traz o acerto e a distribuicao das respostas dos alunos da plataforma (primeira resposta de cada um), recalculados periodicamente.
 // @Tags         questoes
 // @Produce      json
 // @Param        id path int true "ID"
 // @Success      200 {object} model.Questao
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
 // @Router       /questoes/{id} [get]
 func (h *Handlers) GetQuestaoByID(c *gin.Context) {
 	id, ok := parseQuestaoID(c)
@@ -93,10 +169,33 @@ func (h *Handlers) GetQuestaoByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if item.EstatisticasPlataforma, err = h.estatisticaService.Get(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, item)
 }
 
+// RecalcularQuestaoEstatisticas godoc
+// @Summary      Recalcular as estatisticas da plataforma de todas as questoes (admin)
+// @Description  Roda em segundo plano; normalmente o recalculo e periodico e so considera as questoes respondidas ou recorrigidas desde a ultima execucao.
+// @Tags         questoes
+// @Produce      json
+// @Success      202 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /questoes/estatisticas/recalcular [post]
+func (h *Handlers) RecalcularQuestaoEstatisticas(c *gin.Context) {
+	if err := h.estatisticaService.RecalcularTudo(); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "recalculo iniciado"})
+}
+
 // CreateQuestao godoc
 // @Summary      Criar questao
 // @Tags         questoes
@@ -135,6 +234,10 @@ func (h *Handlers) CreateQuestao(c *gin.Context) {
 // @Failure      404 {object} map[string]string
 // @Router       /questoes/{id} [put]
 func (h *Handlers) UpdateQuestao(c *gin.Context) {
+	editorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	id, ok := parseQuestaoID(c)
 	if !ok {
 		return
@@ -146,8 +249,12 @@ func (h *Handlers) UpdateQuestao(c *gin.Context) {
 		return
 	}
 
-	item, err := h.questaoService.Update(id, &req)
+	item, err := h.questaoService.Update(editorID, id, &req)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -155,6 +262,38 @@ func (h *Handlers) UpdateQuestao(c *gin.Context) {
 	c.JSON(http.StatusOK, item)
 }
 
+// GetQuestaoHistorico godoc
+// @Summary      Historico de edicoes da questao (admin)
+// @Description  Versoes da questao, da mais recente para a mais antiga, com os campos alterados (valor anterior e novo), o editor e quantas respostas foram recorrigidas por mudanca de gabarito ou anulacao.
+// @Tags         questoes
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {array} model.QuestaoVersao
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/historico [get]
+func (h *Handlers) GetQuestaoHistorico(c *gin.Context) {
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	versoes, err := h.questaoService.History(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, versoes)
+}
+
 // DeleteQuestao godoc
 // @Summary      Remover questao
 // @Tags         questoes
@@ -177,6 +316,122 @@ func (h *Handlers) DeleteQuestao(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ImportQuestoes godoc
+// @Summary      Importar questoes em lote (admin)
+// @Description  Recebe um arquivo .xlsx, .csv ou .jsonl com as colunas (ou campos) de CreateQuestaoRequest e faz o upsert por id_questao ou, na falta dele, id_questao_original. Celulas vazias nao alteram a questao. Cada linha e salva separadamente e as que falharem sao listadas em erros.
+// @Tags         questoes
+// @Accept       mpfd
+// @Produce      json
+// @Param        file formData file true "Arquivo (.xlsx, .csv ou .jsonl)"
+// @Param        formato query string false "xlsx, csv ou jsonl (padrao: extensao do arquivo)"
+// @Success      200 {object} model.QuestaoImportReport
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/import [post]
+func (h *Handlers) ImportQuestoes(c *gin.Context) {
+	editorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado"})
+		return
+	}
+
+	formato := c.Query("formato")
+	if formato == "" {
+		formato = filepath.Ext(file.Filename)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Não foi possível abrir o arquivo"})
+		return
+	}
+	defer src.Close()
+
+	report, err := h.questaoService.Import(editorID, src, formato)
+	if err != nil {
+		respondQuestaoTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportQuestoes godoc
+// @Summary      Exportar questoes (admin)
+// @Description  Baixa as questoes que atendem aos filtros, ordenadas por id, em .jsonl (todos os campos) ou .xlsx (colunas da importacao mais id; celulas acima de 32767 caracteres ficam vazias). O arquivo pode ser reimportado em /questoes/import.
+// @Tags         questoes
+// @Produce      octet-stream
+// @Param        formato query string false "xlsx (padrao) ou jsonl"
+// @Param        q query string false "Busca textual"
+// @Param        disciplina query []string false "Disciplina (repetível)" collectionFormat(multi)
+// @Param        assunto query []string false "Assunto (repetível)" collectionFormat(multi)
+// @Param        banca query []string false "Banca (repetível)" collectionFormat(multi)
+// @Param        orgao query []string false "Orgao (repetível)" collectionFormat(multi)
+// @Param        cargo query []string false "Cargo (repetível)" collectionFormat(multi)
+// @Param        concurso query []string false "Concurso (repetível)" collectionFormat(multi)
+// @Param        area_conhecimento query []string false "Area de conhecimento (repetível)" collectionFormat(multi)
+// @Param        tipo_questao query []string false "Tipo de questao (repetível)" collectionFormat(multi)
+// @Param        nivel query []string false "Nivel (repetível)" collectionFormat(multi)
+// @Param        dificuldade query []string false "Dificuldade (repetível)" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo"
+// @Param        ano_max query int false "Ano maximo"
+// @Param        acertos_min query number false "Percentual de acertos minimo"
+// @Param        acertos_max query number false "Percentual de acertos maximo"
+// @Param        anulada query bool false "Anulada"
+// @Param        desatualizada query bool false "Desatualizada"
+// @Param        questao_oculta query bool false "Questao oculta"
+// @Success      200 {file} file
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/export [get]
+func (h *Handlers) ExportQuestoes(c *gin.Context) {
+	filters, err := buildQuestaoFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	formato := service.NormalizeQuestaoFormato(c.DefaultQuery("formato", service.QuestaoFormatoXLSX))
+	write, err := h.questaoService.Export(filters, formato)
+	if err != nil {
+		respondQuestaoTransferError(c, err)
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	if formato == service.QuestaoFormatoXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="questoes-%s.%s"`, time.Now().Format("20060102-150405"), formato))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	// The response is already streaming, so a failure can only be logged and
+	// leaves a truncated file.
+	if err := write(c.Writer); err != nil {
+		log.Printf("failed to export questoes: %v", err)
+	}
+}
+
+func respondQuestaoTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrQuestaoImportFormato), errors.Is(err, service.ErrQuestaoImportArquivo),
+		errors.Is(err, service.ErrQuestaoExportFormato), errors.Is(err, service.ErrQuestaoExportXLSX):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 func parseQuestaoID(c *gin.Context) (int, bool) {
 	idStr := strings.TrimSpace(c.Param("id"))
 	id, err := strconv.Atoi(idStr)
@@ -187,50 +442,107 @@ func parseQuestaoID(c *gin.Context) (int, bool) {
 	return id, true
 }
 
-func buildQuestaoFilters(c *gin.Context) *model.QuestaoFilters {
-	disciplina := strings.TrimSpace(c.Query("disciplina"))
-	assunto := strings.TrimSpace(c.Query("assunto"))
-	banca := strings.TrimSpace(c.Query("banca"))
-	orgao := strings.TrimSpace(c.Query("orgao"))
-	cargo := strings.TrimSpace(c.Query("cargo"))
-	concurso := strings.TrimSpace(c.Query("concurso"))
-	areaConhecimento := strings.TrimSpace(c.Query("area_conhecimento"))
+func buildQuestaoFilters(c *gin.Context) (*model.QuestaoFilters, error) {
+	filters := &model.QuestaoFilters{
+		Q:                strings.TrimSpace(c.Query("q")),
+		Disciplina:       queryValues(c, "disciplina"),
+		Assunto:          queryValues(c, "assunto"),
+		Banca:            queryValues(c, "banca"),
+		Orgao:            queryValues(c, "orgao"),
+		Cargo:            queryValues(c, "cargo"),
+		Concurso:         queryValues(c, "concurso"),
+		AreaConhecimento: queryValues(c, "area_conhecimento"),
+		TipoQuestao:      queryValues(c, "tipo_questao"),
+		Nivel:            queryValues(c, "nivel"),
+		Dificuldade:      queryValues(c, "dificuldade"),
+		Sort:             strings.TrimSpace(c.Query("sort")),
+		Order:            strings.TrimSpace(c.Query("order")),
+	}
 
-	var filters model.QuestaoFilters
-	hasAny := false
+	var err error
+	if filters.AnoMin, err = queryInt(c, "ano_min"); err != nil {
+		return nil, err
+	}
+	if filters.AnoMax, err = queryInt(c, "ano_max"); err != nil {
+		return nil, err
+	}
+	if filters.AcertosMin, err = queryFloat(c, "acertos_min"); err != nil {
+		return nil, err
+	}
+	if filters.AcertosMax, err = queryFloat(c, "acertos_max"); err != nil {
+		return nil, err
+	}
+	if filters.Anulada, err = queryBool(c, "anulada"); err != nil {
+		return nil, err
+	}
+	if filters.Desatualizada, err = queryBool(c, "desatualizada"); err != nil {
+		return nil, err
+	}
+	if filters.QuestaoOculta, err = queryBool(c, "questao_oculta"); err != nil {
+		return nil, err
+	}
 
-	if disciplina != "" {
-		filters.Disciplina = &disciplina
-		hasAny = true
+	page, err := queryInt(c, "page")
+	if err != nil {
+		return nil, err
 	}
-	if assunto != "" {
-		filters.Assunto = &assunto
-		hasAny = true
+	if page != nil {
+		filters.Page = *page
 	}
-	if banca != "" {
-		filters.Banca = &banca
-		hasAny = true
+	pageSize, err := queryInt(c, "page_size")
+	if err != nil {
+		return nil, err
 	}
-	if orgao != "" {
-		filters.Orgao = &orgao
-		hasAny = true
+	if pageSize != nil {
+		filters.PageSize = *pageSize
 	}
-	if cargo != "" {
-		filters.Cargo = &cargo
-		hasAny = true
+
+	return filters, nil
+}
+
+// queryValues returns the non-empty values of a repeatable query parameter.
+func queryValues(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range c.QueryArray(key) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
 	}
-	if concurso != "" {
-		filters.Concurso = &concurso
-		hasAny = true
+	return values
+}
+
+func queryInt(c *gin.Context, key string) (*int, error) {
+	value := strings.TrimSpace(c.Query(key))
+	if value == "" {
+		return nil, nil
 	}
-	if areaConhecimento != "" {
-		filters.AreaConhecimento = &areaConhecimento
-		hasAny = true
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s invalido", key)
 	}
+	return &parsed, nil
+}
 
-	if !hasAny {
-		return nil
+func queryFloat(c *gin.Context, key string) (*float64, error) {
+	value := strings.TrimSpace(c.Query(key))
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s invalido", key)
 	}
+	return &parsed, nil
+}
 
-	return &filters
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	value := strings.TrimSpace(c.Query(key))
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s invalido", key)
+	}
+	return &parsed, nil
 }
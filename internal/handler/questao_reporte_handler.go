@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// ReportarQuestao godoc
+// @Summary      Reportar erro em questao
+// @Description  Motivos: gabarito_errado, desatualizada, formatacao, enunciado ou outro. O usuario e notificado quando o reporte for analisado.
+// @Tags         questoes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID"
+// @Param        request body model.ReportarQuestaoRequest true "Reporte"
+// @Success      201 {object} model.QuestaoReporte
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/reportar [post]
+func (h *Handlers) ReportarQuestao(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ReportarQuestaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reporte, err := h.questaoReporteService.Report(userID, id, &req)
+	if err != nil {
+		respondQuestaoReporteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reporte)
+}
+
+// GetQuestaoReportesFila godoc
+// @Summary      Fila de moderacao de reportes (admin)
+// @Description  Questoes reportadas agrupadas, das mais reportadas para as menos e, no empate, das mais antigas.
+// @Tags         questoes
+// @Produce      json
+// @Param        status query string false "pendente (padrao), resolvido ou rejeitado"
+// @Param        motivo query string false "Motivo do reporte"
+// @Param        limit query int false "Itens por pagina (padrao 20, maximo 100)"
+// @Param        offset query int false "Deslocamento"
+// @Success      200 {object} model.QuestaoReporteFila
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/reportes [get]
+func (h *Handlers) GetQuestaoReportesFila(c *gin.Context) {
+	filters := &model.QuestaoReporteFilters{
+		Status: strings.ToLower(strings.TrimSpace(c.Query("status"))),
+		Motivo: strings.ToLower(strings.TrimSpace(c.Query("motivo"))),
+	}
+	filters.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filters.Offset, _ = strconv.Atoi(c.Query("offset"))
+
+	fila, err := h.questaoReporteService.Fila(filters)
+	if err != nil {
+		respondQuestaoReporteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fila)
+}
+
+// GetQuestaoReportes godoc
+// @Summary      Listar reportes de uma questao (admin)
+// @Tags         questoes
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {array} model.QuestaoReporte
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/reportes [get]
+func (h *Handlers) GetQuestaoReportes(c *gin.Context) {
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	reportes, err := h.questaoReporteService.ListByQuestao(id)
+	if err != nil {
+		respondQuestaoReporteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reportes)
+}
+
+// ResolverQuestaoReportes godoc
+// @Summary      Resolver reportes de uma questao (admin)
+// @Description  Fecha os reportes pendentes da questao (ou apenas reporte_ids) como resolvido ou rejeitado. Ao resolver, anulada e desatualizada atualizam a questao; outras correcoes sao feitas em PUT /questoes/{id}. Cada usuario que reportou recebe uma notificacao.
+// @Tags         questoes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID"
+// @Param        request body model.ResolverReportesRequest true "Resolucao"
+// @Success      200 {object} model.ResolverReportesResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/reportes/resolver [post]
+func (h *Handlers) ResolverQuestaoReportes(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ResolverReportesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.questaoReporteService.Resolve(adminID, id, &req)
+	if err != nil {
+		respondQuestaoReporteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func respondQuestaoReporteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+	case errors.Is(err, service.ErrReportesPendentesVazio):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReporteDuplicado):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidReporteMotivo), errors.Is(err, service.ErrReporteDescricaoVazia),
+		errors.Is(err, service.ErrInvalidReporteStatus), errors.Is(err, service.ErrReporteRejeitadoComAcao):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// ResponderQuestao godoc
+// @Summary      Responder questao
+// @Description  Corrige a resposta pelo gabarito, registra a tentativa e retorna o comentario e a resolucao da banca. Questoes anuladas contam como acerto.
+// @Tags         questoes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID"
+// @Param        request body model.ResponderQuestaoRequest true "Resposta"
+// @Success      201 {object} model.ResponderQuestaoResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      422 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/responder [post]
+func (h *Handlers) ResponderQuestao(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ResponderQuestaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.questionAttemptService.Answer(userID, id, &req)
+	if err != nil {
+		respondQuestionAttemptError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetQuestaoTentativas godoc
+// @Summary      Listar minhas tentativas na questao
+// @Tags         questoes
+// @Produce      json
+// @Param        id path int true "ID"
+// @Success      200 {array} model.QuestionAttempt
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/tentativas [get]
+func (h *Handlers) GetQuestaoTentativas(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.questionAttemptService.ListAttempts(userID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func respondQuestionAttemptError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidResposta):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+	case errors.Is(err, service.ErrQuestaoSemGabarito), errors.Is(err, service.ErrQuestaoDiscursiva):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
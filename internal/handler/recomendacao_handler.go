@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+)
+
+// GetQuestoesRecomendadas godoc
+// @Summary      Questoes recomendadas
+// @Description  Questoes nao respondidas nos ultimos 30 dias dos assuntos em que o acerto do usuario mais fica abaixo do esperado para a dificuldade das questoes, dentro do perfil de estudo (/me/perfil-estudo). O que faltar e completado com questoes do perfil.
+// @Tags         questoes
+// @Produce      json
+// @Param        limit query int false "Quantidade (padrao 10, maximo 50)"
+// @Param        disciplina query []string false "Disciplinas" collectionFormat(multi)
+// @Success      200 {object} model.QuestoesRecomendadas
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/recomendadas [get]
+func (h *Handlers) GetQuestoesRecomendadas(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.recomendacaoService.Recomendar(userID, parseInt(c.Query("limit"), 0), queryValues(c, "disciplina"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPerfilEstudo godoc
+// @Summary      Obter perfil de estudo
+// @Tags         me
+// @Produce      json
+// @Success      200 {object} model.PerfilEstudo
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/perfil-estudo [get]
+func (h *Handlers) GetPerfilEstudo(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	perfil, err := h.recomendacaoService.GetPerfil(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, perfil)
+}
+
+// UpdatePerfilEstudo godoc
+// @Summary      Atualizar perfil de estudo
+// @Description  Bancas, concursos, cargos e orgaos alvo; listas vazias nao restringem as recomendacoes.
+// @Tags         me
+// @Accept       json
+// @Produce      json
+// @Param        request body model.UpdatePerfilEstudoRequest true "Perfil"
+// @Success      200 {object} model.PerfilEstudo
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/perfil-estudo [put]
+func (h *Handlers) UpdatePerfilEstudo(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdatePerfilEstudoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	perfil, err := h.recomendacaoService.UpdatePerfil(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, perfil)
+}
@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// EnviarRespostaDiscursiva godoc
+// @Summary      Enviar resposta a questao discursiva
+// @Description  O texto vai para a fila de correcao com a rubrica da questao. As linhas sao contadas como na folha de resposta (cada quebra de linha inicia uma linha e linhas longas quebram a cada 80 caracteres) e nao podem passar de linhas_maximas. So e possivel ter uma resposta aguardando correcao por questao; o usuario e notificado quando ela for corrigida.
+// @Tags         questoes
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID"
+// @Param        request body model.EnviarRespostaDiscursivaRequest true "Resposta"
+// @Success      201 {object} model.RespostaDiscursiva
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      422 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /questoes/{id}/discursivas [post]
+func (h *Handlers) EnviarRespostaDiscursiva(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseQuestaoID(c)
+	if !ok {
+		return
+	}
+
+	var req model.EnviarRespostaDiscursivaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.discursivaService.Enviar(userID, id, &req)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// GetMinhasRespostasDiscursivas godoc
+// @Summary      Listar minhas respostas discursivas
+// @Description  Das mais recentes para as mais antigas, com a questao e, quando corrigidas, as notas por item da rubrica e os comentarios.
+// @Tags         discursivas
+// @Produce      json
+// @Param        status query string false "pendente, em_correcao ou corrigida"
+// @Param        questao_id query int false "ID da questao"
+// @Param        limit query int false "Itens por pagina (padrao 20, maximo 100)"
+// @Param        offset query int false "Deslocamento"
+// @Success      200 {object} model.RespostaDiscursivaList
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /discursivas [get]
+func (h *Handlers) GetMinhasRespostasDiscursivas(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	filters, ok := buildRespostaDiscursivaFilters(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.discursivaService.ListMinhas(userID, filters)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// GetMinhaRespostaDiscursiva godoc
+// @Summary      Obter minha resposta discursiva
+// @Tags         discursivas
+// @Produce      json
+// @Param        id path string true "ID da resposta"
+// @Success      200 {object} model.RespostaDiscursiva
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /discursivas/{id} [get]
+func (h *Handlers) GetMinhaRespostaDiscursiva(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRespostaDiscursivaID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.discursivaService.GetMinha(userID, id)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetFilaCorrecao godoc
+// @Summary      Fila de correcao de respostas discursivas (corretor)
+// @Description  Sem status, lista as respostas disponiveis e as reservadas pelo corretor, das mais antigas para as mais novas. pendente lista as disponiveis (inclusive reservas vencidas), em_correcao as reservadas pelo corretor e corrigida as que ele corrigiu, das mais recentes para as mais antigas.
+// @Tags         correcoes
+// @Produce      json
+// @Param        status query string false "pendente, em_correcao ou corrigida"
+// @Param        disciplina query []string false "Disciplina da questao (repetível)" collectionFormat(multi)
+// @Param        questao_id query int false "ID da questao"
+// @Param        limit query int false "Itens por pagina (padrao 20, maximo 100)"
+// @Param        offset query int false "Deslocamento"
+// @Success      200 {object} model.RespostaDiscursivaList
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /correcoes [get]
+func (h *Handlers) GetFilaCorrecao(c *gin.Context) {
+	corretorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	filters, ok := buildRespostaDiscursivaFilters(c)
+	if !ok {
+		return
+	}
+	filters.Disciplina = queryValues(c, "disciplina")
+
+	list, err := h.discursivaService.Fila(corretorID, filters)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// GetCorrecao godoc
+// @Summary      Obter resposta discursiva para correcao (corretor)
+// @Tags         correcoes
+// @Produce      json
+// @Param        id path string true "ID da resposta"
+// @Success      200 {object} model.RespostaDiscursiva
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /correcoes/{id} [get]
+func (h *Handlers) GetCorrecao(c *gin.Context) {
+	id, ok := parseRespostaDiscursivaID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.discursivaService.Get(id)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// ReservarCorrecao godoc
+// @Summary      Reservar resposta discursiva (corretor)
+// @Description  Reserva a resposta para o corretor por 24 horas; depois disso ela volta para a fila. Reservar de novo renova o prazo.
+// @Tags         correcoes
+// @Produce      json
+// @Param        id path string true "ID da resposta"
+// @Success      200 {object} model.RespostaDiscursiva
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /correcoes/{id}/reservar [post]
+func (h *Handlers) ReservarCorrecao(c *gin.Context) {
+	corretorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRespostaDiscursivaID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.discursivaService.Reservar(corretorID, id)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// LiberarCorrecao godoc
+// @Summary      Liberar resposta discursiva reservada (corretor)
+// @Tags         correcoes
+// @Param        id path string true "ID da resposta"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /correcoes/{id}/liberar [post]
+func (h *Handlers) LiberarCorrecao(c *gin.Context) {
+	corretorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRespostaDiscursivaID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.discursivaService.Liberar(corretorID, id); err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CorrigirRespostaDiscursiva godoc
+// @Summary      Corrigir resposta discursiva (corretor)
+// @Description  Uma nota por item da rubrica, na ordem da rubrica, entre zero e a pontuacao do item, com comentarios opcionais. A resposta precisa estar reservada pelo corretor. Com 60% ou mais dos pontos a resposta conta como acerto no desempenho do aluno, que e notificado da nota.
+// @Tags         correcoes
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da resposta"
+// @Param        request body model.CorrigirRespostaDiscursivaRequest true "Correcao"
+// @Success      200 {object} model.RespostaDiscursiva
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /correcoes/{id}/corrigir [post]
+func (h *Handlers) CorrigirRespostaDiscursiva(c *gin.Context) {
+	corretorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRespostaDiscursivaID(c)
+	if !ok {
+		return
+	}
+
+	var req model.CorrigirRespostaDiscursivaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.discursivaService.Corrigir(corretorID, id, &req)
+	if err != nil {
+		respondRespostaDiscursivaError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func buildRespostaDiscursivaFilters(c *gin.Context) (*model.RespostaDiscursivaFilters, bool) {
+	questaoID, err := queryInt(c, "questao_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	filters := &model.RespostaDiscursivaFilters{
+		Status:    strings.ToLower(strings.TrimSpace(c.Query("status"))),
+		QuestaoID: questaoID,
+	}
+	filters.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filters.Offset, _ = strconv.Atoi(c.Query("offset"))
+	return filters, true
+}
+
+func parseRespostaDiscursivaID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func respondRespostaDiscursivaError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "questao nao encontrada"})
+	case errors.Is(err, service.ErrRespostaDiscursivaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRespostaDiscursivaVazia), errors.Is(err, service.ErrRespostaDiscursivaLinhas),
+		errors.Is(err, service.ErrInvalidRespostaDiscursivaStatus), errors.Is(err, service.ErrRubricaNotasInvalidas):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRespostaDiscursivaAberta), errors.Is(err, service.ErrRespostaDiscursivaIndisponivel),
+		errors.Is(err, service.ErrRespostaDiscursivaNaoReservada):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrQuestaoNaoDiscursiva), errors.Is(err, service.ErrQuestaoSemRubrica):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+)
+
+// GetRevisaoHoje godoc
+// @Summary      Revisao do dia
+// @Description  Questoes erradas agendadas (SM-2) para revisar ate o fim do dia, respeitando o limite diario. A revisao e feita respondendo a questao em /questoes/{id}/responder.
+// @Tags         revisao
+// @Produce      json
+// @Success      200 {object} model.RevisaoHoje
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /revisao/hoje [get]
+func (h *Handlers) GetRevisaoHoje(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	today, err := h.revisaoService.Today(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, today)
+}
+
+// GetRevisaoConfig godoc
+// @Summary      Obter configuracoes da revisao
+// @Tags         revisao
+// @Produce      json
+// @Success      200 {object} model.RevisaoConfig
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /revisao/configuracoes [get]
+func (h *Handlers) GetRevisaoConfig(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	config, err := h.revisaoService.GetConfig(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpdateRevisaoConfig godoc
+// @Summary      Atualizar configuracoes da revisao
+// @Tags         revisao
+// @Accept       json
+// @Produce      json
+// @Param        request body model.UpdateRevisaoConfigRequest true "Configuracoes"
+// @Success      200 {object} model.RevisaoConfig
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /revisao/configuracoes [put]
+func (h *Handlers) UpdateRevisaoConfig(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateRevisaoConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config, err := h.revisaoService.UpdateConfig(userID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRevisaoConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// RemoveRevisaoQuestao godoc
+// @Summary      Tirar questao da revisao
+// @Description  A questao volta para a revisao se for respondida errada de novo.
+// @Tags         revisao
+// @Param        questaoId path int true "ID da questao"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /revisao/questoes/{questaoId} [delete]
+func (h *Handlers) RemoveRevisaoQuestao(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	questaoID, err := strconv.Atoi(c.Param("questaoId"))
+	if err != nil || questaoID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da questao invalido"})
+		return
+	}
+
+	if err := h.revisaoService.Remove(userID, questaoID); err != nil {
+		if errors.Is(err, service.ErrRevisaoCardNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
+)
+
+// CreateSimulado godoc
+// @Summary      Gerar simulado
+// @Description  Sorteia as questoes de cada bloco (sem repetir questoes ja respondidas, anuladas, desatualizadas ou ocultas) e inicia o cronometro.
+// @Tags         simulados
+// @Accept       json
+// @Produce      json
+// @Param        request body model.CreateSimuladoRequest true "Blocos do simulado"
+// @Success      201 {object} model.SimuladoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      422 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /simulados [post]
+func (h *Handlers) CreateSimulado(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req model.CreateSimuladoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	simulado, err := h.simuladoService.Create(userID, &req)
+	if err != nil {
+		respondSimuladoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, simulado)
+}
+
+// GetSimulados godoc
+// @Summary      Listar meus simulados
+// @Tags         simulados
+// @Produce      json
+// @Success      200 {array} model.Simulado
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /simulados [get]
+func (h *Handlers) GetSimulados(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.simuladoService.List(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// GetSimulado godoc
+// @Summary      Obter simulado
+// @Description  O gabarito e os comentarios so aparecem depois de finalizado.
+// @Tags         simulados
+// @Produce      json
+// @Param        id path string true "ID do simulado"
+// @Success      200 {object} model.SimuladoDetail
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /simulados/{id} [get]
+func (h *Handlers) GetSimulado(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseSimuladoID(c)
+	if !ok {
+		return
+	}
+
+	simulado, err := h.simuladoService.Get(userID, id)
+	if err != nil {
+		respondSimuladoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, simulado)
+}
+
+// ResponderSimulado godoc
+// @Summary      Responder questao do simulado
+// @Description  Grava ou troca a resposta da questao na posicao informada. Respostas depois do prazo sao recusadas.
+// @Tags         simulados
+// @Accept       json
+// @Param        id path string true "ID do simulado"
+// @Param        ordem path int true "Posicao da questao (a partir de 1)"
+// @Param        request body model.ResponderSimuladoRequest true "Resposta"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /simulados/{id}/questoes/{ordem} [put]
+func (h *Handlers) ResponderSimulado(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseSimuladoID(c)
+	if !ok {
+		return
+	}
+	ordem, err := strconv.Atoi(c.Param("ordem"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ordem invalida"})
+		return
+	}
+
+	var req model.ResponderSimuladoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.simuladoService.Answer(userID, id, ordem, &req); err != nil {
+		respondSimuladoError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// FinalizarSimulado godoc
+// @Summary      Finalizar simulado
+// @Description  Corrige o simulado, registra as respostas no desempenho e retorna o resultado por disciplina. Pode ser chamado de novo para obter o resultado.
+// @Tags         simulados
+// @Produce      json
+// @Param        id path string true "ID do simulado"
+// @Success      200 {object} model.SimuladoReport
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /simulados/{id}/finalizar [post]
+func (h *Handlers) FinalizarSimulado(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseSimuladoID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.simuladoService.Finish(userID, id)
+	if err != nil {
+		respondSimuladoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func parseSimuladoID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func respondSimuladoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidResposta), errors.Is(err, service.ErrSimuladoTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "simulado nao encontrado"})
+	case errors.Is(err, service.ErrSimuladoQuestaoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSimuladoExpirado), errors.Is(err, service.ErrSimuladoFinalizado):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSimuladoQuestoesInsuficientes):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,15 +1,26 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+)
+
+const (
+	// oauthFlowCookie binds an OAuth flow to the browser that started it. It
+	// is scoped to the auth routes, which start the flows and receive the
+	// callbacks.
+	oauthFlowCookie     = "oauth_flow"
+	oauthFlowCookiePath = "/api/v1/auth"
 )
 
 // SocialLogin godoc
 // @Summary      Login com provedor social
-// @Description  Autentica usuário usando Google ou Facebook OAuth2
+// @Description  Autentica usuário com o access token de um provedor registrado (google, facebook ou OIDC configurado). Provedores OIDC exigem também o id_token emitido para o client_id da API, validado pela JWKS e com o mesmo sub do userinfo
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -17,6 +28,7 @@ import (
 // @Success      200      {object}  model.LoginResponse
 // @Failure      400      {object}  map[string]string
 // @Failure      401      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
 // @Router       /auth/social [post]
 func (h *Handlers) SocialLogin(c *gin.Context) {
 	var req model.SocialAuthRequest
@@ -25,124 +37,303 @@ func (h *Handlers) SocialLogin(c *gin.Context) {
 		return
 	}
 
-	user, err := h.socialAuthService.AuthenticateWithToken(req.Provider, req.AccessToken)
+	user, err := h.socialAuthService.AuthenticateWithToken(req.Provider, req.AccessToken, req.IDToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondSocialError(c, err)
 		return
 	}
 
-	// Gerar token JWT
-	token, expiresIn, err := h.authService.GenerateToken(user)
+	response, err := h.authService.CompleteLogin(user, clientInfo(c))
 	if err != nil {
+		if respondAccountDisabled(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, model.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *user,
-	})
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSocialProviders godoc
+// @Summary      Listar provedores de login social
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  map[string][]string
+// @Router       /auth/providers [get]
+func (h *Handlers) GetSocialProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.socialAuthService.Providers()})
+}
+
+// SocialAuthURL godoc
+// @Summary      Obter URL de autenticação de um provedor
+// @Description  Retorna URL de autorização de qualquer provedor registrado, incluindo provedores OpenID Connect configurados via OIDC_PROVIDERS
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provedor"
+// @Success      200       {object}  map[string]string
+// @Failure      400       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /auth/{provider}/url [get]
+func (h *Handlers) SocialAuthURL(c *gin.Context) {
+	h.socialAuthURL(c, c.Param("provider"))
+}
+
+// SocialCallback godoc
+// @Summary      Callback OAuth de um provedor
+// @Description  Processa retorno do provedor validando o state contra o cookie oauth_flow do navegador, PKCE e, para OIDC, o ID token (assinatura JWKS, issuer, audience e nonce)
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provedor"
+// @Param        code      query     string  true  "Authorization code"
+// @Param        state     query     string  true  "State token"
+// @Success      200       {object}  model.LoginResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Router       /auth/{provider}/callback [get]
+func (h *Handlers) SocialCallback(c *gin.Context) {
+	h.socialCallback(c, c.Param("provider"))
 }
 
 // GoogleAuthURL godoc
 // @Summary      Obter URL de autenticação do Google
-// @Description  Retorna URL para redirecionar usuário ao login do Google
+// @Description  Retorna URL para redirecionar usuário ao login do Google (state e PKCE guardados no cookie oauth_flow)
 // @Tags         auth
 // @Produce      json
 // @Success      200  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
 // @Router       /auth/google/url [get]
 func (h *Handlers) GoogleAuthURL(c *gin.Context) {
-	state := "random-state-token" // Em produção, use um token aleatório e salve na sessão
-	url := h.socialAuthService.GetGoogleAuthURL(state)
-	c.JSON(http.StatusOK, gin.H{"url": url})
+	h.socialAuthURL(c, "google")
 }
 
 // GoogleCallback godoc
 // @Summary      Callback do Google OAuth
-// @Description  Processa retorno do Google após autorização
+// @Description  Processa retorno do Google após autorização, validando o state contra o cookie oauth_flow e PKCE
 // @Tags         auth
 // @Produce      json
 // @Param        code   query     string  true  "Authorization code"
 // @Param        state  query     string  true  "State token"
 // @Success      200    {object}  model.LoginResponse
 // @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
 // @Router       /auth/google/callback [get]
 func (h *Handlers) GoogleCallback(c *gin.Context) {
-	code := c.Query("code")
-	// state := c.Query("state") // Validar state em produção
-
-	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing authorization code"})
-		return
-	}
-
-	user, err := h.socialAuthService.AuthenticateWithGoogle(code)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-		return
-	}
-
-	token, expiresIn, err := h.authService.GenerateToken(user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
-		return
-	}
-
-	c.JSON(http.StatusOK, model.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *user,
-	})
+	h.socialCallback(c, "google")
 }
 
 // FacebookAuthURL godoc
 // @Summary      Obter URL de autenticação do Facebook
-// @Description  Retorna URL para redirecionar usuário ao login do Facebook
+// @Description  Retorna URL para redirecionar usuário ao login do Facebook (state e PKCE guardados no cookie oauth_flow)
 // @Tags         auth
 // @Produce      json
 // @Success      200  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
 // @Router       /auth/facebook/url [get]
 func (h *Handlers) FacebookAuthURL(c *gin.Context) {
-	state := "random-state-token" // Em produção, use um token aleatório e salve na sessão
-	url := h.socialAuthService.GetFacebookAuthURL(state)
-	c.JSON(http.StatusOK, gin.H{"url": url})
+	h.socialAuthURL(c, "facebook")
 }
 
 // FacebookCallback godoc
 // @Summary      Callback do Facebook OAuth
-// @Description  Processa retorno do Facebook após autorização
+// @Description  Processa retorno do Facebook após autorização, validando o state contra o cookie oauth_flow e PKCE
 // @Tags         auth
 // @Produce      json
 // @Param        code   query     string  true  "Authorization code"
 // @Param        state  query     string  true  "State token"
 // @Success      200    {object}  model.LoginResponse
 // @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
 // @Router       /auth/facebook/callback [get]
 func (h *Handlers) FacebookCallback(c *gin.Context) {
+	h.socialCallback(c, "facebook")
+}
+
+// LinkProvider godoc
+// @Summary      Vincular provedor social
+// @Description  Vincula uma conta do provedor ao usuário autenticado. Com access_token o vínculo é imediato; sem ele, retorna a URL de autorização e o vínculo é concluído no callback
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string                     true   "Provedor (google, facebook ou OIDC configurado)"
+// @Param        request   body      model.LinkProviderRequest  false  "Token de acesso do provedor"
+// @Success      200       {object}  model.LinkProviderResponse
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      409       {object}  map[string]string
+// @Router       /auth/link/{provider} [post]
+func (h *Handlers) LinkProvider(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	provider := c.Param("provider")
+
+	var req model.LinkProviderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if req.AccessToken == "" {
+		flow, err := h.socialAuthService.AuthURL(provider, model.OAuthPurposeLink, userID)
+		if err != nil {
+			respondSocialError(c, err)
+			return
+		}
+		h.setOAuthFlowCookie(c, flow.Cookie, int(service.OAuthFlowTTL.Seconds()))
+		c.JSON(http.StatusOK, model.LinkProviderResponse{URL: flow.URL})
+		return
+	}
+
+	identity, err := h.socialAuthService.LinkWithToken(userID, provider, req.AccessToken, req.IDToken)
+	if err != nil {
+		respondSocialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.LinkProviderResponse{Identity: identity})
+}
+
+// UnlinkProvider godoc
+// @Summary      Desvincular provedor social
+// @Description  Remove o vínculo do provedor com o usuário autenticado
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provedor (google, facebook ou OIDC configurado)"
+// @Success      200       {object}  map[string]string
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Router       /auth/unlink/{provider} [post]
+func (h *Handlers) UnlinkProvider(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.socialAuthService.Unlink(userID, c.Param("provider")); err != nil {
+		respondSocialError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Provider unlinked successfully"})
+}
+
+// GetIdentities godoc
+// @Summary      Listar provedores vinculados
+// @Tags         auth
+// @Produce      json
+// @Success      200  {array}   model.UserIdentity
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /auth/identities [get]
+func (h *Handlers) GetIdentities(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	identities, err := h.socialAuthService.GetIdentities(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, identities)
+}
+
+func (h *Handlers) socialAuthURL(c *gin.Context, provider string) {
+	flow, err := h.socialAuthService.AuthURL(provider, model.OAuthPurposeLogin, uuid.Nil)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedProvider) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.setOAuthFlowCookie(c, flow.Cookie, int(service.OAuthFlowTTL.Seconds()))
+	c.JSON(http.StatusOK, gin.H{"url": flow.URL})
+}
+
+func (h *Handlers) socialCallback(c *gin.Context, provider string) {
 	code := c.Query("code")
-	// state := c.Query("state") // Validar state em produção
+	state := c.Query("state")
 
 	if code == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing authorization code"})
 		return
 	}
+	if state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing state"})
+		return
+	}
+
+	// The flow cookie is cleared whatever the outcome, so a callback URL
+	// cannot be completed twice.
+	cookie, _ := c.Cookie(oauthFlowCookie)
+	h.setOAuthFlowCookie(c, "", -1)
 
-	user, err := h.socialAuthService.AuthenticateWithFacebook(code)
+	result, err := h.socialAuthService.HandleCallback(provider, code, state, cookie)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondSocialError(c, err)
 		return
 	}
 
-	token, expiresIn, err := h.authService.GenerateToken(user)
+	if result.Purpose == model.OAuthPurposeLink {
+		c.JSON(http.StatusOK, model.LinkProviderResponse{Identity: result.Identity})
+		return
+	}
+
+	response, err := h.authService.CompleteLogin(result.User, clientInfo(c))
 	if err != nil {
+		if respondAccountDisabled(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, model.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *user,
+	c.JSON(http.StatusOK, response)
+}
+
+// setOAuthFlowCookie stores the flow cookie for maxAge seconds, or deletes it
+// when maxAge is negative. SameSite=Lax lets it come back on the provider's
+// top-level redirect to the callback.
+func (h *Handlers) setOAuthFlowCookie(c *gin.Context, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oauthFlowCookie,
+		Value:    value,
+		Path:     oauthFlowCookiePath,
+		MaxAge:   maxAge,
+		Secure:   h.secureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
 	})
 }
+
+func respondSocialError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedProvider):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidOAuthState):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSocialAccountExists),
+		errors.Is(err, service.ErrIdentityAlreadyLinked),
+		errors.Is(err, service.ErrProviderAlreadyLinked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrProviderNotLinked):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLastLoginMethodRemoval):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"github.com/thepantheon/api/pkg/middleware"
+)
+
+// GetTwoFactorStatus godoc
+// @Summary      Status da autenticação em dois fatores
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  model.TwoFactorStatusResponse
+// @Failure      401  {object}  map[string]string
+// @Router       /auth/2fa [get]
+func (h *Handlers) GetTwoFactorStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	status, err := h.twoFactorService.Status(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SetupTwoFactor godoc
+// @Summary      Iniciar cadastro do TOTP
+// @Description  Gera um novo segredo TOTP e a URI otpauth:// (payload do QR code). Usuários autenticados usam o Bearer token; administradores sem 2FA usam o two_factor_token recebido no login
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TwoFactorSetupRequest  false  "Token de desafio (apenas sem Bearer token)"
+// @Success      200      {object}  model.TwoFactorSetupResponse
+// @Failure      401      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /auth/2fa/setup [post]
+func (h *Handlers) SetupTwoFactor(c *gin.Context) {
+	var user *model.User
+	if userID, ok := middleware.GetUserID(c); ok {
+		found, err := h.userService.GetUserByID(userID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		user = found
+	} else {
+		var req model.TwoFactorSetupRequest
+		if err := c.ShouldBindJSON(&req); err != nil || req.TwoFactorToken == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		found, err := h.twoFactorService.SetupUser(req.TwoFactorToken)
+		if err != nil {
+			respondTwoFactorError(c, err)
+			return
+		}
+		user = found
+	}
+
+	response, err := h.twoFactorService.Setup(user)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EnableTwoFactor godoc
+// @Summary      Ativar autenticação em dois fatores
+// @Description  Confirma o segredo gerado no setup com um código TOTP e retorna os códigos de recuperação (exibidos uma única vez)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TwoFactorCodeRequest  true  "Código TOTP"
+// @Success      200      {object}  model.TwoFactorRecoveryCodesResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /auth/2fa/enable [post]
+func (h *Handlers) EnableTwoFactor(c *gin.Context) {
+	user, req, ok := h.twoFactorRequest(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactorService.Enable(user, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor godoc
+// @Summary      Desativar autenticação em dois fatores
+// @Description  Desativa o 2FA mediante código TOTP ou de recuperação. Não permitido para administradores
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TwoFactorCodeRequest  true  "Código TOTP ou de recuperação"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Router       /auth/2fa/disable [post]
+func (h *Handlers) DisableTwoFactor(c *gin.Context) {
+	user, req, ok := h.twoFactorRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.twoFactorService.Disable(user, req.Code); err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Gerar novos códigos de recuperação
+// @Description  Invalida os códigos de recuperação atuais e gera novos
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TwoFactorCodeRequest  true  "Código TOTP ou de recuperação"
+// @Success      200      {object}  model.TwoFactorRecoveryCodesResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /auth/2fa/recovery-codes [post]
+func (h *Handlers) RegenerateRecoveryCodes(c *gin.Context) {
+	user, req, ok := h.twoFactorRequest(c)
+	if !ok {
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(user, req.Code)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// VerifyTwoFactor godoc
+// @Summary      Concluir login com segundo fator
+// @Description  Troca o two_factor_token do login e um código TOTP (ou de recuperação) pelos tokens de acesso. Em desafios de setup o código também ativa o 2FA e os códigos de recuperação são retornados
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TwoFactorVerifyRequest  true  "Desafio e código"
+// @Success      200      {object}  model.LoginResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      429      {object}  map[string]string
+// @Router       /auth/2fa/verify [post]
+func (h *Handlers) VerifyTwoFactor(c *gin.Context) {
+	var req model.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.authService.VerifyTwoFactor(req.TwoFactorToken, req.Code, clientInfo(c))
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// twoFactorRequest loads the authenticated user and binds the code payload.
+func (h *Handlers) twoFactorRequest(c *gin.Context) (*model.User, *model.TwoFactorCodeRequest, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	var req model.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+
+	user, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return nil, nil, false
+	}
+	return user, &req, true
+}
+
+func respondTwoFactorError(c *gin.Context, err error) {
+	if respondThrottled(c, err) || respondAccountDisabled(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode),
+		errors.Is(err, service.ErrInvalidTwoFactorToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorMandatory):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorNotEnabled),
+		errors.Is(err, service.ErrTwoFactorSetupMissing):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+	"gorm.io/gorm"
 )
 
 func (h *Handlers) CreateUser(c *gin.Context) {
@@ -42,33 +46,64 @@ func (h *Handlers) GetUser(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// GetUsers godoc
+// @Summary      Listar usuários (admin)
+// @Description  Lista usuários com busca por nome/e-mail, filtros, ordenação e totais
+// @Tags         users
+// @Produce      json
+// @Param        q         query     string  false  "Busca por nome ou e-mail"
+// @Param        role      query     string  false  "Papel (user, admin)"
+// @Param        plan_id   query     string  false  "ID do plano ou none para usuários sem plano"
+// @Param        active    query     bool    false  "Ativo"
+// @Param        provider  query     string  false  "Provedor de login (local, google, facebook, ...)"
+// @Param        sort      query     string  false  "created_at, updated_at, full_name, email ou role"
+// @Param        order     query     string  false  "asc ou desc (padrão desc)"
+// @Param        limit     query     int     false  "Itens por página (padrão 20, máximo 100)"
+// @Param        offset    query     int     false  "Deslocamento"
+// @Success      200  {object}  model.UserListResponse
+// @Failure      400  {object}  map[string]string
+// @Router       /users [get]
 func (h *Handlers) GetUsers(c *gin.Context) {
-	limit := 10
-	offset := 0
+	filters := &model.UserFilters{
+		Search: strings.TrimSpace(c.Query("q")),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+	}
+	filters.Limit, _ = strconv.Atoi(c.Query("limit"))
+	filters.Offset, _ = strconv.Atoi(c.Query("offset"))
 
-	if l := c.Query("limit"); l != "" {
-		if parsedLimit, err := strconv.Atoi(l); err == nil {
-			limit = parsedLimit
+	if role := c.Query("role"); role != "" {
+		filters.Role = &role
+	}
+	if provider := c.Query("provider"); provider != "" {
+		filters.Provider = &provider
+	}
+	if planID := c.Query("plan_id"); planID == "none" {
+		filters.NoPlan = true
+	} else if planID != "" {
+		id, err := uuid.Parse(planID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid plan ID"})
+			return
 		}
+		filters.PlanID = &id
 	}
-
-	if o := c.Query("offset"); o != "" {
-		if parsedOffset, err := strconv.Atoi(o); err == nil {
-			offset = parsedOffset
+	if active := c.Query("active"); active != "" {
+		value, err := strconv.ParseBool(active)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid active filter"})
+			return
 		}
+		filters.Active = &value
 	}
 
-	users, err := h.userService.GetAllUsers(limit, offset)
+	response, err := h.adminUserService.Search(filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":   users,
-		"limit":  limit,
-		"offset": offset,
-	})
+	c.JSON(http.StatusOK, response)
 }
 
 func (h *Handlers) UpdateUser(c *gin.Context) {
@@ -95,17 +130,156 @@ func (h *Handlers) UpdateUser(c *gin.Context) {
 }
 
 func (h *Handlers) DeleteUser(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+	actorID, id, ok := adminUserTarget(c)
+	if !ok {
 		return
 	}
 
-	if err := h.userService.DeleteUser(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.adminUserService.Delete(actorID, id, c.ClientIP()); err != nil {
+		respondAdminUserError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
+
+// UpdateUserRole godoc
+// @Summary      Alterar papel do usuário (admin)
+// @Description  Promove ou rebaixa um usuário (user, corretor, admin). As sessões do usuário são encerradas
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                       true  "User ID"
+// @Param        request  body      model.UpdateUserRoleRequest  true  "Novo papel"
+// @Success      200      {object}  model.User
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /users/{id}/role [put]
+func (h *Handlers) UpdateUserRole(c *gin.Context) {
+	actorID, id, ok := adminUserTarget(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminUserService.SetRole(actorID, id, req.Role, c.ClientIP())
+	if err != nil {
+		respondAdminUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeactivateUser godoc
+// @Summary      Desativar usuário (admin)
+// @Description  Desativa a conta e encerra todas as sessões; contas inativas não conseguem fazer login
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  model.User
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Router       /users/{id}/deactivate [post]
+func (h *Handlers) DeactivateUser(c *gin.Context) {
+	h.setUserActive(c, false)
+}
+
+// ReactivateUser godoc
+// @Summary      Reativar usuário (admin)
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  model.User
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /users/{id}/reactivate [post]
+func (h *Handlers) ReactivateUser(c *gin.Context) {
+	h.setUserActive(c, true)
+}
+
+// UpdateUserPlan godoc
+// @Summary      Atribuir plano ao usuário (admin)
+// @Description  Define o plano do usuário; plan_id nulo remove o plano
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                       true  "User ID"
+// @Param        request  body      model.UpdateUserPlanRequest  true  "Plano"
+// @Success      200      {object}  model.User
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Router       /users/{id}/plan [put]
+func (h *Handlers) UpdateUserPlan(c *gin.Context) {
+	actorID, id, ok := adminUserTarget(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateUserPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminUserService.SetPlan(actorID, id, req.PlanID, c.ClientIP())
+	if err != nil {
+		respondAdminUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handlers) setUserActive(c *gin.Context, active bool) {
+	actorID, id, ok := adminUserTarget(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminUserService.SetActive(actorID, id, active, c.ClientIP())
+	if err != nil {
+		respondAdminUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// adminUserTarget reads the acting administrator and the user in the path.
+func adminUserTarget(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return actorID, id, true
+}
+
+func respondAdminUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case errors.Is(err, service.ErrPlanNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidRole),
+		errors.Is(err, service.ErrCannotChangeSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -10,51 +10,60 @@ import (
 	"github.com/thepantheon/api/internal/model"
 )
 
-// CreateUserPerformance godoc
-// @Summary      Registrar desempenho do usuario
+// GetUserPerformance godoc
+// @Summary      Listar desempenho do usuario
+// @Description  Um registro por dia, calculado a partir das respostas em /questoes/{id}/responder.
 // @Tags         meu-desempenho
-// @Accept       json
 // @Produce      json
-// @Param        request body model.CreateUserPerformanceRequest true "Desempenho"
-// @Success      201 {object} model.UserPerformance
+// @Param        data_inicio query string false "Data inicial (YYYY-MM-DD ou RFC3339)"
+// @Param        data_fim query string false "Data final (YYYY-MM-DD ou RFC3339)"
+// @Success      200 {array} model.UserPerformance
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
-// @Router       /meu-desempenho [post]
-func (h *Handlers) CreateUserPerformance(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+// @Router       /meu-desempenho [get]
+func (h *Handlers) GetUserPerformance(c *gin.Context) {
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
 
-	var req model.CreateUserPerformanceRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	startDate, err := parsePerformanceDateParam(c.Query("data_inicio"), false)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	item, err := h.userPerformanceService.Create(userID, &req)
+	endDate, err := parsePerformanceDateParam(c.Query("data_fim"), true)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, item)
+	items, err := h.userPerformanceService.GetByUser(userID, startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if items == nil {
+		items = []model.UserPerformance{}
+	}
+
+	c.JSON(http.StatusOK, items)
 }
 
-// GetUserPerformance godoc
-// @Summary      Listar desempenho do usuario
+// GetUserPerformanceSummary godoc
+// @Summary      Resumo do desempenho do usuario
 // @Tags         meu-desempenho
 // @Produce      json
 // @Param        data_inicio query string false "Data inicial (YYYY-MM-DD ou RFC3339)"
 // @Param        data_fim query string false "Data final (YYYY-MM-DD ou RFC3339)"
-// @Success      200 {array} model.UserPerformance
+// @Success      200 {object} model.UserPerformanceSummary
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
-// @Router       /meu-desempenho [get]
-func (h *Handlers) GetUserPerformance(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+// @Router       /meu-desempenho/resumo [get]
+func (h *Handlers) GetUserPerformanceSummary(c *gin.Context) {
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -70,28 +79,29 @@ func (h *Handlers) GetUserPerformance(c *gin.Context) {
 		return
 	}
 
-	items, err := h.userPerformanceService.GetByUser(userID, startDate, endDate)
+	summary, err := h.userPerformanceService.GetSummary(userID, startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, items)
+	c.JSON(http.StatusOK, summary)
 }
 
-// GetUserPerformanceSummary godoc
-// @Summary      Resumo do desempenho do usuario
+// GetDesempenhoDiscursivo godoc
+// @Summary      Desempenho nas questoes discursivas
+// @Description  Respostas discursivas corrigidas no periodo (pela data de envio), no geral e por disciplina, com as aprovadas (60% ou mais dos pontos) e o percentual medio dos pontos. Elas tambem entram nos totais diarios de /meu-desempenho.
 // @Tags         meu-desempenho
 // @Produce      json
 // @Param        data_inicio query string false "Data inicial (YYYY-MM-DD ou RFC3339)"
 // @Param        data_fim query string false "Data final (YYYY-MM-DD ou RFC3339)"
-// @Success      200 {object} model.UserPerformanceSummary
+// @Success      200 {object} model.DesempenhoDiscursivoResumo
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
-// @Router       /meu-desempenho/resumo [get]
-func (h *Handlers) GetUserPerformanceSummary(c *gin.Context) {
-	userID, ok := h.getUserIDFromRequest(c)
+// @Router       /meu-desempenho/discursivas [get]
+func (h *Handlers) GetDesempenhoDiscursivo(c *gin.Context) {
+	userID, ok := currentUserID(c)
 	if !ok {
 		return
 	}
@@ -107,13 +117,13 @@ func (h *Handlers) GetUserPerformanceSummary(c *gin.Context) {
 		return
 	}
 
-	summary, err := h.userPerformanceService.GetSummary(userID, startDate, endDate)
+	resumo, err := h.discursivaService.Desempenho(userID, startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, summary)
+	c.JSON(http.StatusOK, resumo)
 }
 
 func parsePerformanceDateParam(value string, endOfDay bool) (*time.Time, error) {
@@ -39,17 +39,32 @@ func (h *Handlers) CreateCodigo(c *gin.Context) {
 
 // GetCodigos godoc
 // @Summary      Listar codigos
+// @Description  incidencia e o numero de questoes que citam o artigo.
 // @Tags         vade-mecum-codigos
 // @Produce      json
+// @Param        banca query []string false "Conta so questoes destas bancas na incidencia" collectionFormat(multi)
+// @Param        cargo query []string false "Conta so questoes destes cargos na incidencia" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo das questoes contadas"
+// @Param        ano_max query int false "Ano maximo das questoes contadas"
 // @Success      200 {array} model.VadeMecumCodigo
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /vade-mecum/codigos [get]
 func (h *Handlers) GetCodigos(c *gin.Context) {
+	totais, ok := h.incidencias(c, model.DispositivoFonteCodigo)
+	if !ok {
+		return
+	}
 	items, err := h.codigoService.GetAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range items {
+		total := totais[items[i].ID.String()]
+		items[i].Incidencia = &total
+	}
+
 	c.JSON(http.StatusOK, items)
 }
 
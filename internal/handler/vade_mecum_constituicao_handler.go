@@ -11,17 +11,32 @@ import (
 
 // GetConstituicoes godoc
 // @Summary      Listar constituições
+// @Description  incidencia e o numero de questoes que citam o artigo.
 // @Tags         vade-mecum-constituicao
 // @Produce      json
+// @Param        banca query []string false "Conta so questoes destas bancas na incidencia" collectionFormat(multi)
+// @Param        cargo query []string false "Conta so questoes destes cargos na incidencia" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo das questoes contadas"
+// @Param        ano_max query int false "Ano maximo das questoes contadas"
 // @Success      200 {array} model.VadeMecumConstituicao
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /vade-mecum/constituicao [get]
 func (h *Handlers) GetConstituicoes(c *gin.Context) {
+	totais, ok := h.incidencias(c, model.DispositivoFonteConstituicao)
+	if !ok {
+		return
+	}
 	items, err := h.constituicaoService.GetAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range items {
+		total := totais[items[i].ID.String()]
+		items[i].Incidencia = &total
+	}
+
 	c.JSON(http.StatusOK, items)
 }
 
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/service"
+)
+
+// GetVadeMecumRanking godoc
+// @Summary      Artigos mais cobrados
+// @Description  Ordena os dispositivos do vade-mecum pelo numero de questoes que os citam. Sem fonte, todas as fontes entram no mesmo ranking; diploma (nomecodigo) exige fonte.
+// @Tags         vade-mecum
+// @Produce      json
+// @Param        fonte query string false "codigo, lei, estatuto, constituicao, oab ou jurisprudencia"
+// @Param        diploma query string false "Nome do codigo ou da lei"
+// @Param        banca query []string false "Bancas" collectionFormat(multi)
+// @Param        cargo query []string false "Cargos" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo"
+// @Param        ano_max query int false "Ano maximo"
+// @Param        limit query int false "Itens (padrao 20, maximo 100)"
+// @Param        offset query int false "Deslocamento"
+// @Success      200 {object} model.VadeMecumRanking
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /vade-mecum/ranking [get]
+func (h *Handlers) GetVadeMecumRanking(c *gin.Context) {
+	filters, err := buildIncidenciaFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filters.Fonte = c.Query("fonte")
+	filters.Diploma = strings.TrimSpace(c.Query("diploma"))
+	filters.Limit = parseInt(c.Query("limit"), 0)
+	filters.Offset = parseInt(c.Query("offset"), 0)
+	if filters.Diploma != "" && strings.TrimSpace(filters.Fonte) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "diploma exige fonte"})
+		return
+	}
+
+	ranking, err := h.incidenciaService.Ranking(filters)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDispositivoFonte) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
+
+// buildIncidenciaFilters reads the banca, cargo and year filters shared by
+// the ranking and the vade-mecum listings.
+func buildIncidenciaFilters(c *gin.Context) (*model.VadeMecumIncidenciaFilters, error) {
+	filters := &model.VadeMecumIncidenciaFilters{
+		Banca: queryValues(c, "banca"),
+		Cargo: queryValues(c, "cargo"),
+	}
+
+	var err error
+	if filters.AnoMin, err = queryInt(c, "ano_min"); err != nil {
+		return nil, err
+	}
+	if filters.AnoMax, err = queryInt(c, "ano_max"); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// incidencias returns how many questions cite each row of fonte, keyed by
+// id, for the listings. It writes the error response when it fails.
+func (h *Handlers) incidencias(c *gin.Context, fonte string) (map[string]int64, bool) {
+	filters, err := buildIncidenciaFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	totais, err := h.incidenciaService.Totais(fonte, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return totais, true
+}
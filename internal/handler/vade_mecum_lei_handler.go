@@ -43,17 +43,31 @@ func (h *Handlers) CreateLei(c *gin.Context) {
 
 // GetLeis godoc
 // @Summary      Listar leis
+// @Description  incidencia e o numero de questoes que citam o artigo.
 // @Tags         vade-mecum-leis
 // @Produce      json
+// @Param        banca query []string false "Conta so questoes destas bancas na incidencia" collectionFormat(multi)
+// @Param        cargo query []string false "Conta so questoes destes cargos na incidencia" collectionFormat(multi)
+// @Param        ano_min query int false "Ano minimo das questoes contadas"
+// @Param        ano_max query int false "Ano maximo das questoes contadas"
 // @Success      200 {array} model.VadeMecumLei
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /vade-mecum/leis [get]
 func (h *Handlers) GetLeis(c *gin.Context) {
+	totais, ok := h.incidencias(c, model.DispositivoFonteLei)
+	if !ok {
+		return
+	}
 	items, err := h.leisService.GetAll()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range items {
+		total := totais[items[i].ID]
+		items[i].Incidencia = &total
+	}
 
 	c.JSON(http.StatusOK, items)
 }
@@ -0,0 +1,48 @@
+package model
+
+import "github.com/google/uuid"
+
+const (
+	RoleUser     = "user"
+	RoleAdmin    = "admin"
+	RoleCorretor = "corretor"
+)
+
+// UserFilters drives the admin user listing. Nil filters are not applied.
+type UserFilters struct {
+	Search   string
+	Role     *string
+	PlanID   *uuid.UUID
+	NoPlan   bool
+	Active   *bool
+	Provider *string
+	Sort     string
+	Order    string
+	Limit    int
+	Offset   int
+}
+
+type UserListResponse struct {
+	Data   []User     `json:"data"`
+	Total  int64      `json:"total"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+	Totals UserTotals `json:"totals"`
+}
+
+// UserTotals summarizes the whole user base, regardless of filters.
+type UserTotals struct {
+	Users    int64            `json:"users"`
+	Active   int64            `json:"active"`
+	Inactive int64            `json:"inactive"`
+	ByRole   map[string]int64 `json:"by_role"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// UpdateUserPlanRequest assigns a plan; a null plan_id removes it.
+type UpdateUserPlanRequest struct {
+	PlanID *uuid.UUID `json:"plan_id"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditLoginLockout       = "login_lockout"
+	AuditAdminSecretLockout = "admin_secret_lockout"
+	AuditUserRoleChanged    = "user_role_changed"
+	AuditUserDeactivated    = "user_deactivated"
+	AuditUserReactivated    = "user_reactivated"
+	AuditUserPlanChanged    = "user_plan_changed"
+	AuditUserDeleted        = "user_deleted"
+)
+
+// AuditLog is an append-only record of security relevant events.
+type AuditLog struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    *uuid.UUID     `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	Action    string         `gorm:"type:varchar(50);not null;index" json:"action"`
+	IPAddress string         `gorm:"type:varchar(64)" json:"ip_address,omitempty"`
+	Metadata  datatypes.JSON `gorm:"type:jsonb" json:"metadata,omitempty"`
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	CadernoTipoFiltro = "filtro"
+	CadernoTipoLista  = "lista"
+)
+
+// Caderno is a user's question notebook: either a saved search (Filtros, a
+// QuestaoFilters in JSON) or a handpicked ordered list of questions. A
+// ShareToken makes it readable by anyone with the link.
+type Caderno struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	Nome       string           `gorm:"type:varchar(200);not null" json:"nome"`
+	Descricao  *string          `gorm:"type:text" json:"descricao"`
+	Tipo       string           `gorm:"type:varchar(20);not null" json:"tipo"`
+	Filtros    datatypes.JSON   `gorm:"type:jsonb" json:"filtros,omitempty"`
+	ShareToken *string          `gorm:"type:varchar(64);uniqueIndex" json:"share_token,omitempty"`
+	Questoes   []CadernoQuestao `gorm:"foreignKey:CadernoID" json:"questoes,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+func (c *Caderno) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// CadernoQuestao is a question of a list caderno, at position Ordem.
+type CadernoQuestao struct {
+	CadernoID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	QuestaoID int       `gorm:"primaryKey" json:"questao_id"`
+	Ordem     int       `gorm:"not null" json:"ordem"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (CadernoQuestao) TableName() string {
+	return "caderno_questoes"
+}
+
+// CadernoProgresso tracks a user's progress in a caderno, counting the
+// answers given since StartedAt. It is created the first time the user opens
+// the caderno and restarted on demand.
+type CadernoProgresso struct {
+	CadernoID uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	StartedAt time.Time `gorm:"not null" json:"iniciado_em"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (CadernoProgresso) TableName() string {
+	return "caderno_progressos"
+}
+
+// CadernoRequest creates or replaces a caderno. Exactly one of Filtros and
+// QuestaoIDs must be given; QuestaoIDs keeps the given order.
+type CadernoRequest struct {
+	Nome       string          `json:"nome" binding:"required,max=200"`
+	Descricao  *string         `json:"descricao"`
+	Filtros    *QuestaoFilters `json:"filtros"`
+	QuestaoIDs []int           `json:"questao_ids"`
+}
+
+type CadernoQuestoesRequest struct {
+	QuestaoIDs []int `json:"questao_ids" binding:"required,min=1"`
+}
+
+// CadernoProgressoResumo counts the caderno questions answered since
+// IniciadoEm; the latest answer to each question decides Acertos and Erros.
+// ProximaQuestaoID is the first question not answered yet, to resume from.
+type CadernoProgressoResumo struct {
+	IniciadoEm       time.Time `json:"iniciado_em"`
+	Total            int64     `json:"total"`
+	Respondidas      int64     `json:"respondidas"`
+	Acertos          int64     `json:"acertos"`
+	Erros            int64     `json:"erros"`
+	Percentual       float64   `json:"percentual"`
+	ProximaQuestaoID *int      `json:"proxima_questao_id"`
+}
+
+type CadernoDetail struct {
+	Caderno
+	Link      *string                 `json:"link,omitempty"`
+	Progresso *CadernoProgressoResumo `json:"progresso,omitempty"`
+}
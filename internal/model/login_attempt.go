@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// LoginAttempt holds the failure counter of a throttling key, such as
+// "account:<email>" or "ip:<address>".
+type LoginAttempt struct {
+	Key           string     `gorm:"type:varchar(320);primaryKey" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null" json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificacaoReporteQuestao     = "reporte_questao"
+	NotificacaoRespostaDiscursiva = "resposta_discursiva"
+)
+
+// Notificacao is an in-app message for a user. Link is a frontend path.
+type Notificacao struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_notificacoes_user_created,priority:1" json:"-"`
+	Tipo      string     `gorm:"type:varchar(50);not null" json:"tipo"`
+	Titulo    string     `gorm:"type:varchar(200);not null" json:"titulo"`
+	Mensagem  string     `gorm:"type:text;not null" json:"mensagem"`
+	Link      *string    `gorm:"type:varchar(500)" json:"link,omitempty"`
+	LidaEm    *time.Time `json:"lida_em"`
+	CreatedAt time.Time  `gorm:"index:idx_notificacoes_user_created,priority:2" json:"created_at"`
+}
+
+func (Notificacao) TableName() string {
+	return "notificacoes"
+}
+
+func (n *Notificacao) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+type NotificacaoList struct {
+	Data     []Notificacao `json:"data"`
+	Total    int64         `json:"total"`
+	NaoLidas int64         `json:"nao_lidas"`
+	Limit    int           `json:"limit"`
+	Offset   int           `json:"offset"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DataExportPending    = "pending"
+	DataExportProcessing = "processing"
+	DataExportDone       = "done"
+	DataExportFailed     = "failed"
+
+	AuditDataExportRequested = "data_export_requested"
+	AuditAccountErased       = "account_erased"
+)
+
+// DataExport is an LGPD data portability request. The ZIP archive is built in
+// the background and kept until ExpiresAt.
+type DataExport struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status      string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Size        int64      `json:"tamanho"`
+	Data        []byte     `gorm:"type:bytea" json:"-"`
+	Error       string     `gorm:"type:text" json:"erro,omitempty"`
+	CompletedAt *time.Time `json:"concluido_em,omitempty"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expira_em,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+func (e *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// UserDataSnapshot gathers every record tied to a user, as written to the
+// data export archive.
+type UserDataSnapshot struct {
+	User             User                 `json:"usuario"`
+	Sessions         []UserSession        `json:"sessoes"`
+	Identities       []UserIdentity       `json:"identidades"`
+	Performances     []UserPerformance    `json:"desempenho"`
+	Attempts         []QuestionAttempt    `json:"tentativas"`
+	Simulados        []Simulado           `json:"simulados"`
+	Cadernos         []Caderno            `json:"cadernos"`
+	RevisaoCards     []RevisaoCard        `json:"revisao"`
+	RevisaoConfig    *RevisaoConfig       `json:"revisao_configuracoes,omitempty"`
+	PerfilEstudo     *PerfilEstudo        `json:"perfil_estudo,omitempty"`
+	Discursivas      []RespostaDiscursiva `json:"respostas_discursivas"`
+	Reportes         []QuestaoReporte     `json:"reportes"`
+	Notificacoes     []Notificacao        `json:"notificacoes"`
+	CourseCategories []CourseCategory     `json:"categorias"`
+	Courses          []Course             `json:"cursos"`
+	CourseModules    []CourseModule       `json:"modulos"`
+	CourseItems      []CourseItem         `json:"itens"`
+	AsaasCustomers   []AsaasCustomer      `json:"clientes_asaas"`
+	AsaasPayments    []AsaasPayment       `json:"pagamentos_asaas"`
+	AuditLogs        []AuditLog           `json:"auditoria"`
+	Avatar           *MediaAsset          `json:"avatar,omitempty"`
+}
+
+// ErasureReport counts what the erasure flow anonymized and deleted.
+type ErasureReport struct {
+	AnonymizedCustomers int64            `json:"clientes_anonimizados"`
+	RedactedPayments    int64            `json:"pagamentos_retidos"`
+	Deleted             map[string]int64 `json:"removidos"`
+}
+
+type EraseUserRequest struct {
+	Reason string `json:"motivo"`
+}
@@ -0,0 +1,23 @@
+package model
+
+// UpdateProfileRequest changes the authenticated user's own data. Changing
+// the e-mail requires the current password, or a recent login for accounts
+// without one, and restarts e-mail verification.
+type UpdateProfileRequest struct {
+	FullName        *string `json:"full_name" binding:"omitempty,min=3"`
+	Email           *string `json:"email" binding:"omitempty,email"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+// ChangePasswordRequest sets a new password. CurrentPassword is only optional
+// for social-only accounts that never had a password, which must have logged
+// in recently instead.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" binding:"required,min=8"`
+	Confirm         string `json:"confirm" binding:"required,eqfield=Password"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
@@ -35,8 +35,9 @@ type Questao struct {
 	PossuiResolucaoBanca     *bool           `gorm:"column:possui_resolucao_banca" json:"possui_resolucao_banca"`
 	GabaritoPreliminar       *bool           `gorm:"column:gabarito_preliminar" json:"gabarito_preliminar"`
 	QuestaoOculta            *bool           `gorm:"column:questao_oculta" json:"questao_oculta"`
-	IDQuestao                *string         `gorm:"column:id_questao;type:varchar(100)" json:"id_questao"`
-	IDQuestaoOriginal        *string         `gorm:"column:id_questao_original;type:varchar(100)" json:"id_questao_original"`
+	ErroCaptura              *bool           `gorm:"column:erro_captura" json:"-"`
+	IDQuestao                *string         `gorm:"column:id_questao;type:varchar(100);index" json:"id_questao"`
+	IDQuestaoOriginal        *string         `gorm:"column:id_questao_original;type:varchar(100);index" json:"id_questao_original"`
 	Gabarito                 *string         `gorm:"column:gabarito;type:text" json:"gabarito"`
 	Comentario               *string         `gorm:"column:comentario;type:text" json:"comentario"`
 	ResolucaoBanca           *string         `gorm:"column:resolucao_banca;type:text" json:"resolucao_banca"`
@@ -51,6 +52,14 @@ type Questao struct {
 	Concurso                 *string         `gorm:"column:concurso;type:varchar(200)" json:"concurso"`
 	FormatoQuestao           *string         `gorm:"column:formato_questao;type:varchar(100)" json:"formato_questao"`
 	TipoProva                *string         `gorm:"column:tipo_prova;type:varchar(100)" json:"tipo_prova"`
+
+	// Rubrica and LinhasMaximas only apply to discursive questions.
+	Rubrica       datatypes.JSONSlice[QuestaoRubricaItem] `gorm:"column:rubrica;type:jsonb;not null;default:'[]'" json:"rubrica"`
+	LinhasMaximas *int                                    `gorm:"column:linhas_maximas" json:"linhas_maximas"`
+
+	// EstatisticasPlataforma are the statistics of the platform's own
+	// answers; only GetQuestaoByID fills them.
+	EstatisticasPlataforma *QuestaoEstatisticasPlataforma `gorm:"-" json:"estatisticas_plataforma,omitempty"`
 }
 
 func (Questao) TableName() string {
@@ -101,6 +110,9 @@ type CreateQuestaoRequest struct {
 	Concurso                 *string         `json:"concurso"`
 	FormatoQuestao           *string         `json:"formato_questao"`
 	TipoProva                *string         `json:"tipo_prova"`
+
+	Rubrica       []QuestaoRubricaItem `json:"rubrica"`
+	LinhasMaximas *int                 `json:"linhas_maximas"`
 }
 
 type UpdateQuestaoRequest struct {
@@ -147,26 +159,79 @@ type UpdateQuestaoRequest struct {
 	Concurso                 *string         `json:"concurso"`
 	FormatoQuestao           *string         `json:"formato_questao"`
 	TipoProva                *string         `json:"tipo_prova"`
+
+	Rubrica       []QuestaoRubricaItem `json:"rubrica"`
+	LinhasMaximas *int                 `json:"linhas_maximas"`
 }
 
+// QuestaoFilters drives the question search. Q is a full-text query; slice
+// filters match any of the values, ranges are inclusive and nil flags are not
+// applied. Sort, Order, Page and PageSize only affect listings, not counts.
+// The JSON form, used by saved cadernos, mirrors the query parameters.
 type QuestaoFilters struct {
-	Disciplina       *string
-	Assunto          *string
-	Banca            *string
-	Orgao            *string
-	Cargo            *string
-	Concurso         *string
-	AreaConhecimento *string
+	Q                string   `json:"q,omitempty"`
+	Disciplina       []string `json:"disciplina,omitempty"`
+	Assunto          []string `json:"assunto,omitempty"`
+	Banca            []string `json:"banca,omitempty"`
+	Orgao            []string `json:"orgao,omitempty"`
+	Cargo            []string `json:"cargo,omitempty"`
+	Concurso         []string `json:"concurso,omitempty"`
+	AreaConhecimento []string `json:"area_conhecimento,omitempty"`
+	TipoQuestao      []string `json:"tipo_questao,omitempty"`
+	Nivel            []string `json:"nivel,omitempty"`
+	Dificuldade      []string `json:"dificuldade,omitempty"`
+	AnoMin           *int     `json:"ano_min,omitempty"`
+	AnoMax           *int     `json:"ano_max,omitempty"`
+	AcertosMin       *float64 `json:"acertos_min,omitempty"`
+	AcertosMax       *float64 `json:"acertos_max,omitempty"`
+	Anulada          *bool    `json:"anulada,omitempty"`
+	Desatualizada    *bool    `json:"desatualizada,omitempty"`
+	QuestaoOculta    *bool    `json:"questao_oculta,omitempty"`
+	Sort             string   `json:"sort,omitempty"`
+	Order            string   `json:"order,omitempty"`
+	Page             int      `json:"-"`
+	PageSize         int      `json:"-"`
+}
+
+// QuestaoResult is a search hit. Rank and Trecho are only set for full-text
+// searches; Trecho highlights the matched terms with <mark>.
+type QuestaoResult struct {
+	Questao
+	Rank   *float64 `json:"rank,omitempty"`
+	Trecho *string  `json:"trecho,omitempty"`
+}
+
+type QuestaoPage struct {
+	Data       []QuestaoResult `json:"data"`
+	Total      int64           `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// QuestaoFacetValue is one filter option and how many questions match it.
+type QuestaoFacetValue struct {
+	Valor string `json:"valor"`
+	Total int64  `json:"total"`
 }
 
+// QuestaoFiltersResponse lists the filter options still reachable from the
+// current filters. Each facet applies every filter except its own, so picking
+// one banca still shows the others; Total counts the questions matching all
+// of them.
 type QuestaoFiltersResponse struct {
-	Disciplina       []string `json:"disciplina"`
-	Assunto          []string `json:"assunto"`
-	Banca            []string `json:"banca"`
-	Orgao            []string `json:"orgao"`
-	Cargo            []string `json:"cargo"`
-	Concurso         []string `json:"concurso"`
-	AreaConhecimento []string `json:"area_conhecimento"`
+	Total            int64               `json:"total"`
+	Disciplina       []QuestaoFacetValue `json:"disciplina"`
+	Assunto          []QuestaoFacetValue `json:"assunto"`
+	Banca            []QuestaoFacetValue `json:"banca"`
+	Orgao            []QuestaoFacetValue `json:"orgao"`
+	Cargo            []QuestaoFacetValue `json:"cargo"`
+	Concurso         []QuestaoFacetValue `json:"concurso"`
+	AreaConhecimento []QuestaoFacetValue `json:"area_conhecimento"`
+	TipoQuestao      []QuestaoFacetValue `json:"tipo_questao"`
+	Nivel            []QuestaoFacetValue `json:"nivel"`
+	Dificuldade      []QuestaoFacetValue `json:"dificuldade"`
+	Ano              []QuestaoFacetValue `json:"ano"`
 }
 
 type QuestaoCountResponse struct {
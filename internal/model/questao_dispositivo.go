@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Vade-mecum sources a question can be linked to, one per table.
+const (
+	DispositivoFonteCodigo         = "codigo"
+	DispositivoFonteLei            = "lei"
+	DispositivoFonteEstatuto       = "estatuto"
+	DispositivoFonteConstituicao   = "constituicao"
+	DispositivoFonteOAB            = "oab"
+	DispositivoFonteJurisprudencia = "jurisprudencia"
+)
+
+var DispositivoFontes = []string{
+	DispositivoFonteCodigo,
+	DispositivoFonteLei,
+	DispositivoFonteEstatuto,
+	DispositivoFonteConstituicao,
+	DispositivoFonteOAB,
+	DispositivoFonteJurisprudencia,
+}
+
+// How a link was made: found in the question text or added by an admin.
+const (
+	QuestaoDispositivoOrigemParser = "parser"
+	QuestaoDispositivoOrigemManual = "manual"
+)
+
+// QuestaoDispositivo links a question to the vade-mecum row (article or
+// súmula) it tests. DispositivoID is the id of the row in the table of Fonte.
+// Referencia is the citation as written, such as "art. 37, § 6º, CF".
+// Removido marks parser links an admin discarded, so detection does not add
+// them back.
+type QuestaoDispositivo struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	QuestaoID     int        `gorm:"not null;uniqueIndex:idx_questao_dispositivos_unico,priority:1" json:"questao_id"`
+	Fonte         string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_questao_dispositivos_unico,priority:2;index:idx_questao_dispositivos_dispositivo,priority:1" json:"fonte"`
+	DispositivoID string     `gorm:"type:text;not null;uniqueIndex:idx_questao_dispositivos_unico,priority:3;index:idx_questao_dispositivos_dispositivo,priority:2" json:"dispositivo_id"`
+	Referencia    string     `gorm:"type:varchar(200);not null" json:"referencia"`
+	Origem        string     `gorm:"type:varchar(20);not null" json:"origem"`
+	Removido      bool       `gorm:"not null;default:false" json:"-"`
+	CriadoPor     *uuid.UUID `gorm:"type:uuid" json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (QuestaoDispositivo) TableName() string {
+	return "questao_dispositivos"
+}
+
+func (d *QuestaoDispositivo) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// QuestaoDispositivoItem is a link with a summary of the vade-mecum row:
+// Diploma is the código, lei or tribunal name, Artigo the article or súmula
+// number and Texto its text.
+type QuestaoDispositivoItem struct {
+	QuestaoDispositivo
+	Diploma string `json:"diploma"`
+	Artigo  string `json:"artigo"`
+	Texto   string `json:"texto"`
+}
+
+// DispositivoResumo is the summary of a vade-mecum row.
+type DispositivoResumo struct {
+	ID      string
+	Diploma string
+	Artigo  string
+	Texto   string
+}
+
+// DispositivoBusca locates the rows cited by a reference. Diploma and Excluir
+// are regular expressions over the unaccented nomecodigo (for jurisprudência,
+// nomecodigo and Cabecalho); Artigo matches num_artigo and ArtigoTexto the
+// start of the Normativo of the Constituição, which has no article column.
+type DispositivoBusca struct {
+	Fontes      []string
+	Diploma     string
+	Excluir     string
+	Artigo      string
+	ArtigoTexto string
+}
+
+// DispositivoRef identifies a vade-mecum row.
+type DispositivoRef struct {
+	Fonte         string
+	DispositivoID string
+}
+
+type CreateQuestaoDispositivoRequest struct {
+	Fonte         string `json:"fonte" binding:"required"`
+	DispositivoID string `json:"dispositivo_id" binding:"required"`
+	Referencia    string `json:"referencia"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// QuestaoEstatistica holds the statistics of a question computed from the
+// answers given on the platform, next to the imported AcertosPercentual and
+// QuantidadeResolucoes. Only each user's first answer counts, so retrying a
+// question after seeing the answer key does not inflate the accuracy.
+// Respostas counts those answers per alternative letter.
+type QuestaoEstatistica struct {
+	QuestaoID    int                                `gorm:"primaryKey;autoIncrement:false" json:"questao_id"`
+	Resolucoes   int                                `gorm:"not null" json:"resolucoes"`
+	Acertos      int                                `gorm:"not null" json:"acertos"`
+	Respostas    datatypes.JSONType[map[string]int] `gorm:"type:jsonb;not null" json:"-"`
+	AtualizadoEm time.Time                          `gorm:"not null" json:"atualizado_em"`
+}
+
+func (QuestaoEstatistica) TableName() string {
+	return "questao_estatisticas"
+}
+
+// QuestaoRespostaDistribuicao is how many users chose an alternative first.
+type QuestaoRespostaDistribuicao struct {
+	Resposta   string  `json:"resposta"`
+	Total      int     `json:"total"`
+	Percentual float64 `json:"percentual"`
+}
+
+// QuestaoEstatisticasPlataforma is QuestaoEstatistica as shown with the
+// question: percentages rounded to two decimals and every alternative
+// answered, in letter order.
+type QuestaoEstatisticasPlataforma struct {
+	Resolucoes        int                           `json:"resolucoes"`
+	Acertos           int                           `json:"acertos"`
+	AcertosPercentual float64                       `json:"acertos_percentual"`
+	Distribuicao      []QuestaoRespostaDistribuicao `json:"distribuicao"`
+	AtualizadoEm      time.Time                     `json:"atualizado_em"`
+}
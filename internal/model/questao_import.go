@@ -0,0 +1,18 @@
+package model
+
+// QuestaoImportReport summarizes a bulk import. Rows are upserted one by one,
+// so a failing row is reported without stopping the others.
+type QuestaoImportReport struct {
+	Linhas      int                 `json:"linhas"`
+	Criadas     int                 `json:"criadas"`
+	Atualizadas int                 `json:"atualizadas"`
+	Erros       []QuestaoImportErro `json:"erros"`
+}
+
+// QuestaoImportErro points to a rejected row: Linha is the line of the file
+// (the header is line 1) and Chave its id_questao or id_questao_original.
+type QuestaoImportErro struct {
+	Linha int    `json:"linha"`
+	Chave string `json:"chave,omitempty"`
+	Erro  string `json:"erro"`
+}
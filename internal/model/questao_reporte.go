@@ -0,0 +1,108 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Motivos a student can pick when reporting a question.
+const (
+	QuestaoReporteGabaritoErrado = "gabarito_errado"
+	QuestaoReporteDesatualizada  = "desatualizada"
+	QuestaoReporteFormatacao     = "formatacao"
+	QuestaoReporteEnunciado      = "enunciado"
+	QuestaoReporteOutro          = "outro"
+)
+
+var QuestaoReporteMotivos = []string{
+	QuestaoReporteGabaritoErrado,
+	QuestaoReporteDesatualizada,
+	QuestaoReporteFormatacao,
+	QuestaoReporteEnunciado,
+	QuestaoReporteOutro,
+}
+
+const (
+	QuestaoReportePendente  = "pendente"
+	QuestaoReporteResolvido = "resolvido"
+	QuestaoReporteRejeitado = "rejeitado"
+)
+
+// QuestaoReporte is a problem with a question flagged by a student. It stays
+// pendente until an admin resolves or rejects it; a user has at most one
+// pending report per question.
+type QuestaoReporte struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	QuestaoID    int        `gorm:"not null;index:idx_questao_reportes_questao_status,priority:1;uniqueIndex:idx_questao_reportes_pendente,priority:2,where:status = 'pendente'" json:"questao_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_questao_reportes_pendente,priority:1" json:"user_id"`
+	Motivo       string     `gorm:"type:varchar(30);not null" json:"motivo"`
+	Descricao    string     `gorm:"type:text;not null" json:"descricao"`
+	Status       string     `gorm:"type:varchar(20);not null;default:pendente;index:idx_questao_reportes_questao_status,priority:2" json:"status"`
+	Resposta     *string    `gorm:"type:text" json:"resposta,omitempty"`
+	ResolvidoPor *uuid.UUID `gorm:"type:uuid" json:"-"`
+	ResolvidoEm  *time.Time `json:"resolvido_em,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (r *QuestaoReporte) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+type ReportarQuestaoRequest struct {
+	Motivo    string `json:"motivo" binding:"required"`
+	Descricao string `json:"descricao" binding:"required,max=2000"`
+}
+
+// ResolverReportesRequest closes the pending reports of a question, or only
+// ReporteIDs when given. Desatualizada and Anulada update the question when
+// the reports are resolved.
+type ResolverReportesRequest struct {
+	Status        string      `json:"status" binding:"required"`
+	Resposta      string      `json:"resposta" binding:"max=2000"`
+	ReporteIDs    []uuid.UUID `json:"reporte_ids"`
+	Desatualizada *bool       `json:"desatualizada"`
+	Anulada       *bool       `json:"anulada"`
+}
+
+type ResolverReportesResponse struct {
+	Reportes []QuestaoReporte `json:"reportes"`
+	Questao  *Questao         `json:"questao"`
+}
+
+// QuestaoReporteFilters drives the moderation queue.
+type QuestaoReporteFilters struct {
+	Status string
+	Motivo string
+	Limit  int
+	Offset int
+}
+
+// QuestaoReporteFilaItem is a question in the moderation queue with the
+// reports matching the filters.
+type QuestaoReporteFilaItem struct {
+	QuestaoID       int            `json:"questao_id"`
+	Disciplina      *string        `json:"disciplina"`
+	Assunto         *string        `json:"assunto"`
+	Banca           *string        `json:"banca"`
+	Ano             *int           `json:"ano"`
+	Anulada         *bool          `json:"anulada"`
+	Desatualizada   *bool          `json:"desatualizada"`
+	Total           int64          `json:"total"`
+	Motivos         datatypes.JSON `json:"motivos" swaggertype:"array,string"`
+	PrimeiroReporte time.Time      `json:"primeiro_reporte"`
+	UltimoReporte   time.Time      `json:"ultimo_reporte"`
+}
+
+type QuestaoReporteFila struct {
+	Data   []QuestaoReporteFilaItem `json:"data"`
+	Total  int64                    `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
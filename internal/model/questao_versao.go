@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// QuestaoVersao records an edit of a question: the fields changed, with their
+// previous and new values, who made it and how many recorded attempts were
+// graded again because the answer key changed. Versao counts the edits of the
+// question from 1.
+type QuestaoVersao struct {
+	ID                     uuid.UUID                             `gorm:"type:uuid;primaryKey" json:"id"`
+	QuestaoID              int                                   `gorm:"not null;uniqueIndex:idx_questao_versoes_questao_versao,priority:1" json:"questao_id"`
+	Versao                 int                                   `gorm:"not null;uniqueIndex:idx_questao_versoes_questao_versao,priority:2" json:"versao"`
+	EditorID               *uuid.UUID                            `gorm:"type:uuid" json:"editor_id"`
+	Alteracoes             datatypes.JSONSlice[QuestaoAlteracao] `gorm:"type:jsonb;not null" json:"alteracoes" swaggertype:"array,object"`
+	TentativasRecorrigidas int                                   `gorm:"not null;default:0" json:"tentativas_recorrigidas"`
+	CreatedAt              time.Time                             `json:"created_at"`
+}
+
+func (QuestaoVersao) TableName() string {
+	return "questao_versoes"
+}
+
+func (v *QuestaoVersao) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// QuestaoAlteracao is a changed field, named as in the JSON of Questao.
+type QuestaoAlteracao struct {
+	Campo    string      `json:"campo"`
+	Anterior interface{} `json:"anterior"`
+	Novo     interface{} `json:"novo"`
+}
+
+// QuestaoRecorrecao is the answer key the attempts at a question are graded
+// against after an edit. An empty Gabarito keeps the key recorded in each
+// attempt; Anulada makes every answer correct.
+type QuestaoRecorrecao struct {
+	Gabarito string
+	Anulada  bool
+}
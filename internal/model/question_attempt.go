@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuestionAttempt is one answer a user gave to a question. Gabarito keeps the
+// answer key used to grade it.
+type QuestionAttempt struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index:idx_question_attempts_user_answered,priority:1" json:"user_id"`
+	QuestaoID     int       `gorm:"not null;index" json:"questao_id"`
+	Resposta      string    `gorm:"type:varchar(10);not null" json:"resposta"`
+	Gabarito      string    `gorm:"type:varchar(10);not null" json:"gabarito"`
+	Correct       bool      `gorm:"not null" json:"correta"`
+	TempoSegundos *int      `json:"tempo_segundos,omitempty"`
+	AnsweredAt    time.Time `gorm:"not null;index:idx_question_attempts_user_answered,priority:2" json:"respondida_em"`
+	CreatedAt     time.Time `gorm:"index" json:"created_at"`
+}
+
+func (a *QuestionAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	if a.AnsweredAt.IsZero() {
+		a.AnsweredAt = time.Now()
+	}
+	return nil
+}
+
+// ResponderQuestaoRequest is an answer: the alternative letter (A-E) or, for
+// certo/errado questions, "C"/"certo" or "E"/"errado".
+type ResponderQuestaoRequest struct {
+	Resposta      string `json:"resposta" binding:"required"`
+	TempoSegundos *int   `json:"tempo_segundos" binding:"omitempty,min=0"`
+}
+
+// ResponderQuestaoResponse tells whether the answer was right and reveals the
+// explanations. Annulled questions count as correct for everyone.
+type ResponderQuestaoResponse struct {
+	TentativaID    uuid.UUID `json:"tentativa_id"`
+	Correta        bool      `json:"correta"`
+	Resposta       string    `json:"resposta"`
+	Gabarito       string    `json:"gabarito"`
+	Anulada        bool      `json:"anulada"`
+	Comentario     *string   `json:"comentario"`
+	ResolucaoBanca *string   `json:"resolucao_banca"`
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// PerfilEstudo is the exam a user is preparing for. Recommended questions
+// are drawn from its bancas, concursos, cargos and órgãos; an empty list
+// does not restrict.
+type PerfilEstudo struct {
+	UserID    uuid.UUID                   `gorm:"type:uuid;primaryKey" json:"-"`
+	Bancas    datatypes.JSONSlice[string] `gorm:"type:jsonb;not null" json:"bancas"`
+	Concursos datatypes.JSONSlice[string] `gorm:"type:jsonb;not null" json:"concursos"`
+	Cargos    datatypes.JSONSlice[string] `gorm:"type:jsonb;not null" json:"cargos"`
+	Orgaos    datatypes.JSONSlice[string] `gorm:"type:jsonb;not null" json:"orgaos"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+func (PerfilEstudo) TableName() string {
+	return "perfis_estudo"
+}
+
+// DefaultPerfilEstudo is the profile of users who never saved one: every
+// question is eligible.
+func DefaultPerfilEstudo(userID uuid.UUID) PerfilEstudo {
+	return PerfilEstudo{
+		UserID:    userID,
+		Bancas:    datatypes.JSONSlice[string]{},
+		Concursos: datatypes.JSONSlice[string]{},
+		Cargos:    datatypes.JSONSlice[string]{},
+		Orgaos:    datatypes.JSONSlice[string]{},
+	}
+}
+
+type UpdatePerfilEstudoRequest struct {
+	Bancas    []string `json:"bancas" binding:"max=20,dive,max=200"`
+	Concursos []string `json:"concursos" binding:"max=20,dive,max=200"`
+	Cargos    []string `json:"cargos" binding:"max=20,dive,max=200"`
+	Orgaos    []string `json:"orgaos" binding:"max=20,dive,max=200"`
+}
+
+// AssuntoDesempenho compares a user's accuracy in an assunto with the
+// accuracy expected from the questions answered, taken from their
+// acertos_percentual or, without it, their dificuldade. Deficit weighs the
+// gap by how many answers back it.
+type AssuntoDesempenho struct {
+	Disciplina       string  `json:"disciplina"`
+	Assunto          string  `json:"assunto"`
+	Tentativas       int64   `json:"tentativas"`
+	Acertos          int64   `json:"acertos"`
+	AcertoPercentual float64 `json:"acerto_percentual"`
+	AcertoEsperado   float64 `json:"acerto_esperado"`
+	Deficit          float64 `json:"deficit"`
+}
+
+const (
+	RecomendacaoMotivoFraqueza = "fraqueza"
+	RecomendacaoMotivoPerfil   = "perfil"
+)
+
+// QuestaoRecomendada is a recommended question and why: an assunto the user
+// is weak in (Fraqueza) or, when those run out, the target profile alone.
+type QuestaoRecomendada struct {
+	Questao  Questao            `json:"questao"`
+	Motivo   string             `json:"motivo"`
+	Fraqueza *AssuntoDesempenho `json:"fraqueza,omitempty"`
+}
+
+type QuestoesRecomendadas struct {
+	Perfil    PerfilEstudo         `json:"perfil"`
+	Fraquezas []AssuntoDesempenho  `json:"fraquezas"`
+	Questoes  []QuestaoRecomendada `json:"questoes"`
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// TipoQuestaoDiscursiva is the tipo_questao of discursive (essay and
+	// peça) questions, answered with a text graded by a corretor.
+	TipoQuestaoDiscursiva = "Discursiva"
+
+	// RespostaDiscursivaTentativa is the resposta and gabarito recorded in
+	// question_attempts when a discursive answer is graded.
+	RespostaDiscursivaTentativa = "DISCURSIVA"
+)
+
+const (
+	RespostaDiscursivaPendente   = "pendente"
+	RespostaDiscursivaEmCorrecao = "em_correcao"
+	RespostaDiscursivaCorrigida  = "corrigida"
+)
+
+// QuestaoRubricaItem is a criterion of the rubric of a discursive question
+// and the points it is worth.
+type QuestaoRubricaItem struct {
+	Descricao string  `json:"descricao"`
+	Pontuacao float64 `json:"pontuacao"`
+}
+
+// Discursiva tells whether the question is answered with a text.
+func (q *Questao) Discursiva() bool {
+	return q.TipoQuestao != nil && strings.EqualFold(strings.TrimSpace(*q.TipoQuestao), TipoQuestaoDiscursiva)
+}
+
+// RubricaNota is a rubric criterion with the score and comment the corretor
+// gave it. Nota stays nil until the answer is graded.
+type RubricaNota struct {
+	Descricao  string   `json:"descricao"`
+	Pontuacao  float64  `json:"pontuacao"`
+	Nota       *float64 `json:"nota"`
+	Comentario *string  `json:"comentario,omitempty"`
+}
+
+// RespostaDiscursiva is a text a student wrote for a discursive question. It
+// keeps a copy of the rubric as it was when sent, so later edits to the
+// question do not change how it is graded. It waits as pendente until a
+// corretor reserves it (em_correcao) and grades it (corrigida); a user has at
+// most one answer per question waiting for correction.
+type RespostaDiscursiva struct {
+	ID          uuid.UUID                        `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID      uuid.UUID                        `gorm:"type:uuid;not null;index;uniqueIndex:idx_respostas_discursivas_aberta,priority:1,where:status <> 'corrigida'" json:"user_id"`
+	QuestaoID   int                              `gorm:"not null;index;uniqueIndex:idx_respostas_discursivas_aberta,priority:2" json:"questao_id"`
+	Texto       string                           `gorm:"type:text;not null" json:"texto"`
+	Linhas      int                              `gorm:"not null" json:"linhas"`
+	Status      string                           `gorm:"type:varchar(20);not null;default:pendente;index:idx_respostas_discursivas_status_created,priority:1" json:"status"`
+	CorretorID  *uuid.UUID                       `gorm:"type:uuid;index" json:"-"`
+	ReservadaEm *time.Time                       `json:"reservada_em,omitempty"`
+	Rubrica     datatypes.JSONSlice[RubricaNota] `gorm:"type:jsonb;not null" json:"rubrica"`
+	Nota        *float64                         `json:"nota"`
+	NotaMaxima  float64                          `gorm:"not null" json:"nota_maxima"`
+	Comentario  *string                          `gorm:"type:text" json:"comentario,omitempty"`
+	AttemptID   *uuid.UUID                       `gorm:"type:uuid" json:"-"`
+	CorrigidaEm *time.Time                       `json:"corrigida_em,omitempty"`
+	CreatedAt   time.Time                        `gorm:"index:idx_respostas_discursivas_status_created,priority:2" json:"created_at"`
+	UpdatedAt   time.Time                        `json:"updated_at"`
+
+	Questao *Questao `gorm:"-" json:"questao,omitempty"`
+}
+
+func (RespostaDiscursiva) TableName() string {
+	return "respostas_discursivas"
+}
+
+func (r *RespostaDiscursiva) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+type EnviarRespostaDiscursivaRequest struct {
+	Texto string `json:"texto" binding:"required,max=20000"`
+}
+
+// CorrigirRespostaDiscursivaRequest grades an answer: one entry of Notas per
+// rubric criterion, in the rubric order.
+type CorrigirRespostaDiscursivaRequest struct {
+	Notas      []RubricaNotaRequest `json:"notas" binding:"required,dive"`
+	Comentario string               `json:"comentario" binding:"max=5000"`
+}
+
+type RubricaNotaRequest struct {
+	Nota       *float64 `json:"nota" binding:"required"`
+	Comentario string   `json:"comentario" binding:"max=2000"`
+}
+
+// RespostaDiscursivaFilters drives the student's listing and the correction
+// queue. Nil filters are not applied.
+type RespostaDiscursivaFilters struct {
+	Status     string
+	QuestaoID  *int
+	Disciplina []string
+	Limit      int
+	Offset     int
+}
+
+type RespostaDiscursivaList struct {
+	Data   []RespostaDiscursiva `json:"data"`
+	Total  int64                `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// DesempenhoDiscursivo sums up graded discursive answers, overall or for one
+// disciplina. PercentualMedio is the mean share of the points obtained.
+type DesempenhoDiscursivo struct {
+	Disciplina      string  `json:"disciplina,omitempty"`
+	Corrigidas      int     `json:"corrigidas"`
+	Aprovadas       int     `json:"aprovadas"`
+	PercentualMedio float64 `json:"percentual_medio"`
+}
+
+type DesempenhoDiscursivoResumo struct {
+	Geral       DesempenhoDiscursivo   `json:"geral"`
+	Disciplinas []DesempenhoDiscursivo `json:"disciplinas"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevisaoCard schedules the review of a question the user got wrong, using
+// SM-2: every correct review stretches the interval by EaseFactor and a wrong
+// answer starts it over.
+type RevisaoCard struct {
+	UserID         uuid.UUID  `gorm:"type:uuid;primaryKey;index:idx_revisao_cards_user_due,priority:1" json:"-"`
+	QuestaoID      int        `gorm:"primaryKey" json:"questao_id"`
+	Repetitions    int        `gorm:"not null;default:0" json:"repeticoes"`
+	IntervalDays   int        `gorm:"not null;default:0" json:"intervalo_dias"`
+	EaseFactor     float64    `gorm:"not null;default:2.5" json:"fator_facilidade"`
+	Lapses         int        `gorm:"not null;default:0" json:"lapsos"`
+	Reviews        int        `gorm:"not null;default:0" json:"revisoes"`
+	DueAt          time.Time  `gorm:"not null;index:idx_revisao_cards_user_due,priority:2" json:"proxima_revisao"`
+	LastReviewedAt *time.Time `json:"ultima_revisao,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// RevisaoConfig holds a user's review settings. Users without a row get
+// DefaultRevisaoConfig.
+type RevisaoConfig struct {
+	UserID                uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	LimiteDiario          int       `gorm:"not null" json:"limite_diario"`
+	PrimeiroIntervaloDias int       `gorm:"not null" json:"primeiro_intervalo_dias"`
+	SegundoIntervaloDias  int       `gorm:"not null" json:"segundo_intervalo_dias"`
+	IntervaloMaximoDias   int       `gorm:"not null" json:"intervalo_maximo_dias"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+func DefaultRevisaoConfig(userID uuid.UUID) RevisaoConfig {
+	return RevisaoConfig{
+		UserID:                userID,
+		LimiteDiario:          50,
+		PrimeiroIntervaloDias: 1,
+		SegundoIntervaloDias:  6,
+		IntervaloMaximoDias:   180,
+	}
+}
+
+type UpdateRevisaoConfigRequest struct {
+	LimiteDiario          int `json:"limite_diario" binding:"required,min=1,max=500"`
+	PrimeiroIntervaloDias int `json:"primeiro_intervalo_dias" binding:"required,min=1,max=30"`
+	SegundoIntervaloDias  int `json:"segundo_intervalo_dias" binding:"required,min=1,max=90"`
+	IntervaloMaximoDias   int `json:"intervalo_maximo_dias" binding:"required,min=1,max=3650"`
+}
+
+type RevisaoItem struct {
+	RevisaoCard
+	Questao *Questao `json:"questao"`
+}
+
+// RevisaoHoje is today's review queue: the cards due by the end of the day,
+// oldest first, up to what is left of the daily limit.
+type RevisaoHoje struct {
+	Limite        int           `json:"limite"`
+	RevisadasHoje int64         `json:"revisadas_hoje"`
+	Pendentes     int64         `json:"pendentes"`
+	Questoes      []RevisaoItem `json:"questoes"`
+}
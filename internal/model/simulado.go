@@ -0,0 +1,126 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	SimuladoEmAndamento = "em_andamento"
+	SimuladoFinalizado  = "finalizado"
+)
+
+// Simulado is a timed mock exam built from the question bank. Answers are
+// accepted until ExpiresAt; finishing grades it and records the attempts.
+type Simulado struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID         uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Titulo         string            `gorm:"type:varchar(200);not null" json:"titulo"`
+	Status         string            `gorm:"type:varchar(20);not null;default:em_andamento" json:"status"`
+	DuracaoMinutos int               `gorm:"not null" json:"duracao_minutos"`
+	TotalQuestoes  int               `gorm:"not null" json:"total_questoes"`
+	Acertos        *int              `json:"acertos,omitempty"`
+	StartedAt      time.Time         `gorm:"not null" json:"iniciado_em"`
+	ExpiresAt      time.Time         `gorm:"not null" json:"expira_em"`
+	FinishedAt     *time.Time        `json:"finalizado_em,omitempty"`
+	Questoes       []SimuladoQuestao `gorm:"foreignKey:SimuladoID" json:"questoes,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (s *Simulado) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// SimuladoQuestao is one question of a simulado, in exam order. Correct and
+// AttemptID are set when the simulado is finished.
+type SimuladoQuestao struct {
+	SimuladoID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"-"`
+	Ordem      int        `gorm:"primaryKey" json:"ordem"`
+	QuestaoID  int        `gorm:"not null;index" json:"questao_id"`
+	Resposta   *string    `gorm:"type:varchar(10)" json:"resposta"`
+	AnsweredAt *time.Time `json:"respondida_em,omitempty"`
+	Correct    *bool      `json:"correta,omitempty"`
+	AttemptID  *uuid.UUID `gorm:"type:uuid" json:"-"`
+}
+
+func (SimuladoQuestao) TableName() string {
+	return "simulado_questoes"
+}
+
+// SimuladoBloco asks for Quantidade questions matching any of the given
+// values of each filter, e.g. 10 of Direito Constitucional by FGV.
+type SimuladoBloco struct {
+	Quantidade int      `json:"quantidade" binding:"required,min=1,max=200"`
+	Disciplina []string `json:"disciplina"`
+	Assunto    []string `json:"assunto"`
+	Banca      []string `json:"banca"`
+}
+
+type CreateSimuladoRequest struct {
+	Titulo         string          `json:"titulo"`
+	DuracaoMinutos int             `json:"duracao_minutos" binding:"required,min=1,max=600"`
+	Blocos         []SimuladoBloco `json:"blocos" binding:"required,min=1,max=20,dive"`
+}
+
+type ResponderSimuladoRequest struct {
+	Resposta string `json:"resposta" binding:"required"`
+}
+
+// SimuladoQuestaoView is a question as shown in a simulado. The answer key
+// and explanations are only filled once the simulado is finished.
+type SimuladoQuestaoView struct {
+	Ordem          int        `json:"ordem"`
+	QuestaoID      int        `json:"questao_id"`
+	Titulo         *string    `json:"titulo"`
+	Enunciado      *string    `json:"enunciado"`
+	AlternativaA   *string    `json:"alternativa_a"`
+	AlternativaB   *string    `json:"alternativa_b"`
+	AlternativaC   *string    `json:"alternativa_c"`
+	AlternativaD   *string    `json:"alternativa_d"`
+	AlternativaE   *string    `json:"alternativa_e"`
+	TipoQuestao    *string    `json:"tipo_questao"`
+	Disciplina     *string    `json:"disciplina"`
+	Assunto        *string    `json:"assunto"`
+	Banca          *string    `json:"banca"`
+	Ano            *int       `json:"ano"`
+	Resposta       *string    `json:"resposta"`
+	RespondidaEm   *time.Time `json:"respondida_em,omitempty"`
+	Correta        *bool      `json:"correta,omitempty"`
+	Gabarito       *string    `json:"gabarito,omitempty"`
+	Anulada        *bool      `json:"anulada,omitempty"`
+	Comentario     *string    `json:"comentario,omitempty"`
+	ResolucaoBanca *string    `json:"resolucao_banca,omitempty"`
+}
+
+type SimuladoDetail struct {
+	Simulado
+	Questoes []SimuladoQuestaoView `json:"questoes"`
+}
+
+// SimuladoResultado counts the answers of a finished simulado. Blank answers
+// count as wrong in Percentual.
+type SimuladoResultado struct {
+	Total      int     `json:"total"`
+	Acertos    int     `json:"acertos"`
+	Erros      int     `json:"erros"`
+	EmBranco   int     `json:"em_branco"`
+	Percentual float64 `json:"percentual"`
+}
+
+type SimuladoDisciplinaResultado struct {
+	Disciplina string `json:"disciplina"`
+	SimuladoResultado
+}
+
+type SimuladoReport struct {
+	Simulado      Simulado                      `json:"simulado"`
+	Geral         SimuladoResultado             `json:"geral"`
+	PorDisciplina []SimuladoDisciplinaResultado `json:"por_disciplina"`
+	Questoes      []SimuladoQuestaoView         `json:"questoes"`
+}
@@ -8,20 +8,25 @@ import (
 )
 
 type User struct {
-	ID         uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
-	Email      string         `gorm:"uniqueIndex;not null" json:"email"`
-	FullName   string         `gorm:"not null" json:"full_name"`
-	Password   string         `json:"-"`
-	Avatar     string         `json:"avatar,omitempty"`
-	Provider   string         `json:"provider,omitempty"` // local, google, facebook
-	ProviderID string         `json:"provider_id,omitempty"`
-	Role       string         `gorm:"type:varchar(20);default:user" json:"role"`
-	PlanID     *uuid.UUID     `gorm:"type:uuid" json:"plan_id,omitempty"`
-	Plan       *Plan          `gorm:"foreignKey:PlanID" json:"plan,omitempty"`
-	Active     bool           `gorm:"default:true" json:"active"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Email                string         `gorm:"uniqueIndex;not null" json:"email"`
+	FullName             string         `gorm:"not null" json:"full_name"`
+	Password             string         `json:"-"`
+	Avatar               string         `json:"avatar,omitempty"`
+	AvatarAssetID        *uuid.UUID     `gorm:"type:uuid" json:"-"`
+	Provider             string         `json:"provider,omitempty"` // local, google, facebook
+	ProviderID           string         `json:"provider_id,omitempty"`
+	Role                 string         `gorm:"type:varchar(20);default:user" json:"role"`
+	PlanID               *uuid.UUID     `gorm:"type:uuid" json:"plan_id,omitempty"`
+	Plan                 *Plan          `gorm:"foreignKey:PlanID" json:"plan,omitempty"`
+	Active               bool           `gorm:"default:true" json:"active"`
+	EmailVerifiedAt      *time.Time     `json:"email_verified_at,omitempty"`
+	TwoFactorSecret      string         `json:"-"`
+	TwoFactorEnabledAt   *time.Time     `json:"two_factor_enabled_at,omitempty"`
+	TwoFactorLastCounter int64          `gorm:"default:0" json:"-"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // BeforeCreate generates a UUID for the user if not already set
@@ -47,10 +52,11 @@ type CreateAdminRequest struct {
 	AdminSecret string `json:"admin_secret" binding:"required"`
 }
 
+// UpdateUserRequest edits the profile fields of a user. Status changes go
+// through /users/:id/deactivate and /users/:id/reactivate.
 type UpdateUserRequest struct {
 	Email    string `json:"email" binding:"email"`
 	FullName string `json:"full_name"`
-	Active   bool   `json:"active"`
 }
 
 type LoginRequest struct {
@@ -58,19 +64,29 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// LoginResponse carries the issued tokens. When a second factor is needed
+// only TwoFactorRequired (or TwoFactorSetupRequired) and TwoFactorToken are
+// set, and the login is completed through POST /auth/2fa/verify.
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresIn int64  `json:"expires_in"`
-	User      User   `json:"user"`
+	Token                  string   `json:"token,omitempty"`
+	RefreshToken           string   `json:"refresh_token,omitempty"`
+	ExpiresIn              int64    `json:"expires_in"`
+	User                   *User    `json:"user,omitempty"`
+	TwoFactorRequired      bool     `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required,omitempty"`
+	TwoFactorToken         string   `json:"two_factor_token,omitempty"`
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"`
 }
 
 type RefreshTokenRequest struct {
-	Token string `json:"token" binding:"required"`
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type SocialAuthRequest struct {
-	Provider    string `json:"provider" binding:"required,oneof=google facebook"`
+	Provider    string `json:"provider" binding:"required"`
 	AccessToken string `json:"access_token" binding:"required"`
+	// IDToken is required by OIDC providers.
+	IDToken string `json:"id_token"`
 }
 
 type SocialUserInfo struct {
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	OAuthPurposeLogin = "login"
+	OAuthPurposeLink  = "link"
+)
+
+// UserIdentity links a user to an account on an external identity provider.
+// A user may have several identities, one per provider.
+type UserIdentity struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject" json:"provider"`
+	ProviderUserID string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject" json:"provider_user_id"`
+	Email          string    `gorm:"type:varchar(255)" json:"email"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+func (i *UserIdentity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+type LinkProviderRequest struct {
+	AccessToken string `json:"access_token"`
+	// IDToken is required by OIDC providers.
+	IDToken string `json:"id_token"`
+}
+
+type LinkProviderResponse struct {
+	URL      string        `json:"url,omitempty"`
+	Identity *UserIdentity `json:"identity,omitempty"`
+}
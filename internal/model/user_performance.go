@@ -7,16 +7,20 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserPerformance holds a user's totals for one day, derived from
+// question_attempts. Rows without Day were self-reported before attempts were
+// recorded and are kept as history.
 type UserPerformance struct {
-	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	UserID           uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	TotalQuestions   int       `gorm:"not null" json:"total_questoes"`
-	CorrectQuestions int       `gorm:"not null" json:"questoes_corretas"`
-	WrongQuestions   int       `gorm:"not null" json:"questoes_erradas"`
-	AccuracyPercent  float64   `gorm:"not null" json:"percentual_acerto"`
-	RecordedAt       time.Time `gorm:"not null" json:"data_gravacao"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_performances_user_day,priority:1" json:"user_id"`
+	Day              *time.Time `gorm:"type:date;uniqueIndex:idx_user_performances_user_day,priority:2" json:"-"`
+	TotalQuestions   int        `gorm:"not null" json:"total_questoes"`
+	CorrectQuestions int        `gorm:"not null" json:"questoes_corretas"`
+	WrongQuestions   int        `gorm:"not null" json:"questoes_erradas"`
+	AccuracyPercent  float64    `gorm:"not null" json:"percentual_acerto"`
+	RecordedAt       time.Time  `gorm:"not null" json:"data_gravacao"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 func (u *UserPerformance) BeforeCreate(tx *gorm.DB) error {
@@ -29,13 +33,6 @@ func (u *UserPerformance) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-type CreateUserPerformanceRequest struct {
-	TotalQuestoes     int        `json:"total_questoes" binding:"min=0"`
-	QuestoesCorretas  int        `json:"questoes_corretas" binding:"min=0"`
-	QuestoesErradas   int        `json:"questoes_erradas" binding:"min=0"`
-	DataGravacao      *time.Time `json:"data_gravacao"`
-}
-
 type UserPerformanceSummary struct {
 	TotalQuestions   int     `json:"total_questoes"`
 	CorrectQuestions int     `json:"questoes_corretas"`
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserSession represents a logged-in device. Only the SHA-256 hash of the
+// opaque refresh token is stored; the previous hash is kept to detect reuse
+// of an already rotated token.
+type UserSession struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	RefreshTokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	PreviousTokenHash *string    `gorm:"type:varchar(64);index" json:"-"`
+	UserAgent         string     `gorm:"type:text" json:"user_agent"`
+	IPAddress         string     `gorm:"type:varchar(64)" json:"ip_address"`
+	LastSeenAt        time.Time  `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt         time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason     *string    `gorm:"type:varchar(50)" json:"revoked_reason,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+func (s *UserSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	if s.LastSeenAt.IsZero() {
+		s.LastSeenAt = time.Now()
+	}
+	return nil
+}
+
+// ClientInfo identifies the device/network that started or refreshed a session.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserSessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -39,6 +39,8 @@ type VadeMecumCodigo struct {
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	// Incidencia counts the questions citing the article; only the listing fills it.
+	Incidencia *int64 `gorm:"-" json:"incidencia,omitempty"`
 }
 
 func (v *VadeMecumCodigo) BeforeCreate(tx *gorm.DB) error {
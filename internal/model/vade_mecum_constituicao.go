@@ -29,6 +29,8 @@ type VadeMecumConstituicao struct {
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	// Incidencia counts the questions citing the dispositivo; only the listing fills it.
+	Incidencia *int64 `gorm:"-" json:"incidencia,omitempty"`
 }
 
 func (VadeMecumConstituicao) TableName() string {
@@ -0,0 +1,49 @@
+package model
+
+// VadeMecumIncidencia counts the questions of a banca, cargo and year that
+// cite a vade-mecum row. Questions without banca, cargo or year are counted
+// under "" and 0. The counts follow questao_dispositivos and are refreshed
+// whenever the links or the question change.
+type VadeMecumIncidencia struct {
+	Fonte         string `gorm:"type:varchar(20);primaryKey" json:"fonte"`
+	DispositivoID string `gorm:"type:text;primaryKey" json:"dispositivo_id"`
+	Banca         string `gorm:"type:varchar(200);primaryKey" json:"banca"`
+	Cargo         string `gorm:"type:varchar(200);primaryKey" json:"cargo"`
+	Ano           int    `gorm:"primaryKey" json:"ano"`
+	Total         int    `gorm:"not null" json:"total"`
+}
+
+func (VadeMecumIncidencia) TableName() string {
+	return "vade_mecum_incidencias"
+}
+
+// VadeMecumIncidenciaFilters restricts the counted questions. Slices match
+// any of the values and the year range is inclusive. Fonte and Diploma (the
+// nomecodigo of códigos and leis) only apply to the ranking.
+type VadeMecumIncidenciaFilters struct {
+	Fonte   string
+	Diploma string
+	Banca   []string
+	Cargo   []string
+	AnoMin  *int
+	AnoMax  *int
+	Limit   int
+	Offset  int
+}
+
+// VadeMecumRankingItem is a vade-mecum row and how many questions cite it.
+type VadeMecumRankingItem struct {
+	Fonte         string `json:"fonte"`
+	DispositivoID string `json:"dispositivo_id"`
+	Diploma       string `json:"diploma"`
+	Artigo        string `json:"artigo"`
+	Texto         string `json:"texto"`
+	Incidencia    int64  `json:"incidencia"`
+}
+
+type VadeMecumRanking struct {
+	Data   []VadeMecumRankingItem `json:"data"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
@@ -33,6 +33,8 @@ type VadeMecumLei struct {
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	// Incidencia counts the questions citing the article; only the listing fills it.
+	Incidencia *int64 `gorm:"-" json:"incidencia,omitempty"`
 }
 
 func (VadeMecumLei) TableName() string {
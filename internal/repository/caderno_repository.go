@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CadernoRepository struct {
+	db *gorm.DB
+}
+
+func NewCadernoRepository(db *gorm.DB) *CadernoRepository {
+	return &CadernoRepository{db: db}
+}
+
+// Create stores the caderno together with its question list, if any.
+func (r *CadernoRepository) Create(caderno *model.Caderno) error {
+	return r.db.Create(caderno).Error
+}
+
+func (r *CadernoRepository) GetByIDAndUser(id, userID uuid.UUID) (*model.Caderno, error) {
+	var caderno model.Caderno
+	if err := r.db.First(&caderno, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		return nil, err
+	}
+	return &caderno, nil
+}
+
+func (r *CadernoRepository) GetByShareToken(token string) (*model.Caderno, error) {
+	var caderno model.Caderno
+	if err := r.db.First(&caderno, "share_token = ?", token).Error; err != nil {
+		return nil, err
+	}
+	return &caderno, nil
+}
+
+func (r *CadernoRepository) ListByUser(userID uuid.UUID) ([]model.Caderno, error) {
+	var items []model.Caderno
+	err := r.db.Where("user_id = ?", userID).Order("updated_at DESC").Find(&items).Error
+	return items, err
+}
+
+// Update saves the caderno fields and, when questaoIDs is not nil, replaces
+// its question list.
+func (r *CadernoRepository) Update(caderno *model.Caderno, questaoIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(caderno).Select("nome", "descricao", "tipo", "filtros", "updated_at").Updates(caderno).Error; err != nil {
+			return err
+		}
+		if questaoIDs == nil {
+			return nil
+		}
+		if err := tx.Where("caderno_id = ?", caderno.ID).Delete(&model.CadernoQuestao{}).Error; err != nil {
+			return err
+		}
+		return insertCadernoQuestoes(tx, caderno.ID, questaoIDs, 1)
+	})
+}
+
+// AddQuestoes appends the questions to the end of the list, skipping the ones
+// already in it. It reports false, adding nothing, when the list would end up
+// with more than limit questions.
+func (r *CadernoRepository) AddQuestoes(cadernoID uuid.UUID, questaoIDs []int, limit int) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var caderno model.Caderno
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&caderno, "id = ?", cadernoID).Error; err != nil {
+			return err
+		}
+		var existing, repeated int64
+		if err := tx.Model(&model.CadernoQuestao{}).Where("caderno_id = ?", cadernoID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.CadernoQuestao{}).Where("caderno_id = ? AND questao_id IN ?", cadernoID, questaoIDs).
+			Count(&repeated).Error; err != nil {
+			return err
+		}
+		if existing+int64(len(questaoIDs))-repeated > int64(limit) {
+			return nil
+		}
+
+		var last int
+		if err := tx.Model(&model.CadernoQuestao{}).Where("caderno_id = ?", cadernoID).
+			Select("COALESCE(MAX(ordem), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		if err := insertCadernoQuestoes(tx, cadernoID, questaoIDs, last+1); err != nil {
+			return err
+		}
+		added = true
+		return tx.Model(&caderno).Update("updated_at", time.Now()).Error
+	})
+	return added, err
+}
+
+func (r *CadernoRepository) RemoveQuestao(cadernoID uuid.UUID, questaoID int) (bool, error) {
+	result := r.db.Where("caderno_id = ? AND questao_id = ?", cadernoID, questaoID).Delete(&model.CadernoQuestao{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *CadernoRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("caderno_id = ?", id).Delete(&model.CadernoQuestao{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("caderno_id = ?", id).Delete(&model.CadernoProgresso{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Caderno{}, "id = ?", id).Error
+	})
+}
+
+func (r *CadernoRepository) SetShareToken(id uuid.UUID, token *string) error {
+	return r.db.Model(&model.Caderno{}).Where("id = ?", id).Update("share_token", token).Error
+}
+
+// GetProgress returns the user's progress row, creating it on first access.
+func (r *CadernoRepository) GetProgress(cadernoID, userID uuid.UUID) (*model.CadernoProgresso, error) {
+	progress := model.CadernoProgresso{CadernoID: cadernoID, UserID: userID, StartedAt: time.Now()}
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.First(&progress, "caderno_id = ? AND user_id = ?", cadernoID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// ResetProgress restarts the user's progress from now.
+func (r *CadernoRepository) ResetProgress(cadernoID, userID uuid.UUID) (*model.CadernoProgresso, error) {
+	progress := model.CadernoProgresso{CadernoID: cadernoID, UserID: userID, StartedAt: time.Now()}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "caderno_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"started_at", "updated_at"}),
+	}).Create(&progress).Error
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// ListQuestoes returns one page of a list caderno's questions in order.
+func (r *CadernoRepository) ListQuestoes(cadernoID uuid.UUID, page, pageSize int) ([]model.Questao, int64, error) {
+	var total int64
+	if err := r.listQuery(cadernoID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.Questao
+	err := r.listQuery(cadernoID).
+		Select("questoes.*").
+		Order("caderno_questoes.ordem").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&items).Error
+	return items, total, err
+}
+
+func (r *CadernoRepository) listQuery(cadernoID uuid.UUID) *gorm.DB {
+	return questaoQuery(r.db, nil).
+		Joins("JOIN caderno_questoes ON caderno_questoes.questao_id = questoes.id").
+		Where("caderno_questoes.caderno_id = ?", cadernoID)
+}
+
+// Progress counts the caderno questions the user answered since
+// progress.StartedAt, using the latest answer to each question, and finds the
+// first one not answered yet in listing order. Filter cadernos are resolved
+// with filters.
+func (r *CadernoRepository) Progress(caderno *model.Caderno, filters *model.QuestaoFilters, progress *model.CadernoProgresso) (*model.CadernoProgressoResumo, error) {
+	scope := func() *gorm.DB {
+		if caderno.Tipo == model.CadernoTipoFiltro {
+			return questaoQuery(r.db, filters)
+		}
+		return r.listQuery(caderno.ID)
+	}
+	summary := &model.CadernoProgressoResumo{IniciadoEm: progress.StartedAt}
+
+	if err := scope().Count(&summary.Total).Error; err != nil {
+		return nil, err
+	}
+
+	var counts struct {
+		Respondidas int64
+		Acertos     int64
+	}
+	err := r.db.Raw(`
+		SELECT COUNT(*) AS respondidas, COUNT(*) FILTER (WHERE correct) AS acertos
+		FROM (
+			SELECT DISTINCT ON (qa.questao_id) qa.correct
+			FROM question_attempts qa
+			WHERE qa.user_id = ? AND qa.answered_at >= ? AND qa.questao_id IN (?)
+			ORDER BY qa.questao_id, qa.answered_at DESC
+		) latest`, progress.UserID, progress.StartedAt, scope().Select("questoes.id")).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	summary.Respondidas = counts.Respondidas
+	summary.Acertos = counts.Acertos
+	summary.Erros = counts.Respondidas - counts.Acertos
+
+	next := scope().
+		Where("NOT EXISTS (SELECT 1 FROM question_attempts qa WHERE qa.questao_id = questoes.id AND qa.user_id = ? AND qa.answered_at >= ?)",
+			progress.UserID, progress.StartedAt)
+	if caderno.Tipo == model.CadernoTipoFiltro {
+		next = next.Clauses(questaoOrder(filters))
+	} else {
+		next = next.Order("caderno_questoes.ordem")
+	}
+	var ids []int
+	if err := next.Limit(1).Pluck("questoes.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		summary.ProximaQuestaoID = &ids[0]
+	}
+
+	return summary, nil
+}
+
+func insertCadernoQuestoes(tx *gorm.DB, cadernoID uuid.UUID, questaoIDs []int, firstOrdem int) error {
+	if len(questaoIDs) == 0 {
+		return nil
+	}
+	items := make([]model.CadernoQuestao, 0, len(questaoIDs))
+	for i, id := range questaoIDs {
+		items = append(items, model.CadernoQuestao{CadernoID: cadernoID, QuestaoID: id, Ordem: firstOrdem + i})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&items).Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewDataExportRepository(db *gorm.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+func (r *DataExportRepository) Create(export *model.DataExport) error {
+	return r.db.Create(export).Error
+}
+
+// GetByIDAndUser loads an export without its archive.
+func (r *DataExportRepository) GetByIDAndUser(id, userID uuid.UUID) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := r.db.Omit("data").Where("id = ? AND user_id = ?", id, userID).First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetWithData loads an export including its archive.
+func (r *DataExportRepository) GetWithData(id, userID uuid.UUID) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ListByUser returns the exports of the user, newest first, without archives.
+func (r *DataExportRepository) ListByUser(userID uuid.UUID) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.Omit("data").Where("user_id = ?", userID).Order("created_at DESC").Find(&exports).Error
+	return exports, err
+}
+
+// GetInProgress returns the pending or processing export of the user, or nil.
+func (r *DataExportRepository) GetInProgress(userID uuid.UUID) (*model.DataExport, error) {
+	var exports []model.DataExport
+	err := r.db.Omit("data").
+		Where("user_id = ? AND status IN ?", userID, []string{model.DataExportPending, model.DataExportProcessing}).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&exports).Error
+	if err != nil || len(exports) == 0 {
+		return nil, err
+	}
+	return &exports[0], nil
+}
+
+func (r *DataExportRepository) UpdateColumns(id uuid.UUID, values map[string]interface{}) error {
+	return r.db.Model(&model.DataExport{}).Where("id = ?", id).Updates(values).Error
+}
+
+func (r *DataExportRepository) DeleteExpired(now time.Time) error {
+	return r.db.Where("expires_at IS NOT NULL AND expires_at < ?", now).Delete(&model.DataExport{}).Error
+}
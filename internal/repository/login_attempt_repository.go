@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+// LoginAttemptRepository is the Postgres implementation of
+// service.LoginAttemptStore, shared by every API instance.
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) Get(key string) (*model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := r.db.Where("key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RegisterFailure increments the counter atomically. The count restarts when
+// the previous failure is older than window.
+func (r *LoginAttemptRepository) RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := r.db.Raw(`
+		INSERT INTO login_attempts (key, failures, last_failure_at, updated_at)
+		VALUES (?, 1, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING key, failures, last_failure_at, locked_until, updated_at`,
+		key, now, now, now.Add(-window),
+	).Scan(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(key string, until time.Time) error {
+	return r.db.Model(&model.LoginAttempt{}).
+		Where("key = ?", key).
+		Updates(map[string]interface{}{"locked_until": until, "updated_at": time.Now()}).Error
+}
+
+func (r *LoginAttemptRepository) Reset(key string) error {
+	return r.db.Where("key = ?", key).Delete(&model.LoginAttempt{}).Error
+}
+
+// DeleteStale removes counters that are neither recent nor locked.
+func (r *LoginAttemptRepository) DeleteStale(before time.Time) error {
+	return r.db.
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).
+		Delete(&model.LoginAttempt{}).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type NotificacaoRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificacaoRepository(db *gorm.DB) *NotificacaoRepository {
+	return &NotificacaoRepository{db: db}
+}
+
+func (r *NotificacaoRepository) Create(item *model.Notificacao) error {
+	return r.db.Create(item).Error
+}
+
+// ListByUser returns one page of the user's notifications, newest first, with
+// the total matching and the unread count.
+func (r *NotificacaoRepository) ListByUser(userID uuid.UUID, naoLidas bool, limit, offset int) ([]model.Notificacao, int64, int64, error) {
+	base := func() *gorm.DB {
+		query := r.db.Model(&model.Notificacao{}).Where("user_id = ?", userID)
+		if naoLidas {
+			query = query.Where("lida_em IS NULL")
+		}
+		return query
+	}
+
+	var total, unread int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, 0, err
+	}
+	if err := r.db.Model(&model.Notificacao{}).Where("user_id = ? AND lida_em IS NULL", userID).Count(&unread).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	var items []model.Notificacao
+	err := base().Order("created_at DESC").Limit(limit).Offset(offset).Find(&items).Error
+	return items, total, unread, err
+}
+
+func (r *NotificacaoRepository) MarkRead(userID, id uuid.UUID, at time.Time) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.Notificacao{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil || count == 0 {
+		return false, err
+	}
+	err := r.db.Model(&model.Notificacao{}).
+		Where("id = ? AND user_id = ? AND lida_em IS NULL", id, userID).
+		Update("lida_em", at).Error
+	return true, err
+}
+
+func (r *NotificacaoRepository) MarkAllRead(userID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.Model(&model.Notificacao{}).
+		Where("user_id = ? AND lida_em IS NULL", userID).
+		Update("lida_em", at)
+	return result.RowsAffected, result.Error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
 	"gorm.io/gorm"
 )
@@ -20,3 +21,11 @@ func (r *PlanRepository) GetAllWithUsers() ([]model.Plan, error) {
 	}
 	return plans, nil
 }
+
+func (r *PlanRepository) GetByID(id uuid.UUID) (*model.Plan, error) {
+	var plan model.Plan
+	if err := r.db.First(&plan, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PrivacyRepository reads and erases, across tables, every record tied to a
+// user. It backs the LGPD data export and erasure flows.
+type PrivacyRepository struct {
+	db *gorm.DB
+}
+
+func NewPrivacyRepository(db *gorm.DB) *PrivacyRepository {
+	return &PrivacyRepository{db: db}
+}
+
+// Snapshot collects all data of the user. Asaas customers created before they
+// were linked to users are matched by e-mail.
+func (r *PrivacyRepository) Snapshot(userID uuid.UUID) (*model.UserDataSnapshot, error) {
+	snapshot := &model.UserDataSnapshot{}
+	if err := r.db.Preload("Plan").First(&snapshot.User, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	queries := []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&snapshot.Sessions, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Identities, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Performances, r.db.Where("user_id = ?", userID).Order("recorded_at")},
+		{&snapshot.Attempts, r.db.Where("user_id = ?", userID).Order("answered_at")},
+		{&snapshot.Simulados, r.db.Preload("Questoes").Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Cadernos, r.db.Preload("Questoes").Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.RevisaoCards, r.db.Where("user_id = ?", userID).Order("due_at")},
+		{&snapshot.Discursivas, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Reportes, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Notificacoes, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.CourseCategories, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.Courses, r.db.Preload("Modules").Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.CourseModules, r.db.Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.CourseItems, r.db.Preload("Modules").Where("user_id = ?", userID).Order("created_at")},
+		{&snapshot.AsaasCustomers, r.customersOf(r.db, &snapshot.User).Order("created_at")},
+		{&snapshot.AuditLogs, r.db.Where("user_id = ?", userID).Order("created_at")},
+	}
+	for _, q := range queries {
+		if err := q.query.Find(q.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if ids := customerAsaasIDs(snapshot.AsaasCustomers); len(ids) > 0 {
+		if err := r.db.Where("customer_id IN ?", ids).Order("created_at").Find(&snapshot.AsaasPayments).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var config model.RevisaoConfig
+	if err := r.db.First(&config, "user_id = ?", userID).Error; err == nil {
+		snapshot.RevisaoConfig = &config
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var perfil model.PerfilEstudo
+	if err := r.db.First(&perfil, "user_id = ?", userID).Error; err == nil {
+		snapshot.PerfilEstudo = &perfil
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	if snapshot.User.AvatarAssetID != nil {
+		var avatar model.MediaAsset
+		err := r.db.First(&avatar, "id = ?", *snapshot.User.AvatarAssetID).Error
+		if err == nil {
+			snapshot.Avatar = &avatar
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Erase runs the erasure of a user in a single transaction:
+//   - the user row is kept for referential integrity but stripped of PII,
+//     deactivated and soft deleted;
+//   - Asaas customers are anonymized and the JSON payloads of their payments
+//     are passed through redact; payment rows themselves are retained;
+//   - personal content, credentials and exports are hard-deleted.
+//
+// It returns a nil report, and erases nothing, when the user is the last
+// active administrator.
+func (r *PrivacyRepository) Erase(userID uuid.UUID, redact func(string) string) (*model.ErasureReport, error) {
+	report := &model.ErasureReport{Deleted: map[string]int64{}}
+	now := time.Now()
+	kept := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if kept, err = keepsActiveAdmin(tx, userID); err != nil || !kept {
+			return err
+		}
+
+		var user model.User
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		var customers []model.AsaasCustomer
+		if err := r.customersOf(tx, &user).Find(&customers).Error; err != nil {
+			return err
+		}
+		for _, customer := range customers {
+			if err := tx.Model(&model.AsaasCustomer{}).Where("id = ?", customer.ID).Updates(map[string]interface{}{
+				"user_id":       userID,
+				"name":          "Titular removido",
+				"cpf_cnpj":      "",
+				"email":         fmt.Sprintf("removido-%s@deleted.invalid", customer.ID),
+				"phone":         "",
+				"response_json": redact(customer.ResponseJSON),
+				"anonymized_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		report.AnonymizedCustomers = int64(len(customers))
+
+		if ids := customerAsaasIDs(customers); len(ids) > 0 {
+			var payments []model.AsaasPayment
+			if err := tx.Where("customer_id IN ?", ids).Find(&payments).Error; err != nil {
+				return err
+			}
+			for _, payment := range payments {
+				if err := tx.Model(&model.AsaasPayment{}).Where("id = ?", payment.ID).Updates(map[string]interface{}{
+					"request_json":      redact(payment.RequestJSON),
+					"response_json":     redact(payment.ResponseJSON),
+					"confirmation_json": redact(payment.ConfirmationJSON),
+				}).Error; err != nil {
+					return err
+				}
+			}
+			report.RedactedPayments = int64(len(payments))
+		}
+
+		deletes := []erasureStep{
+			{"course_module_items", tx.Where("course_item_id IN (?) OR course_module_id IN (?)",
+				tx.Unscoped().Model(&model.CourseItem{}).Select("id").Where("user_id = ?", userID),
+				tx.Unscoped().Model(&model.CourseModule{}).Select("id").Where("user_id = ?", userID)), &model.CourseModuleItem{}},
+			{"course_course_modules", tx.Where("course_id IN (?) OR course_module_id IN (?)",
+				tx.Unscoped().Model(&model.Course{}).Select("id").Where("user_id = ?", userID),
+				tx.Unscoped().Model(&model.CourseModule{}).Select("id").Where("user_id = ?", userID)), &model.CourseCourseModule{}},
+			{"course_items", tx.Unscoped().Where("user_id = ?", userID), &model.CourseItem{}},
+			{"courses", tx.Unscoped().Where("user_id = ?", userID), &model.Course{}},
+			{"course_modules", tx.Unscoped().Where("user_id = ?", userID), &model.CourseModule{}},
+			{"course_categories", tx.Unscoped().Where("user_id = ?", userID), &model.CourseCategory{}},
+			{"simulado_questoes", tx.Where("simulado_id IN (?)",
+				tx.Model(&model.Simulado{}).Select("id").Where("user_id = ?", userID)), &model.SimuladoQuestao{}},
+			{"simulados", tx.Where("user_id = ?", userID), &model.Simulado{}},
+			{"caderno_questoes", tx.Where("caderno_id IN (?)",
+				tx.Model(&model.Caderno{}).Select("id").Where("user_id = ?", userID)), &model.CadernoQuestao{}},
+			{"caderno_progressos", tx.Where("user_id = ? OR caderno_id IN (?)", userID,
+				tx.Model(&model.Caderno{}).Select("id").Where("user_id = ?", userID)), &model.CadernoProgresso{}},
+			{"cadernos", tx.Where("user_id = ?", userID), &model.Caderno{}},
+			{"revisao_cards", tx.Where("user_id = ?", userID), &model.RevisaoCard{}},
+			{"revisao_configs", tx.Where("user_id = ?", userID), &model.RevisaoConfig{}},
+			{"perfis_estudo", tx.Where("user_id = ?", userID), &model.PerfilEstudo{}},
+			{"questao_reportes", tx.Where("user_id = ?", userID), &model.QuestaoReporte{}},
+			{"notificacoes", tx.Where("user_id = ?", userID), &model.Notificacao{}},
+			{"respostas_discursivas", tx.Where("user_id = ?", userID), &model.RespostaDiscursiva{}},
+			{"question_attempts", tx.Where("user_id = ?", userID), &model.QuestionAttempt{}},
+			{"user_performances", tx.Where("user_id = ?", userID), &model.UserPerformance{}},
+			{"user_sessions", tx.Where("user_id = ?", userID), &model.UserSession{}},
+			{"user_tokens", tx.Where("user_id = ?", userID), &model.UserToken{}},
+			{"user_identities", tx.Where("user_id = ?", userID), &model.UserIdentity{}},
+			{"user_recovery_codes", tx.Where("user_id = ?", userID), &model.UserRecoveryCode{}},
+			{"data_exports", tx.Where("user_id = ?", userID), &model.DataExport{}},
+			{"login_attempts", tx.Where("key = ?", "account:"+strings.ToLower(strings.TrimSpace(user.Email))), &model.LoginAttempt{}},
+		}
+		if user.AvatarAssetID != nil {
+			deletes = append(deletes, erasureStep{"media_assets", tx.Unscoped().Where("id = ?", *user.AvatarAssetID), &model.MediaAsset{}})
+		}
+		for _, d := range deletes {
+			result := d.query.Delete(d.model)
+			if result.Error != nil {
+				return fmt.Errorf("erase %s: %w", d.name, result.Error)
+			}
+			report.Deleted[d.name] = result.RowsAffected
+		}
+
+		return tx.Unscoped().Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"email":                   fmt.Sprintf("deleted-%s@deleted.invalid", userID),
+			"full_name":               "Usuário removido",
+			"password":                "",
+			"avatar":                  "",
+			"avatar_asset_id":         nil,
+			"provider":                "",
+			"provider_id":             "",
+			"active":                  false,
+			"email_verified_at":       nil,
+			"two_factor_secret":       "",
+			"two_factor_enabled_at":   nil,
+			"two_factor_last_counter": 0,
+			"deleted_at":              now,
+		}).Error
+	})
+	if err != nil || !kept {
+		return nil, err
+	}
+	return report, nil
+}
+
+// erasureStep is a hard delete run by Erase; name is the table reported.
+type erasureStep struct {
+	name  string
+	query *gorm.DB
+	model interface{}
+}
+
+// customersOf matches the Asaas customers of a user: linked rows plus legacy
+// rows, created before the link existed, with the same e-mail.
+func (r *PrivacyRepository) customersOf(db *gorm.DB, user *model.User) *gorm.DB {
+	return db.Model(&model.AsaasCustomer{}).
+		Where("user_id = ? OR (user_id IS NULL AND LOWER(email) = LOWER(?))", user.ID, user.Email)
+}
+
+func customerAsaasIDs(customers []model.AsaasCustomer) []string {
+	ids := make([]string, 0, len(customers))
+	for _, customer := range customers {
+		ids = append(ids, customer.AsaasID)
+	}
+	return ids
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuestaoDispositivoRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestaoDispositivoRepository(db *gorm.DB) *QuestaoDispositivoRepository {
+	return &QuestaoDispositivoRepository{db: db}
+}
+
+// dispositivoTabela describes how to read a vade-mecum source: the name
+// matched by DispositivoBusca.Diploma, the article number and the text.
+// The Constituição has neither name nor article column; its article number
+// is read from the start of the Normativo.
+type dispositivoTabela struct {
+	tabela  string
+	diploma string
+	artigo  string
+	texto   string
+}
+
+var dispositivoTabelas = map[string]dispositivoTabela{
+	model.DispositivoFonteCodigo:         {"vade_mecum_codigos", "nomecodigo", "num_artigo", `"Normativo"`},
+	model.DispositivoFonteLei:            {"vade_mecum_leis", "nomecodigo", "num_artigo", `"Artigos"`},
+	model.DispositivoFonteEstatuto:       {"vade_mecum_estatutos", "nomecodigo", "num_artigo", `"Artigos"`},
+	model.DispositivoFonteConstituicao:   {"vade_mecum_constituicao", "'Constituição Federal'", `substring("Normativo" from '^\s*[Aa]rt[a-z]*\.?\s*([0-9.]*[0-9](-[A-Za-z])?)')`, `"Normativo"`},
+	model.DispositivoFonteOAB:            {"vade_mecum_oab", "nomecodigo", "num_artigo", `"Artigos"`},
+	model.DispositivoFonteJurisprudencia: {"vade_mecum_jurisprudencia", `concat_ws(' ', nomecodigo, "Cabecalho")`, "num_artigo", `"Enunciado"`},
+}
+
+// SupportsFonte reports whether fonte is a known vade-mecum source.
+func (r *QuestaoDispositivoRepository) SupportsFonte(fonte string) bool {
+	_, ok := dispositivoTabelas[fonte]
+	return ok
+}
+
+// FindDispositivos returns, for each source of busca, the first row that
+// matches it.
+func (r *QuestaoDispositivoRepository) FindDispositivos(busca *model.DispositivoBusca) ([]model.DispositivoRef, error) {
+	var refs []model.DispositivoRef
+	for _, fonte := range busca.Fontes {
+		tabela, ok := dispositivoTabelas[fonte]
+		if !ok {
+			continue
+		}
+
+		query := r.db.Table(tabela.tabela).Where("deleted_at IS NULL")
+		if fonte == model.DispositivoFonteConstituicao {
+			query = query.Where(`"Normativo" ~* ?`, busca.ArtigoTexto)
+		} else {
+			query = query.Where("unaccent("+tabela.diploma+") ~* ?", busca.Diploma).
+				Where(tabela.artigo+" ~* ?", busca.Artigo)
+			if busca.Excluir != "" {
+				query = query.Where("unaccent("+tabela.diploma+") !~* ?", busca.Excluir)
+			}
+		}
+
+		var ids []string
+		if err := query.Order("id").Limit(1).Pluck("id::text", &ids).Error; err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			refs = append(refs, model.DispositivoRef{Fonte: fonte, DispositivoID: id})
+		}
+	}
+	return refs, nil
+}
+
+// Resumos summarizes the given rows of a source, keyed by id. Rows deleted
+// from the vade-mecum are left out.
+func (r *QuestaoDispositivoRepository) Resumos(fonte string, ids []string) (map[string]model.DispositivoResumo, error) {
+	resumos := make(map[string]model.DispositivoResumo, len(ids))
+	tabela, ok := dispositivoTabelas[fonte]
+	if !ok || len(ids) == 0 {
+		return resumos, nil
+	}
+
+	var rows []model.DispositivoResumo
+	err := r.db.Table(tabela.tabela).
+		Select("id::text AS id, COALESCE("+tabela.diploma+", '') AS diploma, COALESCE("+tabela.artigo+", '') AS artigo, COALESCE("+tabela.texto+", '') AS texto").
+		Where("deleted_at IS NULL AND id::text IN ?", ids).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		resumos[row.ID] = row
+	}
+	return resumos, nil
+}
+
+// ListByQuestao returns the links of a question that were not removed.
+func (r *QuestaoDispositivoRepository) ListByQuestao(questaoID int) ([]model.QuestaoDispositivo, error) {
+	var items []model.QuestaoDispositivo
+	err := r.db.Where("questao_id = ? AND removido = ?", questaoID, false).
+		Order("fonte, created_at").
+		Find(&items).Error
+	return items, err
+}
+
+// ReplaceDetected makes the parser links of a question match links: stale
+// ones are deleted and new ones inserted. Manual links and removed ones are
+// kept, so a link an admin discarded is not added back.
+func (r *QuestaoDispositivoRepository) ReplaceDetected(questaoID int, links []model.QuestaoDispositivo) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		touched, err := questaoDispositivoRefs(tx, questaoID)
+		if err != nil {
+			return err
+		}
+
+		stale := tx.Where("questao_id = ? AND origem = ? AND removido = ?", questaoID, model.QuestaoDispositivoOrigemParser, false)
+		if len(links) > 0 {
+			keep := make([][]interface{}, 0, len(links))
+			for _, link := range links {
+				keep = append(keep, []interface{}{link.Fonte, link.DispositivoID})
+				touched = append(touched, model.DispositivoRef{Fonte: link.Fonte, DispositivoID: link.DispositivoID})
+			}
+			stale = stale.Where("(fonte, dispositivo_id) NOT IN ?", keep)
+		}
+		if err := stale.Delete(&model.QuestaoDispositivo{}).Error; err != nil {
+			return err
+		}
+
+		if len(links) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		return refreshIncidencias(tx, touched)
+	})
+}
+
+// AddManual links a question to a row by hand. An existing link, removed or
+// not, becomes a manual one.
+func (r *QuestaoDispositivoRepository) AddManual(item *model.QuestaoDispositivo) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "questao_id"}, {Name: "fonte"}, {Name: "dispositivo_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"origem":     model.QuestaoDispositivoOrigemManual,
+					"removido":   false,
+					"referencia": item.Referencia,
+					"criado_por": item.CriadoPor,
+					"updated_at": item.UpdatedAt,
+				}),
+			},
+			clause.Returning{},
+		).Create(item).Error
+		if err != nil {
+			return err
+		}
+		return refreshIncidencias(tx, []model.DispositivoRef{{Fonte: item.Fonte, DispositivoID: item.DispositivoID}})
+	})
+}
+
+// Remove unlinks a row from a question. Parser links are only marked as
+// removed; manual ones are deleted. It reports whether a link was found.
+func (r *QuestaoDispositivoRepository) Remove(questaoID int, id uuid.UUID) (bool, error) {
+	var item model.QuestaoDispositivo
+	err := r.db.Where("id = ? AND questao_id = ? AND removido = ?", id, questaoID, false).First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, r.db.Transaction(func(tx *gorm.DB) error {
+		if item.Origem == model.QuestaoDispositivoOrigemManual {
+			err = tx.Delete(&item).Error
+		} else {
+			err = tx.Model(&item).Update("removido", true).Error
+		}
+		if err != nil {
+			return err
+		}
+		return refreshIncidencias(tx, []model.DispositivoRef{{Fonte: item.Fonte, DispositivoID: item.DispositivoID}})
+	})
+}
+
+// ListQuestoes returns one page of the questions linked to a row, by id.
+func (r *QuestaoDispositivoRepository) ListQuestoes(ref model.DispositivoRef, page, pageSize int) ([]model.Questao, int64, error) {
+	query := func() *gorm.DB {
+		return questaoQuery(r.db, nil).
+			Where("EXISTS (SELECT 1 FROM questao_dispositivos qd WHERE qd.questao_id = questoes.id AND qd.fonte = ? AND qd.dispositivo_id = ? AND NOT qd.removido)",
+				ref.Fonte, ref.DispositivoID)
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.Questao
+	err := query().
+		Order("id").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&items).Error
+	return items, total, err
+}
+
+// DispositivoExists reports whether ref points to a row of the vade-mecum.
+func (r *QuestaoDispositivoRepository) DispositivoExists(ref model.DispositivoRef) (bool, error) {
+	tabela, ok := dispositivoTabelas[ref.Fonte]
+	if !ok {
+		return false, nil
+	}
+	var count int64
+	err := r.db.Table(tabela.tabela).Where("deleted_at IS NULL AND id::text = ?", ref.DispositivoID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type QuestaoEstatisticaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestaoEstatisticaRepository(db *gorm.DB) *QuestaoEstatisticaRepository {
+	return &QuestaoEstatisticaRepository{db: db}
+}
+
+// questaoEstatisticaLockClass namespaces the advisory lock held while the
+// statistics are recomputed.
+const questaoEstatisticaLockClass = 24
+
+// questaoEstatisticaChunk bounds the questions recomputed per statement.
+const questaoEstatisticaChunk = 1000
+
+// questaoEstatisticaInsert recomputes the statistics from the first answer
+// of each user to each question; %s narrows the attempts read.
+const questaoEstatisticaInsert = `WITH primeiras AS (
+		SELECT DISTINCT ON (questao_id, user_id) questao_id, resposta, correct
+		FROM question_attempts
+		WHERE %s
+		ORDER BY questao_id, user_id, answered_at, created_at
+	), respostas AS (
+		SELECT questao_id, jsonb_object_agg(resposta, total) AS respostas
+		FROM (SELECT questao_id, resposta, COUNT(*) AS total FROM primeiras GROUP BY questao_id, resposta) r
+		GROUP BY questao_id
+	)
+	INSERT INTO questao_estatisticas (questao_id, resolucoes, acertos, respostas, atualizado_em)
+	SELECT p.questao_id, COUNT(*), COUNT(*) FILTER (WHERE p.correct), r.respostas, NOW()
+	FROM primeiras p
+	JOIN respostas r ON r.questao_id = p.questao_id
+	GROUP BY p.questao_id, r.respostas
+	ON CONFLICT (questao_id) DO UPDATE SET
+		resolucoes = EXCLUDED.resolucoes,
+		acertos = EXCLUDED.acertos,
+		respostas = EXCLUDED.respostas,
+		atualizado_em = EXCLUDED.atualizado_em`
+
+func (r *QuestaoEstatisticaRepository) GetByQuestao(questaoID int) (*model.QuestaoEstatistica, error) {
+	var item model.QuestaoEstatistica
+	if err := r.db.First(&item, "questao_id = ?", questaoID).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// TryLock takes the advisory lock that lets a single API instance recompute
+// the statistics at a time. It reports false when another instance holds
+// it; otherwise unlock must be called when the run ends. The lock belongs to
+// a connection set aside from the pool until then.
+func (r *QuestaoEstatisticaRepository) TryLock() (unlock func(), locked bool, err error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1::int, 0)", questaoEstatisticaLockClass).Scan(&locked); err != nil || !locked {
+		conn.Close()
+		return nil, false, err
+	}
+	return func() {
+		// Closing the connection would return it to the pool still holding
+		// the lock, so it is released explicitly first.
+		conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1::int, 0)", questaoEstatisticaLockClass)
+		conn.Close()
+	}, true, nil
+}
+
+// Changed returns the questions answered or regraded since the given time.
+func (r *QuestaoEstatisticaRepository) Changed(since time.Time) ([]int, error) {
+	var ids []int
+	err := r.db.Raw(`SELECT questao_id FROM question_attempts WHERE created_at >= ?
+		UNION
+		SELECT questao_id FROM questao_versoes WHERE tentativas_recorrigidas > 0 AND created_at >= ?`, since, since).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// Refresh recomputes the statistics of the given questions. Questions left
+// without answers lose theirs.
+func (r *QuestaoEstatisticaRepository) Refresh(ids []int) error {
+	for start := 0; start < len(ids); start += questaoEstatisticaChunk {
+		chunk := ids[start:min(start+questaoEstatisticaChunk, len(ids))]
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("questao_id IN ?", chunk).Delete(&model.QuestaoEstatistica{}).Error; err != nil {
+				return err
+			}
+			return tx.Exec(fmt.Sprintf(questaoEstatisticaInsert, "questao_id IN ?"), chunk).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshAll recomputes the statistics of every question and returns how
+// many have any.
+func (r *QuestaoEstatisticaRepository) RefreshAll() (int64, error) {
+	var total int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&model.QuestaoEstatistica{}).Error; err != nil {
+			return err
+		}
+		result := tx.Exec(fmt.Sprintf(questaoEstatisticaInsert, "TRUE"))
+		total = result.RowsAffected
+		return result.Error
+	})
+	return total, err
+}
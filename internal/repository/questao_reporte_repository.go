@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuestaoReporteRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestaoReporteRepository(db *gorm.DB) *QuestaoReporteRepository {
+	return &QuestaoReporteRepository{db: db}
+}
+
+func (r *QuestaoReporteRepository) Create(item *model.QuestaoReporte) error {
+	return r.db.Create(item).Error
+}
+
+func (r *QuestaoReporteRepository) HasPending(userID uuid.UUID, questaoID int) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.QuestaoReporte{}).
+		Where("user_id = ? AND questao_id = ? AND status = ?", userID, questaoID, model.QuestaoReportePendente).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Fila groups the reports matching filters by question, most reported first
+// and then oldest first.
+func (r *QuestaoReporteRepository) Fila(filters *model.QuestaoReporteFilters) ([]model.QuestaoReporteFilaItem, int64, error) {
+	base := func() *gorm.DB {
+		query := r.db.Model(&model.QuestaoReporte{}).Where("questao_reportes.status = ?", filters.Status)
+		if filters.Motivo != "" {
+			query = query.Where("questao_reportes.motivo = ?", filters.Motivo)
+		}
+		return query
+	}
+
+	var total int64
+	if err := r.db.Table("(?) AS fila", base().Select("questao_reportes.questao_id").Group("questao_reportes.questao_id")).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.QuestaoReporteFilaItem
+	err := base().
+		Select(`questao_reportes.questao_id, questoes.disciplina, questoes.assunto, questoes.banca, questoes.ano,
+			questoes.anulada, questoes.desatualizada, COUNT(*) AS total,
+			jsonb_agg(DISTINCT questao_reportes.motivo) AS motivos,
+			MIN(questao_reportes.created_at) AS primeiro_reporte, MAX(questao_reportes.created_at) AS ultimo_reporte`).
+		Joins("LEFT JOIN questoes ON questoes.id = questao_reportes.questao_id").
+		Group("questao_reportes.questao_id, questoes.disciplina, questoes.assunto, questoes.banca, questoes.ano, questoes.anulada, questoes.desatualizada").
+		Order("total DESC, primeiro_reporte").
+		Limit(filters.Limit).
+		Offset(filters.Offset).
+		Scan(&items).Error
+	return items, total, err
+}
+
+func (r *QuestaoReporteRepository) ListByQuestao(questaoID int) ([]model.QuestaoReporte, error) {
+	var items []model.QuestaoReporte
+	err := r.db.Where("questao_id = ?", questaoID).Order("created_at DESC").Find(&items).Error
+	return items, err
+}
+
+// Resolve closes the pending reports of a question, restricted to ids when
+// given, and returns the ones it changed, so concurrent moderators never
+// close a report twice.
+func (r *QuestaoReporteRepository) Resolve(questaoID int, ids []uuid.UUID, status string, resposta *string, adminID uuid.UUID, at time.Time) ([]model.QuestaoReporte, error) {
+	var items []model.QuestaoReporte
+	query := r.db.Model(&items).
+		Clauses(clause.Returning{}).
+		Where("questao_id = ? AND status = ?", questaoID, model.QuestaoReportePendente)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	err := query.Updates(map[string]interface{}{
+		"status":        status,
+		"resposta":      resposta,
+		"resolvido_por": adminID,
+		"resolvido_em":  at,
+		"updated_at":    at,
+	}).Error
+	return items, err
+}
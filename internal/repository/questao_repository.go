@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"strings"
+
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type QuestaoRepository struct {
@@ -17,14 +21,116 @@ func (r *QuestaoRepository) Create(item *model.Questao) error {
 	return r.db.Create(item).Error
 }
 
-func (r *QuestaoRepository) GetAll(filters *model.QuestaoFilters) ([]model.Questao, error) {
-	var items []model.Questao
-	query := r.buildQuestaoQuery(filters)
+// questaoSortColumns whitelists the sort options of the search. Difficulty
+// labels are ranked instead of sorted alphabetically.
+var questaoSortColumns = map[string]string{
+	"id":                    "id",
+	"ano":                   "ano",
+	"acertos_percentual":    "acertos_percentual",
+	"quantidade_resolucoes": "quantidade_resolucoes",
+	"dificuldade": `CASE LOWER(dificuldade)
+		WHEN 'muito fácil' THEN 1 WHEN 'muito facil' THEN 1
+		WHEN 'fácil' THEN 2 WHEN 'facil' THEN 2
+		WHEN 'média' THEN 3 WHEN 'media' THEN 3 WHEN 'médio' THEN 3 WHEN 'medio' THEN 3
+		WHEN 'difícil' THEN 4 WHEN 'dificil' THEN 4
+		WHEN 'muito difícil' THEN 5 WHEN 'muito dificil' THEN 5
+		END`,
+}
 
-	if err := query.Find(&items).Error; err != nil {
-		return nil, err
+func (r *QuestaoRepository) SupportsSort(sort string) bool {
+	_, ok := questaoSortColumns[sort]
+	return ok || sort == "relevancia"
+}
+
+// Search returns one page of the questions matching the filters together
+// with the total number of matches. Full-text searches are ranked and sorted
+// by relevance unless another sort is asked for.
+func (r *QuestaoRepository) Search(filters *model.QuestaoFilters) ([]model.QuestaoResult, int64, error) {
+	var total int64
+	if err := r.buildQuestaoQuery(filters).Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	return items, nil
+
+	// The explicit select keeps GORM from selecting the Rank and Trecho
+	// fields of QuestaoResult as table columns.
+	query := r.buildQuestaoQuery(filters).Select("questoes.*")
+	if filters.Q != "" {
+		query = query.Select("questoes.*, ts_rank_cd(search_vector, websearch_to_tsquery('pt_unaccent', ?)) AS rank", filters.Q)
+	}
+
+	var items []model.QuestaoResult
+	err := query.
+		Clauses(questaoOrder(filters)).
+		Offset((filters.Page - 1) * filters.PageSize).
+		Limit(filters.PageSize).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Q != "" && len(items) > 0 {
+		if err := r.fillSnippets(items, filters.Q); err != nil {
+			return nil, 0, err
+		}
+	}
+	return items, total, nil
+}
+
+// questaoOrder is the listing order of the search: relevance for full-text
+// searches unless another sort is asked for, then id.
+func questaoOrder(filters *model.QuestaoFilters) clause.OrderBy {
+	if filters.Q != "" && (filters.Sort == "" || filters.Sort == "relevancia") {
+		return clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank_cd(search_vector, websearch_to_tsquery('pt_unaccent', ?)) DESC, id",
+			Vars: []interface{}{filters.Q},
+		}}
+	}
+
+	column, ok := questaoSortColumns[filters.Sort]
+	if !ok {
+		column = questaoSortColumns["id"]
+	}
+	direction := "ASC"
+	if strings.EqualFold(filters.Order, "desc") {
+		direction = "DESC"
+	}
+	return clause.OrderBy{Expression: clause.Expr{SQL: column + " " + direction + " NULLS LAST, id"}}
+}
+
+// fillSnippets highlights the query terms in the statement, alternatives and
+// comment of the page's questions. It runs only over the page so ts_headline,
+// which is expensive, is not computed for every match.
+func (r *QuestaoRepository) fillSnippets(items []model.QuestaoResult, q string) error {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	var rows []struct {
+		ID     int
+		Trecho string
+	}
+	err := r.db.Model(&model.Questao{}).
+		Select(`id, ts_headline('pt_unaccent',
+			concat_ws(' … ', titulo, enunciado, alternativa_a, alternativa_b, alternativa_c, alternativa_d, alternativa_e, comentario),
+			websearch_to_tsquery('pt_unaccent', ?),
+			'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "') AS trecho`, q).
+		Where("id IN ?", ids).
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	snippets := make(map[int]string, len(rows))
+	for _, row := range rows {
+		snippets[row.ID] = row.Trecho
+	}
+	for i := range items {
+		if snippet, ok := snippets[items[i].ID]; ok {
+			items[i].Trecho = &snippet
+		}
+	}
+	return nil
 }
 
 func (r *QuestaoRepository) GetByID(id int) (*model.Questao, error) {
@@ -35,84 +141,311 @@ func (r *QuestaoRepository) GetByID(id int) (*model.Questao, error) {
 	return &item, nil
 }
 
-func (r *QuestaoRepository) Update(item *model.Questao) error {
-	return r.db.Save(item).Error
+func (r *QuestaoRepository) GetByIDs(ids []int) ([]model.Questao, error) {
+	var items []model.Questao
+	if len(ids) == 0 {
+		return items, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&items).Error
+	return items, err
+}
+
+// questaoRespondivel keeps the questions with a usable answer key.
+const questaoRespondivel = "(UPPER(TRIM(gabarito)) IN ('A', 'B', 'C', 'D', 'E', 'CERTO', 'ERRADO') OR numero_alternativa_correta BETWEEN 1 AND 5)"
+
+// SampleIDs picks up to limit random questions matching filters that userID
+// has never answered, skipping exclude. Questions without a usable answer key
+// are left out.
+func (r *QuestaoRepository) SampleIDs(filters *model.QuestaoFilters, userID uuid.UUID, exclude []int, limit int) ([]int, error) {
+	query := r.buildQuestaoQuery(filters).
+		Where(questaoRespondivel).
+		Where("NOT EXISTS (SELECT 1 FROM question_attempts qa WHERE qa.questao_id = questoes.id AND qa.user_id = ?)", userID)
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+
+	var ids []int
+	err := query.Order("random()").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// externalIDChunk bounds the IN lists of FindByExternalIDs.
+const externalIDChunk = 1000
+
+// FindByExternalIDs loads id, id_questao and id_questao_original of the
+// questions whose id_questao is in idQuestao or whose id_questao_original is
+// in idOriginal.
+func (r *QuestaoRepository) FindByExternalIDs(idQuestao, idOriginal []string) ([]model.Questao, error) {
+	lookups := []struct {
+		column string
+		values []string
+	}{
+		{"id_questao", idQuestao},
+		{"id_questao_original", idOriginal},
+	}
+
+	var items []model.Questao
+	for _, lookup := range lookups {
+		for start := 0; start < len(lookup.values); start += externalIDChunk {
+			var chunk []model.Questao
+			err := r.db.Select("id", "id_questao", "id_questao_original").
+				Where(lookup.column+" IN ?", lookup.values[start:min(start+externalIDChunk, len(lookup.values))]).
+				Find(&chunk).Error
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, chunk...)
+		}
+	}
+	return items, nil
+}
+
+// EachBatch walks the questions matching filters in id order, size at a time.
+func (r *QuestaoRepository) EachBatch(filters *model.QuestaoFilters, size int, fn func([]model.Questao) error) error {
+	var batch []model.Questao
+	return r.buildQuestaoQuery(filters).FindInBatches(&batch, size, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
 }
 
+// UpdateVersioned locks the question and passes it to update, which applies
+// the edit and returns the version to record, or nil when nothing changed.
+// The question and the version, numbered after the last one, are saved in
+// the same transaction. With the recorrecao update returns, the attempts at
+// the question are graded again and the version records how many changed.
+func (r *QuestaoRepository) UpdateVersioned(id int, update func(item *model.Questao) (*model.QuestaoVersao, *model.QuestaoRecorrecao, error)) (*model.Questao, error) {
+	var item model.Questao
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, "id = ?", id).Error; err != nil {
+			return err
+		}
+		versao, recorrecao, err := update(&item)
+		if err != nil || versao == nil {
+			return err
+		}
+
+		if err := tx.Model(&model.QuestaoVersao{}).
+			Where("questao_id = ?", item.ID).
+			Select("COALESCE(MAX(versao), 0) + 1").
+			Scan(&versao.Versao).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(&item).Error; err != nil {
+			return err
+		}
+		if recorrecao != nil {
+			count, err := regradeAttempts(tx, item.ID, recorrecao)
+			if err != nil {
+				return err
+			}
+			versao.TentativasRecorrigidas = count
+		}
+
+		versao.QuestaoID = item.ID
+		if err := tx.Create(versao).Error; err != nil {
+			return err
+		}
+
+		// The banca, cargo or year counted for the cited articles may have
+		// changed.
+		refs, err := questaoDispositivoRefs(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		return refreshIncidencias(tx, refs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *QuestaoRepository) ListVersoes(questaoID int) ([]model.QuestaoVersao, error) {
+	var items []model.QuestaoVersao
+	err := r.db.Where("questao_id = ?", questaoID).Order("versao DESC").Find(&items).Error
+	return items, err
+}
+
+// Delete removes a question with its vade-mecum links and recounts the
+// articles it cited.
 func (r *QuestaoRepository) Delete(id int) error {
-	return r.db.Delete(&model.Questao{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		refs, err := questaoDispositivoRefs(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.QuestaoDispositivo{}, "questao_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.Questao{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return refreshIncidencias(tx, refs)
+	})
 }
 
 func (r *QuestaoRepository) Count(filters *model.QuestaoFilters) (int64, error) {
 	var count int64
-	err := r.buildQuestaoQuery(filters).
-		Where("erro_captura IS NULL OR erro_captura = ?", false).
-		Count(&count).Error
+	err := r.buildQuestaoQuery(filters).Count(&count).Error
 	return count, err
 }
 
-func (r *QuestaoRepository) GetFilterOptions() (*model.QuestaoFiltersResponse, error) {
-	response := &model.QuestaoFiltersResponse{}
-
-	loadDistinct := func(column string, dest *[]string) error {
-		return r.db.
-			Model(&model.Questao{}).
-			Where(column+" IS NOT NULL AND "+column+" <> ''").
-			Distinct().
-			Order(column).
-			Pluck(column, dest).Error
-	}
+// questaoFacets lists the facet columns; clear drops the facet's own filter
+// so its other values stay reachable.
+var questaoFacets = []struct {
+	column string
+	clear  func(*model.QuestaoFilters)
+	dest   func(*model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue
+}{
+	{"disciplina", func(f *model.QuestaoFilters) { f.Disciplina = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Disciplina }},
+	{"assunto", func(f *model.QuestaoFilters) { f.Assunto = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Assunto }},
+	{"banca", func(f *model.QuestaoFilters) { f.Banca = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Banca }},
+	{"orgao", func(f *model.QuestaoFilters) { f.Orgao = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Orgao }},
+	{"cargo", func(f *model.QuestaoFilters) { f.Cargo = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Cargo }},
+	{"concurso", func(f *model.QuestaoFilters) { f.Concurso = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Concurso }},
+	{"area_conhecimento", func(f *model.QuestaoFilters) { f.AreaConhecimento = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.AreaConhecimento }},
+	{"tipo_questao", func(f *model.QuestaoFilters) { f.TipoQuestao = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.TipoQuestao }},
+	{"nivel", func(f *model.QuestaoFilters) { f.Nivel = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Nivel }},
+	{"dificuldade", func(f *model.QuestaoFilters) { f.Dificuldade = nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Dificuldade }},
+	{"ano", func(f *model.QuestaoFilters) { f.AnoMin, f.AnoMax = nil, nil }, func(r *model.QuestaoFiltersResponse) *[]model.QuestaoFacetValue { return &r.Ano }},
+}
 
-	if err := loadDistinct("disciplina", &response.Disciplina); err != nil {
-		return nil, err
+// GetFacets counts the questions per filter value. With useView the counts
+// come from the questao_facets materialized view, unless the filters need
+// columns it does not have (q and acertos ranges), which fall back to
+// questoes.
+func (r *QuestaoRepository) GetFacets(filters *model.QuestaoFilters, useView bool) (*model.QuestaoFiltersResponse, error) {
+	if filters == nil {
+		filters = &model.QuestaoFilters{}
 	}
-	if err := loadDistinct("assunto", &response.Assunto); err != nil {
-		return nil, err
+	if filters.Q != "" || filters.AcertosMin != nil || filters.AcertosMax != nil {
+		useView = false
 	}
-	if err := loadDistinct("banca", &response.Banca); err != nil {
-		return nil, err
-	}
-	if err := loadDistinct("orgao", &response.Orgao); err != nil {
-		return nil, err
+
+	base := func(f *model.QuestaoFilters) *gorm.DB {
+		if useView {
+			return applyQuestaoFilters(r.db.Table("questao_facets"), f)
+		}
+		return r.buildQuestaoQuery(f)
 	}
-	if err := loadDistinct("cargo", &response.Cargo); err != nil {
-		return nil, err
+	countExpr := "COUNT(*)"
+	if useView {
+		countExpr = "COALESCE(SUM(total), 0)"
 	}
-	if err := loadDistinct("concurso", &response.Concurso); err != nil {
+
+	response := &model.QuestaoFiltersResponse{}
+	if err := base(filters).Select(countExpr).Scan(&response.Total).Error; err != nil {
 		return nil, err
 	}
-	if err := loadDistinct("area_conhecimento", &response.AreaConhecimento); err != nil {
-		return nil, err
+
+	for _, facet := range questaoFacets {
+		scoped := *filters
+		facet.clear(&scoped)
+
+		order := facet.column
+		if facet.column == "ano" {
+			order += " DESC"
+		}
+		values := []model.QuestaoFacetValue{}
+		err := base(&scoped).
+			Select("CAST(" + facet.column + " AS text) AS valor, " + countExpr + " AS total").
+			Where(facet.column + " IS NOT NULL AND CAST(" + facet.column + " AS text) <> ''").
+			Group(facet.column).
+			Order(order).
+			Scan(&values).Error
+		if err != nil {
+			return nil, err
+		}
+		*facet.dest(response) = values
 	}
 
 	return response, nil
 }
 
+// FacetViewReady reports whether questao_facets exists and has been populated.
+func (r *QuestaoRepository) FacetViewReady() (bool, error) {
+	var ready bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM pg_matviews WHERE schemaname = current_schema() AND matviewname = 'questao_facets' AND ispopulated)").
+		Scan(&ready).Error
+	return ready, err
+}
+
+// RefreshFacetView recomputes questao_facets without blocking readers.
+func (r *QuestaoRepository) RefreshFacetView() error {
+	return r.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY questao_facets").Error
+}
+
+// buildQuestaoQuery applies the filters. Questions whose capture failed are
+// never listed nor counted.
 func (r *QuestaoRepository) buildQuestaoQuery(filters *model.QuestaoFilters) *gorm.DB {
-	query := r.db.Model(&model.Questao{})
+	return questaoQuery(r.db, filters)
+}
+
+func questaoQuery(db *gorm.DB, filters *model.QuestaoFilters) *gorm.DB {
+	query := db.Model(&model.Questao{}).Where("erro_captura IS NULL OR erro_captura = ?", false)
+	return applyQuestaoFilters(query, filters)
+}
+
+// applyQuestaoFilters adds the filter conditions to query, which may target
+// questoes or the questao_facets view.
+func applyQuestaoFilters(query *gorm.DB, filters *model.QuestaoFilters) *gorm.DB {
 	if filters == nil {
 		return query
 	}
-	if filters.Disciplina != nil {
-		query = query.Where("disciplina = ?", *filters.Disciplina)
+
+	if filters.Q != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('pt_unaccent', ?)", filters.Q)
 	}
-	if filters.Assunto != nil {
-		query = query.Where("assunto = ?", *filters.Assunto)
+
+	inFilters := []struct {
+		column string
+		values []string
+	}{
+		{"disciplina", filters.Disciplina},
+		{"assunto", filters.Assunto},
+		{"banca", filters.Banca},
+		{"orgao", filters.Orgao},
+		{"cargo", filters.Cargo},
+		{"concurso", filters.Concurso},
+		{"area_conhecimento", filters.AreaConhecimento},
+		{"tipo_questao", filters.TipoQuestao},
+		{"nivel", filters.Nivel},
+		{"dificuldade", filters.Dificuldade},
 	}
-	if filters.Banca != nil {
-		query = query.Where("banca = ?", *filters.Banca)
+	for _, f := range inFilters {
+		if len(f.values) > 0 {
+			query = query.Where(f.column+" IN ?", f.values)
+		}
 	}
-	if filters.Orgao != nil {
-		query = query.Where("orgao = ?", *filters.Orgao)
+
+	if filters.AnoMin != nil {
+		query = query.Where("ano >= ?", *filters.AnoMin)
+	}
+	if filters.AnoMax != nil {
+		query = query.Where("ano <= ?", *filters.AnoMax)
 	}
-	if filters.Cargo != nil {
-		query = query.Where("cargo = ?", *filters.Cargo)
+	if filters.AcertosMin != nil {
+		query = query.Where("acertos_percentual >= ?", *filters.AcertosMin)
 	}
-	if filters.Concurso != nil {
-		query = query.Where("concurso = ?", *filters.Concurso)
+	if filters.AcertosMax != nil {
+		query = query.Where("acertos_percentual <= ?", *filters.AcertosMax)
 	}
-	if filters.AreaConhecimento != nil {
-		query = query.Where("area_conhecimento = ?", *filters.AreaConhecimento)
+
+	// A missing flag means false.
+	flags := []struct {
+		column string
+		value  *bool
+	}{
+		{"anulada", filters.Anulada},
+		{"desatualizada", filters.Desatualizada},
+		{"questao_oculta", filters.QuestaoOculta},
 	}
+	for _, f := range flags {
+		if f.value != nil {
+			query = query.Where("COALESCE("+f.column+", false) = ?", *f.value)
+		}
+	}
+
 	return query
 }
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type QuestionAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionAttemptRepository(db *gorm.DB) *QuestionAttemptRepository {
+	return &QuestionAttemptRepository{db: db}
+}
+
+// Record stores the attempt and recomputes the user's performance for that day
+// in the same transaction.
+func (r *QuestionAttemptRepository) Record(attempt *model.QuestionAttempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		return refreshPerformances(tx, []uuid.UUID{attempt.ID})
+	})
+}
+
+func (r *QuestionAttemptRepository) ListByUserAndQuestao(userID uuid.UUID, questaoID, limit int) ([]model.QuestionAttempt, error) {
+	var items []model.QuestionAttempt
+	err := r.db.Where("user_id = ? AND questao_id = ?", userID, questaoID).
+		Order("answered_at DESC").
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
+
+// performanceLockClass namespaces the advisory locks taken on (user, day)
+// pairs by refreshPerformances.
+const performanceLockClass = 14
+
+// refreshPerformances rebuilds the daily user_performances rows of every
+// (user, day) touched by the given attempts. Days follow the database session
+// time zone (DB_TIMEZONE). Each pair is locked until the transaction ends,
+// in a fixed order, so concurrent answers of the same user and day recount
+// one after the other and the later one sees the attempt of the earlier.
+func refreshPerformances(tx *gorm.DB, attemptIDs []uuid.UUID) error {
+	if err := tx.Exec(`
+		SELECT pg_advisory_xact_lock(?, hashtext(k.user_id::text || ':' || k.day::text))
+		FROM (
+			SELECT DISTINCT user_id, answered_at::date AS day
+			FROM question_attempts WHERE id IN ?
+			ORDER BY 1, 2
+		) k`, performanceLockClass, attemptIDs).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`
+		INSERT INTO user_performances
+			(id, user_id, day, total_questions, correct_questions, wrong_questions, accuracy_percent, recorded_at, created_at, updated_at)
+		SELECT gen_random_uuid(), a.user_id, a.answered_at::date,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE a.correct),
+			COUNT(*) FILTER (WHERE NOT a.correct),
+			ROUND(100.0 * COUNT(*) FILTER (WHERE a.correct) / COUNT(*), 2),
+			MAX(a.answered_at), NOW(), NOW()
+		FROM question_attempts a
+		WHERE (a.user_id, a.answered_at::date) IN (
+			SELECT user_id, answered_at::date FROM question_attempts WHERE id IN ?
+		)
+		GROUP BY a.user_id, a.answered_at::date
+		ON CONFLICT (user_id, day) DO UPDATE SET
+			total_questions = EXCLUDED.total_questions,
+			correct_questions = EXCLUDED.correct_questions,
+			wrong_questions = EXCLUDED.wrong_questions,
+			accuracy_percent = EXCLUDED.accuracy_percent,
+			recorded_at = EXCLUDED.recorded_at,
+			updated_at = NOW()`, attemptIDs).Error
+}
+
+// attemptChunk bounds the IN lists built from attempt IDs.
+const attemptChunk = 1000
+
+// regradeAttempts grades every attempt at the question again with recorrecao,
+// then recomputes the daily performances and the simulado scores of the
+// attempts that changed. It returns how many changed. Graded discursive
+// answers keep the corretor's result.
+func regradeAttempts(tx *gorm.DB, questaoID int, recorrecao *model.QuestaoRecorrecao) (int, error) {
+	rows, err := tx.Raw(`
+		UPDATE question_attempts SET gabarito = g.gabarito, correct = g.correct
+		FROM (
+			SELECT id, COALESCE(NULLIF(?, ''), gabarito) AS gabarito,
+				? OR resposta = COALESCE(NULLIF(?, ''), gabarito) AS correct
+			FROM question_attempts
+			WHERE questao_id = ? AND resposta <> ?
+		) g
+		WHERE question_attempts.id = g.id
+			AND (question_attempts.gabarito <> g.gabarito OR question_attempts.correct <> g.correct)
+		RETURNING question_attempts.id`,
+		recorrecao.Gabarito, recorrecao.Anulada, recorrecao.Gabarito, questaoID, model.RespostaDiscursivaTentativa).Rows()
+	if err != nil {
+		return 0, err
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(ids); start += attemptChunk {
+		chunk := ids[start:min(start+attemptChunk, len(ids))]
+		if err := refreshPerformances(tx, chunk); err != nil {
+			return 0, err
+		}
+		if err := tx.Exec(`
+			UPDATE simulado_questoes SET correct = a.correct
+			FROM question_attempts a
+			WHERE simulado_questoes.attempt_id = a.id AND a.id IN ?`, chunk).Error; err != nil {
+			return 0, err
+		}
+		if err := tx.Exec(`
+			UPDATE simulados SET
+				acertos = (SELECT COUNT(*) FROM simulado_questoes sq WHERE sq.simulado_id = simulados.id AND sq.correct),
+				updated_at = NOW()
+			WHERE id IN (SELECT simulado_id FROM simulado_questoes WHERE attempt_id IN ?)`, chunk).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
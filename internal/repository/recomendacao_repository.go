@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecomendacaoRepository struct {
+	db *gorm.DB
+}
+
+func NewRecomendacaoRepository(db *gorm.DB) *RecomendacaoRepository {
+	return &RecomendacaoRepository{db: db}
+}
+
+// questaoAcertoEsperado is the accuracy expected on a question: the share of
+// right answers it had at the source or, without it, one derived from the
+// dificuldade label (muito fácil 80% down to muito difícil 20%).
+var questaoAcertoEsperado = "COALESCE(acertos_percentual, 95 - 15 * (" + questaoSortColumns["dificuldade"] + "), 50)"
+
+// GetPerfil returns the user's profile, or an empty one when none was saved.
+func (r *RecomendacaoRepository) GetPerfil(userID uuid.UUID) (*model.PerfilEstudo, error) {
+	var perfil model.PerfilEstudo
+	err := r.db.First(&perfil, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		perfil = model.DefaultPerfilEstudo(userID)
+		return &perfil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &perfil, nil
+}
+
+func (r *RecomendacaoRepository) SavePerfil(perfil *model.PerfilEstudo) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bancas", "concursos", "cargos", "orgaos", "updated_at"}),
+	}).Create(perfil).Error
+}
+
+// Desempenho groups the user's answers since the given time by disciplina
+// and assunto, optionally within disciplinas. Questions without disciplina
+// are left out.
+func (r *RecomendacaoRepository) Desempenho(userID uuid.UUID, since time.Time, disciplinas []string) ([]model.AssuntoDesempenho, error) {
+	query := r.db.Table("question_attempts qa").
+		Select("q.disciplina, COALESCE(q.assunto, '') AS assunto, COUNT(*) AS tentativas, "+
+			"COUNT(*) FILTER (WHERE qa.correct) AS acertos, AVG("+questaoAcertoEsperado+") AS acerto_esperado").
+		Joins("JOIN questoes q ON q.id = qa.questao_id").
+		Where("qa.user_id = ? AND qa.answered_at >= ?", userID, since).
+		Where("COALESCE(q.disciplina, '') <> ''")
+	if len(disciplinas) > 0 {
+		query = query.Where("q.disciplina IN ?", disciplinas)
+	}
+
+	var items []model.AssuntoDesempenho
+	err := query.Group("1, 2").Scan(&items).Error
+	return items, err
+}
+
+// Candidatas picks up to limit questions matching filters that userID did
+// not answer since recentes, skipping exclude. Questions never answered come
+// first, then those whose expected accuracy is closest to alvo.
+func (r *RecomendacaoRepository) Candidatas(filters *model.QuestaoFilters, userID uuid.UUID, recentes time.Time, exclude []int, alvo float64, limit int) ([]model.Questao, error) {
+	query := questaoQuery(r.db, filters).
+		Where(questaoRespondivel).
+		Where("NOT EXISTS (SELECT 1 FROM question_attempts qa WHERE qa.questao_id = questoes.id AND qa.user_id = ? AND qa.answered_at >= ?)", userID, recentes)
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+
+	var items []model.Questao
+	err := query.
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "EXISTS (SELECT 1 FROM question_attempts qa WHERE qa.questao_id = questoes.id AND qa.user_id = ?), ABS(" + questaoAcertoEsperado + " - ?), random()",
+			Vars: []interface{}{userID, alvo},
+		}}).
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RespostaDiscursivaRepository struct {
+	db *gorm.DB
+}
+
+func NewRespostaDiscursivaRepository(db *gorm.DB) *RespostaDiscursivaRepository {
+	return &RespostaDiscursivaRepository{db: db}
+}
+
+func (r *RespostaDiscursivaRepository) Create(item *model.RespostaDiscursiva) error {
+	return r.db.Create(item).Error
+}
+
+// HasOpen tells whether the user has an answer to the question not graded yet.
+func (r *RespostaDiscursivaRepository) HasOpen(userID uuid.UUID, questaoID int) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.RespostaDiscursiva{}).
+		Where("user_id = ? AND questao_id = ? AND status <> ?", userID, questaoID, model.RespostaDiscursivaCorrigida).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *RespostaDiscursivaRepository) GetByID(id uuid.UUID) (*model.RespostaDiscursiva, error) {
+	var item model.RespostaDiscursiva
+	if err := r.db.First(&item, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListByUser returns the user's answers matching filters, newest first.
+func (r *RespostaDiscursivaRepository) ListByUser(userID uuid.UUID, filters *model.RespostaDiscursivaFilters) ([]model.RespostaDiscursiva, int64, error) {
+	base := func() *gorm.DB {
+		query := r.applyFilters(r.db.Model(&model.RespostaDiscursiva{}).Where("user_id = ?", userID), filters)
+		if filters.Status != "" {
+			query = query.Where("status = ?", filters.Status)
+		}
+		return query
+	}
+
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var items []model.RespostaDiscursiva
+	err := base().Order("created_at DESC").Limit(filters.Limit).Offset(filters.Offset).Find(&items).Error
+	return items, total, err
+}
+
+// Fila is the correction queue of corretorID, which never includes the
+// corretor's own answers. pendente lists the answers anyone may reserve,
+// including reservations made before expiradas;
+// em_correcao and corrigida list the ones reserved or graded by corretorID;
+// an empty status lists both pendente and the corretor's reservations. Open
+// answers come oldest first and graded ones newest first.
+func (r *RespostaDiscursivaRepository) Fila(corretorID uuid.UUID, expiradas time.Time, filters *model.RespostaDiscursivaFilters) ([]model.RespostaDiscursiva, int64, error) {
+	base := func() *gorm.DB {
+		query := r.applyFilters(r.db.Model(&model.RespostaDiscursiva{}).Where("user_id <> ?", corretorID), filters)
+		disponivel := r.db.Where("status = ?", model.RespostaDiscursivaPendente).
+			Or("status = ? AND reservada_em < ?", model.RespostaDiscursivaEmCorrecao, expiradas)
+		switch filters.Status {
+		case model.RespostaDiscursivaPendente:
+			return query.Where(disponivel)
+		case model.RespostaDiscursivaEmCorrecao, model.RespostaDiscursivaCorrigida:
+			return query.Where("status = ? AND corretor_id = ?", filters.Status, corretorID)
+		}
+		return query.Where(disponivel.Or("status = ? AND corretor_id = ?", model.RespostaDiscursivaEmCorrecao, corretorID))
+	}
+	order := "created_at"
+	if filters.Status == model.RespostaDiscursivaCorrigida {
+		order = "corrigida_em DESC"
+	}
+
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var items []model.RespostaDiscursiva
+	err := base().Order(order).Limit(filters.Limit).Offset(filters.Offset).Find(&items).Error
+	return items, total, err
+}
+
+func (r *RespostaDiscursivaRepository) applyFilters(query *gorm.DB, filters *model.RespostaDiscursivaFilters) *gorm.DB {
+	if filters.QuestaoID != nil {
+		query = query.Where("questao_id = ?", *filters.QuestaoID)
+	}
+	if len(filters.Disciplina) > 0 {
+		query = query.Where("questao_id IN (?)", r.db.Model(&model.Questao{}).Select("id").Where("disciplina IN ?", filters.Disciplina))
+	}
+	return query
+}
+
+// Reservar assigns the answer to corretorID if it is pendente, its
+// reservation expired before expiradas or it is already reserved by
+// corretorID, unless it is the corretor's own answer. It returns nil when
+// the answer could not be reserved.
+func (r *RespostaDiscursivaRepository) Reservar(id, corretorID uuid.UUID, at, expiradas time.Time) (*model.RespostaDiscursiva, error) {
+	var items []model.RespostaDiscursiva
+	err := r.db.Model(&items).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND user_id <> ?", id, corretorID).
+		Where(r.db.Where("status = ?", model.RespostaDiscursivaPendente).
+			Or("status = ? AND (corretor_id = ? OR reservada_em < ?)", model.RespostaDiscursivaEmCorrecao, corretorID, expiradas)).
+		Updates(map[string]interface{}{
+			"status":       model.RespostaDiscursivaEmCorrecao,
+			"corretor_id":  corretorID,
+			"reservada_em": at,
+			"updated_at":   at,
+		}).Error
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// Liberar puts an answer reserved by corretorID back in the queue. It reports
+// false when the answer was not reserved by corretorID.
+func (r *RespostaDiscursivaRepository) Liberar(id, corretorID uuid.UUID) (bool, error) {
+	result := r.db.Model(&model.RespostaDiscursiva{}).
+		Where("id = ? AND status = ? AND corretor_id = ?", id, model.RespostaDiscursivaEmCorrecao, corretorID).
+		Updates(map[string]interface{}{
+			"status":       model.RespostaDiscursivaPendente,
+			"corretor_id":  nil,
+			"reservada_em": nil,
+			"updated_at":   time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Corrigir stores the grade of an answer reserved by corretorID with the
+// attempt it counts as, and recomputes the user's performance for the day of
+// the attempt, in one transaction. It reports false when the answer is no
+// longer reserved by corretorID.
+func (r *RespostaDiscursivaRepository) Corrigir(item *model.RespostaDiscursiva, corretorID uuid.UUID, attempt *model.QuestionAttempt) (bool, error) {
+	corrigida := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.RespostaDiscursiva
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ?", item.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != model.RespostaDiscursivaEmCorrecao || current.CorretorID == nil || *current.CorretorID != corretorID {
+			return nil
+		}
+
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		item.AttemptID = &attempt.ID
+		if err := tx.Model(&model.RespostaDiscursiva{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"status":       item.Status,
+			"rubrica":      item.Rubrica,
+			"nota":         item.Nota,
+			"comentario":   item.Comentario,
+			"attempt_id":   item.AttemptID,
+			"corrigida_em": item.CorrigidaEm,
+			"updated_at":   item.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := refreshPerformances(tx, []uuid.UUID{attempt.ID}); err != nil {
+			return err
+		}
+		corrigida = true
+		return nil
+	})
+	return corrigida, err
+}
+
+// Desempenho groups the user's graded answers sent between start and end by
+// disciplina. An answer is aprovada when its attempt counts as correct.
+func (r *RespostaDiscursivaRepository) Desempenho(userID uuid.UUID, start, end *time.Time) ([]model.DesempenhoDiscursivo, error) {
+	query := r.db.Table("respostas_discursivas r").
+		Select("COALESCE(q.disciplina, '') AS disciplina, COUNT(*) AS corrigidas, "+
+			"COUNT(*) FILTER (WHERE a.correct) AS aprovadas, AVG(100 * r.nota / r.nota_maxima) AS percentual_medio").
+		Joins("JOIN question_attempts a ON a.id = r.attempt_id").
+		Joins("LEFT JOIN questoes q ON q.id = r.questao_id").
+		Where("r.user_id = ? AND r.status = ? AND r.nota_maxima > 0", userID, model.RespostaDiscursivaCorrigida)
+	if start != nil {
+		query = query.Where("r.created_at >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where("r.created_at <= ?", *end)
+	}
+
+	var items []model.DesempenhoDiscursivo
+	err := query.Group("q.disciplina").Order("1").Scan(&items).Error
+	return items, err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevisaoRepository struct {
+	db *gorm.DB
+}
+
+func NewRevisaoRepository(db *gorm.DB) *RevisaoRepository {
+	return &RevisaoRepository{db: db}
+}
+
+// GetConfig returns the user's settings, or the defaults when none were saved.
+func (r *RevisaoRepository) GetConfig(userID uuid.UUID) (*model.RevisaoConfig, error) {
+	var config model.RevisaoConfig
+	err := r.db.First(&config, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		config = model.DefaultRevisaoConfig(userID)
+		return &config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (r *RevisaoRepository) SaveConfig(config *model.RevisaoConfig) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"limite_diario", "primeiro_intervalo_dias", "segundo_intervalo_dias", "intervalo_maximo_dias", "updated_at"}),
+	}).Create(config).Error
+}
+
+// UpdateCard locks the user's card for the question and passes it to update,
+// with nil when there is none yet. The card update returns is saved; nil
+// leaves things as they are.
+func (r *RevisaoRepository) UpdateCard(userID uuid.UUID, questaoID int, update func(card *model.RevisaoCard) *model.RevisaoCard) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.RevisaoCard
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "user_id = ? AND questao_id = ?", userID, questaoID).Error
+		var card *model.RevisaoCard
+		switch {
+		case err == nil:
+			card = &current
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		next := update(card)
+		if next == nil {
+			return nil
+		}
+		next.UserID, next.QuestaoID = userID, questaoID
+		if card == nil {
+			return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(next).Error
+		}
+		return tx.Save(next).Error
+	})
+}
+
+// ListDue returns up to limit cards due before the given time, oldest first.
+func (r *RevisaoRepository) ListDue(userID uuid.UUID, before time.Time, limit int) ([]model.RevisaoCard, error) {
+	var items []model.RevisaoCard
+	err := r.db.Where("user_id = ? AND due_at < ?", userID, before).
+		Order("due_at").
+		Order("questao_id").
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
+
+func (r *RevisaoRepository) CountDue(userID uuid.UUID, before time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.RevisaoCard{}).Where("user_id = ? AND due_at < ?", userID, before).Count(&count).Error
+	return count, err
+}
+
+func (r *RevisaoRepository) CountReviewedSince(userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.RevisaoCard{}).Where("user_id = ? AND last_reviewed_at >= ?", userID, since).Count(&count).Error
+	return count, err
+}
+
+func (r *RevisaoRepository) DeleteCard(userID uuid.UUID, questaoID int) (bool, error) {
+	result := r.db.Where("user_id = ? AND questao_id = ?", userID, questaoID).Delete(&model.RevisaoCard{})
+	return result.RowsAffected > 0, result.Error
+}
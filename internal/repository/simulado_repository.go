@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SimuladoRepository struct {
+	db *gorm.DB
+}
+
+func NewSimuladoRepository(db *gorm.DB) *SimuladoRepository {
+	return &SimuladoRepository{db: db}
+}
+
+// Create stores the simulado together with its questions.
+func (r *SimuladoRepository) Create(simulado *model.Simulado) error {
+	return r.db.Create(simulado).Error
+}
+
+func (r *SimuladoRepository) GetByIDAndUser(id, userID uuid.UUID) (*model.Simulado, error) {
+	var simulado model.Simulado
+	err := r.db.
+		Preload("Questoes", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		First(&simulado, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &simulado, nil
+}
+
+func (r *SimuladoRepository) ListByUser(userID uuid.UUID) ([]model.Simulado, error) {
+	var items []model.Simulado
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&items).Error
+	return items, err
+}
+
+// SaveAnswer sets the answer of one question while the simulado is still in
+// progress and before its deadline. It holds a share lock on the simulado, so
+// it either lands before Finish grades or sees the simulado finished. It
+// reports false when nothing was updated.
+func (r *SimuladoRepository) SaveAnswer(simuladoID uuid.UUID, ordem int, resposta string, at time.Time) (bool, error) {
+	saved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Simulado
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			First(&current, "id = ?", simuladoID).Error; err != nil {
+			return err
+		}
+		if current.Status != model.SimuladoEmAndamento || !at.Before(current.ExpiresAt) {
+			return nil
+		}
+
+		result := tx.Model(&model.SimuladoQuestao{}).
+			Where("simulado_id = ? AND ordem = ?", simuladoID, ordem).
+			Updates(map[string]interface{}{"resposta": resposta, "answered_at": at})
+		saved = result.RowsAffected > 0
+		return result.Error
+	})
+	return saved, err
+}
+
+// Finish locks the simulado, reloads its questions and passes it to grade,
+// which sets the results and returns the attempts to record. The attempts
+// and the results are stored in the same transaction, so every answer saved
+// before the lock is graded. It reports false, leaving simulado as it was,
+// when the simulado was already finished.
+func (r *SimuladoRepository) Finish(simulado *model.Simulado, grade func(simulado *model.Simulado) []model.QuestionAttempt) (bool, error) {
+	finished := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Simulado
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ?", simulado.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != model.SimuladoEmAndamento {
+			return nil
+		}
+		if err := tx.Where("simulado_id = ?", current.ID).Order("ordem").Find(&current.Questoes).Error; err != nil {
+			return err
+		}
+
+		attempts := grade(&current)
+
+		if len(attempts) > 0 {
+			if err := tx.Create(&attempts).Error; err != nil {
+				return err
+			}
+		}
+		attemptIDs := make(map[int]uuid.UUID, len(attempts))
+		ids := make([]uuid.UUID, 0, len(attempts))
+		for _, attempt := range attempts {
+			attemptIDs[attempt.QuestaoID] = attempt.ID
+			ids = append(ids, attempt.ID)
+		}
+
+		for i := range current.Questoes {
+			q := &current.Questoes[i]
+			if id, ok := attemptIDs[q.QuestaoID]; ok {
+				q.AttemptID = &id
+			}
+			if err := tx.Model(&model.SimuladoQuestao{}).
+				Where("simulado_id = ? AND ordem = ?", current.ID, q.Ordem).
+				Updates(map[string]interface{}{"correct": q.Correct, "attempt_id": q.AttemptID}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&model.Simulado{}).Where("id = ?", current.ID).Updates(map[string]interface{}{
+			"status":      current.Status,
+			"acertos":     current.Acertos,
+			"finished_at": current.FinishedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if len(ids) > 0 {
+			if err := refreshPerformances(tx, ids); err != nil {
+				return err
+			}
+		}
+		*simulado = current
+		finished = true
+		return nil
+	})
+	return finished, err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+func (r *UserIdentityRepository) Create(item *model.UserIdentity) error {
+	return r.db.Create(item).Error
+}
+
+func (r *UserIdentityRepository) GetByProviderUserID(provider, providerUserID string) (*model.UserIdentity, error) {
+	var item model.UserIdentity
+	if err := r.db.First(&item, "provider = ? AND provider_user_id = ?", provider, providerUserID).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *UserIdentityRepository) GetByUserAndProvider(userID uuid.UUID, provider string) (*model.UserIdentity, error) {
+	var item model.UserIdentity
+	if err := r.db.First(&item, "user_id = ? AND provider = ?", userID, provider).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *UserIdentityRepository) GetByUser(userID uuid.UUID) ([]model.UserIdentity, error) {
+	var items []model.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *UserIdentityRepository) CountByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.UserIdentity{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *UserIdentityRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&model.UserIdentity{}, "id = ?", id).Error
+}
+
+func (r *UserIdentityRepository) DeleteByUser(userID uuid.UUID) error {
+	return r.db.Where("user_id = ?", userID).Delete(&model.UserIdentity{}).Error
+}
@@ -16,10 +16,6 @@ func NewUserPerformanceRepository(db *gorm.DB) *UserPerformanceRepository {
 	return &UserPerformanceRepository{db: db}
 }
 
-func (r *UserPerformanceRepository) Create(item *model.UserPerformance) error {
-	return r.db.Create(item).Error
-}
-
 func (r *UserPerformanceRepository) GetByUser(userID uuid.UUID, startDate, endDate *time.Time) ([]model.UserPerformance, error) {
 	var items []model.UserPerformance
 	query := r.db.Where("user_id = ?", userID)
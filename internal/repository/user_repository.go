@@ -1,9 +1,12 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -36,20 +39,69 @@ func (r *UserRepository) GetByEmail(email string) (*model.User, error) {
 	return &user, nil
 }
 
-func (r *UserRepository) GetAll(limit, offset int) ([]model.User, error) {
-	var users []model.User
-	err := r.db.Offset(offset).Limit(limit).Find(&users).Error
-	return users, err
-}
-
 func (r *UserRepository) Update(id uuid.UUID, user *model.User) error {
 	return r.db.Model(&model.User{}).Where("id = ?", id).Updates(user).Error
 }
 
+// UpdateColumns updates the given columns, including zero values.
+func (r *UserRepository) UpdateColumns(id uuid.UUID, values map[string]interface{}) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Updates(values).Error
+}
+
 func (r *UserRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&model.User{}, "id = ?", id).Error
 }
 
+// UpdateKeepingAdmin updates the given columns like UpdateColumns. It reports
+// false, without updating, when the user is the last active administrator
+// (see keepsActiveAdmin).
+func (r *UserRepository) UpdateKeepingAdmin(id uuid.UUID, values map[string]interface{}) (bool, error) {
+	kept := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if kept, err = keepsActiveAdmin(tx, id); err != nil || !kept {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", id).Updates(values).Error
+	})
+	return kept, err
+}
+
+// DeleteKeepingAdmin deletes the user like Delete. It reports false, without
+// deleting, when the user is the last active administrator.
+func (r *UserRepository) DeleteKeepingAdmin(id uuid.UUID) (bool, error) {
+	kept := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if kept, err = keepsActiveAdmin(tx, id); err != nil || !kept {
+			return err
+		}
+		return tx.Delete(&model.User{}, "id = ?", id).Error
+	})
+	return kept, err
+}
+
+// keepsActiveAdmin locks the rows of the active administrators for the rest
+// of tx and reports whether one of them is left besides userID. Concurrent
+// demotions, deactivations, deletions and erasures wait on the same rows, so
+// two of them cannot each see the other admin and remove both.
+func keepsActiveAdmin(tx *gorm.DB, userID uuid.UUID) (bool, error) {
+	var ids []uuid.UUID
+	if err := tx.Model(&model.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ? AND active = ?", model.RoleAdmin, true).
+		Order("id").
+		Pluck("id", &ids).Error; err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if id == userID {
+			return len(ids) > 1, nil
+		}
+	}
+	return true, nil
+}
+
 func (r *UserRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&model.User{}).Count(&count).Error
@@ -61,3 +113,119 @@ func (r *UserRepository) Exists(email string) (bool, error) {
 	err := r.db.Model(&model.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+// AdvanceTwoFactorCounter records the TOTP time step that was just accepted.
+// It reports false when that step (or a later one) was already used, which
+// blocks replaying a code.
+func (r *UserRepository) AdvanceTwoFactorCounter(id uuid.UUID, counter int64) (bool, error) {
+	result := r.db.Model(&model.User{}).
+		Where("id = ? AND two_factor_last_counter < ?", id, counter).
+		Update("two_factor_last_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// userSortColumns whitelists the columns the admin listing can sort by.
+var userSortColumns = map[string]string{
+	"created_at": "users.created_at",
+	"updated_at": "users.updated_at",
+	"full_name":  "users.full_name",
+	"email":      "users.email",
+	"role":       "users.role",
+}
+
+// Search returns a page of users matching the filters and the total number
+// of matches.
+func (r *UserRepository) Search(filters *model.UserFilters) ([]model.User, int64, error) {
+	var total int64
+	if err := r.buildUserQuery(filters).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := userSortColumns[filters.Sort]
+	if !ok {
+		column = userSortColumns["created_at"]
+	}
+	direction := "DESC"
+	if strings.EqualFold(filters.Order, "asc") {
+		direction = "ASC"
+	}
+
+	var users []model.User
+	err := r.buildUserQuery(filters).
+		Preload("Plan").
+		Order(column + " " + direction).
+		Order("users.id").
+		Offset(filters.Offset).
+		Limit(filters.Limit).
+		Find(&users).Error
+	return users, total, err
+}
+
+func (r *UserRepository) buildUserQuery(filters *model.UserFilters) *gorm.DB {
+	query := r.db.Model(&model.User{})
+	if filters.Search != "" {
+		pattern := "%" + escapeLike(filters.Search) + "%"
+		query = query.Where(`users.full_name ILIKE ? ESCAPE '\' OR users.email ILIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+	if filters.Role != nil {
+		query = query.Where("users.role = ?", *filters.Role)
+	}
+	if filters.NoPlan {
+		query = query.Where("users.plan_id IS NULL")
+	} else if filters.PlanID != nil {
+		query = query.Where("users.plan_id = ?", *filters.PlanID)
+	}
+	if filters.Active != nil {
+		query = query.Where("users.active = ?", *filters.Active)
+	}
+	if filters.Provider != nil {
+		if *filters.Provider == "local" {
+			query = query.Where("users.password <> ''")
+		} else {
+			query = query.Where(
+				"users.provider = ? OR EXISTS (SELECT 1 FROM user_identities ui WHERE ui.user_id = users.id AND ui.provider = ?)",
+				*filters.Provider, *filters.Provider,
+			)
+		}
+	}
+	return query
+}
+
+// likeEscaper escapes the LIKE wildcards, so user input only matches itself
+// in patterns declared with ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// Totals counts the whole user base by status and role.
+func (r *UserRepository) Totals() (*model.UserTotals, error) {
+	totals := &model.UserTotals{ByRole: map[string]int64{}}
+
+	var rows []struct {
+		Role   string
+		Active bool
+		Count  int64
+	}
+	if err := r.db.Model(&model.User{}).
+		Select("role, active, COUNT(*) AS count").
+		Group("role, active").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		totals.Users += row.Count
+		totals.ByRole[row.Role] += row.Count
+		if row.Active {
+			totals.Active += row.Count
+		} else {
+			totals.Inactive += row.Count
+		}
+	}
+	return totals, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) *UserSessionRepository {
+	return &UserSessionRepository{db: db}
+}
+
+func (r *UserSessionRepository) Create(item *model.UserSession) error {
+	return r.db.Create(item).Error
+}
+
+func (r *UserSessionRepository) GetByID(id uuid.UUID) (*model.UserSession, error) {
+	var item model.UserSession
+	if err := r.db.First(&item, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *UserSessionRepository) GetByTokenHash(hash string) (*model.UserSession, error) {
+	var item model.UserSession
+	if err := r.db.First(&item, "refresh_token_hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *UserSessionRepository) GetByPreviousTokenHash(hash string) (*model.UserSession, error) {
+	var item model.UserSession
+	if err := r.db.First(&item, "previous_token_hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *UserSessionRepository) GetActiveByUser(userID uuid.UUID) ([]model.UserSession, error) {
+	var items []model.UserSession
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&items).Error
+	return items, err
+}
+
+// Rotate swaps the refresh token hash only if the session still holds
+// oldHash, so two concurrent refreshes with the same token cannot both win.
+func (r *UserSessionRepository) Rotate(session *model.UserSession, oldHash string) (bool, error) {
+	result := r.db.Model(&model.UserSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  session.RefreshTokenHash,
+			"previous_token_hash": oldHash,
+			"user_agent":          session.UserAgent,
+			"ip_address":          session.IPAddress,
+			"last_seen_at":        session.LastSeenAt,
+			"expires_at":          session.ExpiresAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserSessionRepository) Revoke(id uuid.UUID, reason string) error {
+	return r.db.Model(&model.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+func (r *UserSessionRepository) RevokeAllByUser(userID uuid.UUID, reason string) error {
+	return r.db.Model(&model.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+// RevokeOthersByUser revokes every active session of the user except keepID.
+func (r *UserSessionRepository) RevokeOthersByUser(userID, keepID uuid.UUID, reason string) error {
+	return r.db.Model(&model.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
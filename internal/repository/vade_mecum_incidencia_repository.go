@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/gorm"
+)
+
+type VadeMecumIncidenciaRepository struct {
+	db *gorm.DB
+}
+
+func NewVadeMecumIncidenciaRepository(db *gorm.DB) *VadeMecumIncidenciaRepository {
+	return &VadeMecumIncidenciaRepository{db: db}
+}
+
+// refreshIncidencias recounts the given rows inside tx. Callers pass every
+// row whose links or questions changed, before and after the change.
+func refreshIncidencias(tx *gorm.DB, refs []model.DispositivoRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	tuples := make([][]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		tuples = append(tuples, []interface{}{ref.Fonte, ref.DispositivoID})
+	}
+
+	if err := tx.Where("(fonte, dispositivo_id) IN ?", tuples).Delete(&model.VadeMecumIncidencia{}).Error; err != nil {
+		return err
+	}
+	// Questions are counted per banca, cargo and year from the links that were
+	// not removed.
+	return tx.Exec(`INSERT INTO vade_mecum_incidencias (fonte, dispositivo_id, banca, cargo, ano, total)
+		SELECT qd.fonte, qd.dispositivo_id, COALESCE(q.banca, ''), COALESCE(q.cargo, ''), COALESCE(q.ano, 0), COUNT(DISTINCT q.id)
+		FROM questao_dispositivos qd
+		JOIN questoes q ON q.id = qd.questao_id
+		WHERE NOT qd.removido AND (q.erro_captura IS NULL OR q.erro_captura = false)
+			AND (qd.fonte, qd.dispositivo_id) IN ?
+		GROUP BY 1, 2, 3, 4, 5
+		ON CONFLICT (fonte, dispositivo_id, banca, cargo, ano) DO UPDATE SET total = EXCLUDED.total`, tuples).Error
+}
+
+// questaoDispositivoRefs returns the rows a question is linked to.
+func questaoDispositivoRefs(tx *gorm.DB, questaoID int) ([]model.DispositivoRef, error) {
+	var refs []model.DispositivoRef
+	err := tx.Model(&model.QuestaoDispositivo{}).
+		Select("fonte, dispositivo_id").
+		Where("questao_id = ? AND removido = ?", questaoID, false).
+		Scan(&refs).Error
+	return refs, err
+}
+
+func applyIncidenciaFilters(query *gorm.DB, filters *model.VadeMecumIncidenciaFilters) *gorm.DB {
+	if len(filters.Banca) > 0 {
+		query = query.Where("i.banca IN ?", filters.Banca)
+	}
+	if len(filters.Cargo) > 0 {
+		query = query.Where("i.cargo IN ?", filters.Cargo)
+	}
+	if filters.AnoMin != nil {
+		query = query.Where("i.ano >= ?", *filters.AnoMin)
+	}
+	if filters.AnoMax != nil {
+		query = query.Where("i.ano <= ?", *filters.AnoMax)
+	}
+	return query
+}
+
+// Totais returns how many questions cite each row of a source, keyed by id.
+// Rows no question cites are left out.
+func (r *VadeMecumIncidenciaRepository) Totais(fonte string, filters *model.VadeMecumIncidenciaFilters) (map[string]int64, error) {
+	var rows []struct {
+		DispositivoID string
+		Incidencia    int64
+	}
+	err := applyIncidenciaFilters(r.db.Table("vade_mecum_incidencias i"), filters).
+		Select("i.dispositivo_id, SUM(i.total) AS incidencia").
+		Where("i.fonte = ?", fonte).
+		Group("i.dispositivo_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totais := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totais[row.DispositivoID] = row.Incidencia
+	}
+	return totais, nil
+}
+
+// Ranking returns one page of the most cited rows and how many rows are
+// cited at all. Rows deleted from the vade-mecum are left out when a fonte
+// is given.
+func (r *VadeMecumIncidenciaRepository) Ranking(filters *model.VadeMecumIncidenciaFilters) ([]model.VadeMecumRankingItem, int64, error) {
+	query := func() *gorm.DB {
+		query := applyIncidenciaFilters(r.db.Table("vade_mecum_incidencias i"), filters)
+		if tabela, ok := dispositivoTabelas[filters.Fonte]; ok {
+			join := "JOIN " + tabela.tabela + " t ON t.id::text = i.dispositivo_id AND t.deleted_at IS NULL"
+			if filters.Diploma != "" && filters.Fonte != model.DispositivoFonteConstituicao {
+				query = query.Joins(join+" AND t.nomecodigo = ?", filters.Diploma)
+			} else {
+				query = query.Joins(join)
+			}
+			query = query.Where("i.fonte = ?", filters.Fonte)
+		}
+		return query.Group("i.fonte, i.dispositivo_id")
+	}
+
+	var total int64
+	if err := r.db.Table("(?) AS ranking", query().Select("1")).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []model.VadeMecumRankingItem
+	err := query().
+		Select("i.fonte, i.dispositivo_id, SUM(i.total) AS incidencia").
+		Order("incidencia DESC, i.fonte, i.dispositivo_id").
+		Offset(filters.Offset).
+		Limit(filters.Limit).
+		Scan(&items).Error
+	return items, total, err
+}
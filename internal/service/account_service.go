@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+)
+
+var ErrInvalidUserToken = errors.New("invalid or expired token")
+
+// AccountService handles the e-mail based account flows: password recovery
+// and e-mail verification.
+type AccountService struct {
+	userRepo    *repository.UserRepository
+	tokenRepo   *repository.UserTokenRepository
+	sessionRepo *repository.UserSessionRepository
+	mailer      Mailer
+	frontendURL string
+}
+
+func NewAccountService(userRepo *repository.UserRepository, tokenRepo *repository.UserTokenRepository, sessionRepo *repository.UserSessionRepository, mailer Mailer, frontendURL string) *AccountService {
+	return &AccountService{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		sessionRepo: sessionRepo,
+		mailer:      mailer,
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+// ForgotPassword sends a reset link when the e-mail exists. Unknown e-mails
+// are ignored silently so the endpoint cannot be used to enumerate accounts.
+func (s *AccountService) ForgotPassword(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	token, err := s.issueToken(user, model.UserTokenPasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	// Delivery failures are logged instead of returned so the response does not
+	// reveal whether the account exists.
+	err = s.mailer.Send(MailMessage{
+		To:      user.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf(
+			"Olá, %s!\n\nRecebemos uma solicitação para redefinir sua senha.\nAcesse o link abaixo em até 1 hora:\n\n%s\n\nSe você não fez essa solicitação, ignore este e-mail.",
+			user.FullName,
+			s.link("/redefinir-senha", token),
+		),
+	})
+	if err != nil {
+		log.Printf("failed to send password reset e-mail to %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+// ResetPassword consumes the reset token, stores the new password and revokes
+// every active session of the user.
+func (s *AccountService) ResetPassword(token, newPassword string) error {
+	item, err := s.consumeToken(token, model.UserTokenPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateColumns(item.UserID, map[string]interface{}{"password": string(hashedPassword)}); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAllByUser(item.UserID, "password_reset")
+}
+
+func (s *AccountService) SendVerificationEmail(user *model.User) error {
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	token, err := s.issueToken(user, model.UserTokenEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(MailMessage{
+		To:      user.Email,
+		Subject: "Confirme seu e-mail",
+		Body: fmt.Sprintf(
+			"Olá, %s!\n\nConfirme seu endereço de e-mail acessando o link abaixo:\n\n%s\n\nO link expira em 48 horas.",
+			user.FullName,
+			s.link("/verificar-email", token),
+		),
+	})
+}
+
+// ResendVerification behaves like ForgotPassword regarding unknown e-mails.
+func (s *AccountService) ResendVerification(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+	if err := s.SendVerificationEmail(user); err != nil {
+		log.Printf("failed to send verification e-mail to %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+func (s *AccountService) VerifyEmail(token string) error {
+	item, err := s.consumeToken(token, model.UserTokenEmailVerification)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.UpdateColumns(item.UserID, map[string]interface{}{"email_verified_at": time.Now()})
+}
+
+func (s *AccountService) issueToken(user *model.User, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.InvalidateByUser(user.ID, purpose); err != nil {
+		return "", err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.Create(&model.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *AccountService) consumeToken(token, purpose string) (*model.UserToken, error) {
+	item, err := s.tokenRepo.GetValid(hashOpaqueToken(token), purpose)
+	if err != nil {
+		return nil, ErrInvalidUserToken
+	}
+
+	used, err := s.tokenRepo.MarkUsed(item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidUserToken
+	}
+	return item, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.frontendURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// SendVerificationEmailAsync sends the verification e-mail in the background;
+// failures are only logged.
+func (s *AccountService) SendVerificationEmailAsync(user *model.User) {
+	go func() {
+		if err := s.SendVerificationEmail(user); err != nil {
+			log.Printf("failed to send verification e-mail to %s: %v", user.Email, err)
+		}
+	}()
+}
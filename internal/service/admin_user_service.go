@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/gorm"
+)
+
+const maxUserPageSize = 100
+
+var (
+	ErrInvalidRole      = errors.New("invalid role")
+	ErrCannotChangeSelf = errors.New("administrators cannot change their own role or status or delete themselves")
+	ErrLastAdmin        = errors.New("the last active administrator cannot be demoted, deactivated or deleted")
+	ErrPlanNotFound     = errors.New("plan not found")
+
+	assignableUserRoles = map[string]bool{model.RoleUser: true, model.RoleCorretor: true, model.RoleAdmin: true}
+)
+
+// AdminUserService backs the admin user management screens. Every change is
+// written to the audit log with the administrator who made it.
+type AdminUserService struct {
+	userRepo    *repository.UserRepository
+	sessionRepo *repository.UserSessionRepository
+	planRepo    *repository.PlanRepository
+	audit       *AuditService
+}
+
+func NewAdminUserService(userRepo *repository.UserRepository, sessionRepo *repository.UserSessionRepository, planRepo *repository.PlanRepository, audit *AuditService) *AdminUserService {
+	return &AdminUserService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		planRepo:    planRepo,
+		audit:       audit,
+	}
+}
+
+func (s *AdminUserService) Search(filters *model.UserFilters) (*model.UserListResponse, error) {
+	if filters.Limit <= 0 || filters.Limit > maxUserPageSize {
+		filters.Limit = 20
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	users, total, err := s.userRepo.Search(filters)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.userRepo.Totals()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserListResponse{
+		Data:   users,
+		Total:  total,
+		Limit:  filters.Limit,
+		Offset: filters.Offset,
+		Totals: *totals,
+	}, nil
+}
+
+// SetRole promotes or demotes a user. Sessions are revoked so the new role
+// takes effect on the next login; promoted admins must then set up 2FA.
+func (s *AdminUserService) SetRole(actorID, userID uuid.UUID, role, ipAddress string) (*model.User, error) {
+	if !assignableUserRoles[role] {
+		return nil, ErrInvalidRole
+	}
+	if actorID == userID {
+		return nil, ErrCannotChangeSelf
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	kept, err := s.userRepo.UpdateKeepingAdmin(userID, map[string]interface{}{"role": role})
+	if err != nil {
+		return nil, err
+	}
+	if !kept {
+		return nil, ErrLastAdmin
+	}
+	if err := s.sessionRepo.RevokeAllByUser(userID, "role_changed"); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(model.AuditUserRoleChanged, &userID, ipAddress, map[string]interface{}{
+		"actor_id": actorID.String(),
+		"from":     user.Role,
+		"to":       role,
+	})
+	return s.userRepo.GetByID(userID)
+}
+
+// SetActive deactivates or reactivates a user. Deactivation revokes every
+// session; login, refresh and 2FA verification refuse inactive accounts.
+func (s *AdminUserService) SetActive(actorID, userID uuid.UUID, active bool, ipAddress string) (*model.User, error) {
+	if actorID == userID {
+		return nil, ErrCannotChangeSelf
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Active == active {
+		return user, nil
+	}
+
+	kept, err := s.userRepo.UpdateKeepingAdmin(userID, map[string]interface{}{"active": active})
+	if err != nil {
+		return nil, err
+	}
+	if !kept {
+		return nil, ErrLastAdmin
+	}
+
+	action := model.AuditUserReactivated
+	if !active {
+		action = model.AuditUserDeactivated
+		if err := s.sessionRepo.RevokeAllByUser(userID, "account_disabled"); err != nil {
+			return nil, err
+		}
+	}
+
+	s.audit.Record(action, &userID, ipAddress, map[string]interface{}{
+		"actor_id": actorID.String(),
+	})
+	return s.userRepo.GetByID(userID)
+}
+
+// SetPlan assigns a plan to the user, or removes it when planID is nil.
+func (s *AdminUserService) SetPlan(actorID, userID uuid.UUID, planID *uuid.UUID, ipAddress string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if planID != nil {
+		if _, err := s.planRepo.GetByID(*planID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrPlanNotFound
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.UpdateColumns(userID, map[string]interface{}{"plan_id": planID}); err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"actor_id": actorID.String(),
+		"from":     nil,
+		"to":       nil,
+	}
+	if user.PlanID != nil {
+		metadata["from"] = user.PlanID.String()
+	}
+	if planID != nil {
+		metadata["to"] = planID.String()
+	}
+	s.audit.Record(model.AuditUserPlanChanged, &userID, ipAddress, metadata)
+
+	return s.userRepo.GetByID(userID)
+}
+
+// Delete removes a user. Like demotion and deactivation, it never leaves the
+// platform without an active administrator.
+func (s *AdminUserService) Delete(actorID, userID uuid.UUID, ipAddress string) error {
+	if actorID == userID {
+		return ErrCannotChangeSelf
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	kept, err := s.userRepo.DeleteKeepingAdmin(userID)
+	if err != nil {
+		return err
+	}
+	if !kept {
+		return ErrLastAdmin
+	}
+
+	s.audit.Record(model.AuditUserDeleted, &userID, ipAddress, map[string]interface{}{
+		"actor_id": actorID.String(),
+		"role":     user.Role,
+	})
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/datatypes"
+)
+
+// AuditService writes security relevant events to audit_logs. Recording never
+// fails the caller: errors are only logged.
+type AuditService struct {
+	repo *repository.AuditLogRepository
+}
+
+func NewAuditService(repo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+func (s *AuditService) Record(action string, userID *uuid.UUID, ipAddress string, metadata map[string]interface{}) {
+	entry := &model.AuditLog{
+		UserID:    userID,
+		Action:    action,
+		IPAddress: ipAddress,
+	}
+	if len(metadata) > 0 {
+		if raw, err := json.Marshal(metadata); err == nil {
+			entry.Metadata = datatypes.JSON(raw)
+		}
+	}
+
+	log.Printf("audit: action=%s user=%v ip=%s metadata=%s", action, userID, ipAddress, string(entry.Metadata))
+	if err := s.repo.Create(entry); err != nil {
+		log.Printf("failed to write audit log %s: %v", action, err)
+	}
+}
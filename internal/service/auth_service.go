@@ -1,63 +1,300 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrAccountDisabled     = errors.New("account is disabled")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrInvalidSession      = errors.New("session is no longer valid")
 )
 
 type AuthService struct {
 	userService *UserService
+	sessionRepo *repository.UserSessionRepository
+	twoFactor   *TwoFactorService
+	throttle    *LoginThrottle
 	jwtSecret   string
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
 }
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(userService *UserService, jwtSecret string) *AuthService {
+func NewAuthService(userService *UserService, sessionRepo *repository.UserSessionRepository, twoFactor *TwoFactorService, throttle *LoginThrottle, jwtSecret string, accessTTL, refreshTTL time.Duration) *AuthService {
 	return &AuthService{
 		userService: userService,
+		sessionRepo: sessionRepo,
+		twoFactor:   twoFactor,
+		throttle:    throttle,
 		jwtSecret:   jwtSecret,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
 	}
 }
 
-func (s *AuthService) Login(req *model.LoginRequest) (*model.LoginResponse, error) {
+// Login checks the password behind the login throttle. Unknown e-mails count
+// as failures too, so the throttle does not reveal which accounts exist.
+func (s *AuthService) Login(req *model.LoginRequest, client model.ClientInfo) (*model.LoginResponse, error) {
+	if err := s.throttle.Allow(req.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userService.GetUserByEmail(req.Email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		s.throttle.RegisterFailure(req.Email, client.IPAddress, model.AuditLoginLockout)
+		return nil, ErrInvalidCredentials
 	}
 
 	if err := s.userService.VerifyPassword(user, req.Password); err != nil {
-		return nil, errors.New("invalid credentials")
+		s.throttle.RegisterFailure(req.Email, client.IPAddress, model.AuditLoginLockout)
+		return nil, ErrInvalidCredentials
+	}
+
+	s.throttle.RegisterSuccess(req.Email)
+	return s.CompleteLogin(user, client)
+}
+
+// CompleteLogin finishes a first-factor login (password or social): it issues
+// tokens, or a two-factor challenge when the account requires a second step.
+func (s *AuthService) CompleteLogin(user *model.User, client model.ClientInfo) (*model.LoginResponse, error) {
+	if !user.Active {
+		return nil, ErrAccountDisabled
+	}
+	if s.twoFactor.Required(user) {
+		return s.twoFactor.NewChallenge(user)
+	}
+	return s.IssueTokens(user, client)
+}
+
+// VerifyTwoFactor completes a login started with a two-factor challenge. For
+// setup challenges the code also enables the second factor and the new
+// recovery codes are returned along with the tokens.
+func (s *AuthService) VerifyTwoFactor(challengeToken, code string, client model.ClientInfo) (*model.LoginResponse, error) {
+	userID, purpose, err := s.twoFactor.ParseChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorToken
+	}
+	if !user.Active {
+		return nil, ErrAccountDisabled
+	}
+
+	if err := s.throttle.Allow(user.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	switch purpose {
+	case twoFactorPurposeSetup:
+		recoveryCodes, err = s.twoFactor.Enable(user, code)
+	case twoFactorPurposeLogin:
+		err = s.twoFactor.Verify(user, code)
+	default:
+		err = ErrInvalidTwoFactorToken
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.throttle.RegisterFailure(user.Email, client.IPAddress, model.AuditLoginLockout)
+		}
+		return nil, err
+	}
+	s.throttle.RegisterSuccess(user.Email)
+
+	response, err := s.IssueTokens(user, client)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// IssueTokens starts a new session for the user and returns an access token
+// bound to it together with the opaque refresh token.
+func (s *AuthService) IssueTokens(user *model.User, client model.ClientInfo) (*model.LoginResponse, error) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &model.UserSession{
+		UserID:           user.ID,
+		RefreshTokenHash: refreshHash,
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(s.refreshTTL),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+
+	token, expiresIn, err := s.GenerateToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
+		User:         user,
+	}, nil
+}
+
+// Refresh rotates the refresh token. Presenting a token that was already
+// rotated revokes the whole session, since it means the token leaked.
+func (s *AuthService) Refresh(refreshToken string, client model.ClientInfo) (*model.LoginResponse, error) {
+	hash := hashOpaqueToken(refreshToken)
+
+	session, err := s.sessionRepo.GetByTokenHash(hash)
+	if err != nil {
+		if reused, reuseErr := s.sessionRepo.GetByPreviousTokenHash(hash); reuseErr == nil {
+			_ = s.sessionRepo.Revoke(reused.ID, "reuse_detected")
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userService.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if !user.Active {
+		_ = s.sessionRepo.Revoke(session.ID, "account_disabled")
+		return nil, ErrAccountDisabled
+	}
+
+	newToken, newHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
 	}
 
-	token, expiresIn, err := s.GenerateToken(user)
+	now := time.Now()
+	session.RefreshTokenHash = newHash
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.refreshTTL)
+	if client.UserAgent != "" {
+		session.UserAgent = client.UserAgent
+	}
+	if client.IPAddress != "" {
+		session.IPAddress = client.IPAddress
+	}
+
+	rotated, err := s.sessionRepo.Rotate(session, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		_ = s.sessionRepo.Revoke(session.ID, "reuse_detected")
+		return nil, ErrRefreshTokenReused
+	}
+
+	token, expiresIn, err := s.GenerateToken(user, session.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *user,
+		Token:        token,
+		RefreshToken: newToken,
+		ExpiresIn:    expiresIn,
+		User:         user,
 	}, nil
 }
 
-func (s *AuthService) GenerateToken(user *model.User) (string, int64, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+// Logout revokes the session of the current access token. When a refresh
+// token is provided instead, the session it belongs to is revoked.
+func (s *AuthService) Logout(userID uuid.UUID, sessionID uuid.UUID, refreshToken string) error {
+	if refreshToken != "" {
+		session, err := s.sessionRepo.GetByTokenHash(hashOpaqueToken(refreshToken))
+		if err != nil || session.UserID != userID {
+			return ErrInvalidRefreshToken
+		}
+		sessionID = session.ID
+	}
+	if sessionID == uuid.Nil {
+		return errors.New("session not found")
+	}
+	return s.RevokeSession(userID, sessionID)
+}
+
+func (s *AuthService) LogoutAll(userID uuid.UUID) error {
+	return s.sessionRepo.RevokeAllByUser(userID, "logout_all")
+}
+
+func (s *AuthService) RevokeSession(userID, sessionID uuid.UUID) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID {
+		return errors.New("session not found")
+	}
+	return s.sessionRepo.Revoke(session.ID, "logout")
+}
+
+func (s *AuthService) GetSessions(userID, currentSessionID uuid.UUID) ([]model.UserSessionResponse, error) {
+	sessions, err := s.sessionRepo.GetActiveByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]model.UserSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, model.UserSessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+			CreatedAt:  session.CreatedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return response, nil
+}
+
+func (s *AuthService) GenerateToken(user *model.User, sessionID uuid.UUID) (string, int64, error) {
+	now := time.Now()
+	expirationTime := now.Add(s.accessTTL)
 	claims := &Claims{
 		UserID: user.ID.String(),
 		Email:  user.Email,
+		Role:   user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if sessionID != uuid.Nil {
+		claims.SessionID = sessionID.String()
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.jwtSecret))
@@ -71,6 +308,9 @@ func (s *AuthService) GenerateToken(user *model.User) (string, int64, error) {
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
 		return []byte(s.jwtSecret), nil
 	})
 
@@ -80,3 +320,52 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// Authenticate validates an access token against the current state of its
+// session and user, so revoking the session, disabling the account or
+// changing the role takes effect before the token expires. The returned user
+// carries the role from the database, not the one in the token.
+func (s *AuthService) Authenticate(tokenString string) (*model.User, uuid.UUID, error) {
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, uuid.Nil, errors.New("invalid token")
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, uuid.Nil, errors.New("invalid token")
+	}
+
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, uuid.Nil, ErrInvalidSession
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		return nil, uuid.Nil, ErrInvalidSession
+	}
+	if !user.Active {
+		return nil, uuid.Nil, ErrAccountDisabled
+	}
+	return user, session.ID, nil
+}
+
+// newOpaqueToken returns a random URL-safe token and the SHA-256 hash that is
+// persisted in its place.
+func newOpaqueToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashOpaqueToken(token), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
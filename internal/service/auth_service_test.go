@@ -0,0 +1,131 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+)
+
+func newTestAuthService(accessTTL time.Duration) *AuthService {
+	return NewAuthService(nil, nil, nil, nil, "test-secret", accessTTL, time.Hour)
+}
+
+func TestGenerateTokenRoundTrip(t *testing.T) {
+	s := newTestAuthService(15 * time.Minute)
+	user := &model.User{ID: uuid.New(), Email: "ana@example.com", Role: model.RoleAdmin}
+	sessionID := uuid.New()
+
+	token, expiresAt, err := s.GenerateToken(user, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait := time.Until(time.Unix(expiresAt, 0)); wait < 14*time.Minute || wait > 15*time.Minute {
+		t.Errorf("token expires in %s, want 15m", wait)
+	}
+
+	claims, err := s.ValidateToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != user.ID.String() || claims.Email != user.Email || claims.Role != user.Role || claims.SessionID != sessionID.String() {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestValidateTokenRejects(t *testing.T) {
+	s := newTestAuthService(15 * time.Minute)
+	user := &model.User{ID: uuid.New(), Role: model.RoleUser}
+
+	expired, _, err := newTestAuthService(-time.Minute).GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSecret, _, err := NewAuthService(nil, nil, nil, nil, "other-secret", time.Minute, time.Hour).GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, &Claims{UserID: user.ID.String(), Role: model.RoleAdmin}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, _, err := s.GenerateToken(user, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signature of a user token on claims promoted to admin.
+	parts := strings.Split(valid, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := strings.Replace(string(payload), `"role":"user"`, `"role":"admin"`, 1)
+	if forged == string(payload) {
+		t.Fatalf("no role claim in %s", payload)
+	}
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(forged))
+	promoted := strings.Join(parts, ".")
+
+	for name, token := range map[string]string{
+		"expired":      expired,
+		"other secret": otherSecret,
+		"alg none":     unsigned,
+		"tampered":     promoted,
+		"garbage":      "not-a-jwt",
+	} {
+		if _, err := s.ValidateToken(token); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+}
+
+// Tokens that fail before the session lookup never reach the repositories,
+// which are nil here.
+func TestAuthenticateRejectsTokensWithoutSession(t *testing.T) {
+	s := newTestAuthService(15 * time.Minute)
+	user := &model.User{ID: uuid.New(), Role: model.RoleUser}
+
+	withoutSession, _, err := s.GenerateToken(user, uuid.Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Authenticate(withoutSession); err == nil {
+		t.Error("a token without sid was accepted")
+	}
+	if _, _, err := s.Authenticate("not-a-jwt"); err == nil {
+		t.Error("an invalid token was accepted")
+	}
+}
+
+func TestNewOpaqueToken(t *testing.T) {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, otherHash, err := newOpaqueToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token == other || hash == otherHash {
+		t.Error("two opaque tokens are equal")
+	}
+	if len(token) != 43 {
+		t.Errorf("token has %d characters, want 43 (32 bytes in base64url)", len(token))
+	}
+	if hash != hashOpaqueToken(token) {
+		t.Error("the returned hash is not the hash of the token")
+	}
+	if raw, err := hex.DecodeString(hash); err != nil || len(raw) != 32 {
+		t.Errorf("hash %q is not a hex SHA-256", hash)
+	}
+	if hash == token {
+		t.Error("the token is stored in clear")
+	}
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const maxCadernoQuestoes = 1000
+
+var (
+	ErrCadernoConteudo         = errors.New("informe filtros ou questao_ids, mas nao ambos")
+	ErrCadernoTooLarge         = fmt.Errorf("o caderno pode ter no maximo %d questoes", maxCadernoQuestoes)
+	ErrCadernoQuestaoNotFound  = errors.New("questoes nao encontradas")
+	ErrCadernoNotLista         = errors.New("o caderno e um filtro salvo; edite os filtros")
+	ErrCadernoQuestaoNotInList = errors.New("questao nao esta no caderno")
+)
+
+type CadernoService struct {
+	repo        *repository.CadernoRepository
+	questaoRepo *repository.QuestaoRepository
+	frontendURL string
+}
+
+// NewCadernoService builds the service; share links point to frontendURL.
+func NewCadernoService(repo *repository.CadernoRepository, questaoRepo *repository.QuestaoRepository, frontendURL string) *CadernoService {
+	return &CadernoService{repo: repo, questaoRepo: questaoRepo, frontendURL: strings.TrimRight(frontendURL, "/")}
+}
+
+func (s *CadernoService) Create(userID uuid.UUID, req *model.CadernoRequest) (*model.CadernoDetail, error) {
+	caderno := &model.Caderno{UserID: userID}
+	ids, err := s.apply(caderno, req)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		caderno.Questoes = append(caderno.Questoes, model.CadernoQuestao{QuestaoID: id, Ordem: i + 1})
+	}
+	if err := s.repo.Create(caderno); err != nil {
+		return nil, err
+	}
+	return s.ownerDetail(caderno)
+}
+
+func (s *CadernoService) List(userID uuid.UUID) ([]model.Caderno, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// Get returns the caderno with its share link and the owner's progress.
+func (s *CadernoService) Get(userID, id uuid.UUID) (*model.CadernoDetail, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ownerDetail(caderno)
+}
+
+// Update replaces the caderno name, description and content. Progress is
+// kept; answers count again for questions added back.
+func (s *CadernoService) Update(userID, id uuid.UUID, req *model.CadernoRequest) (*model.CadernoDetail, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.apply(caderno, req)
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = []int{}
+	}
+	if err := s.repo.Update(caderno, ids); err != nil {
+		return nil, err
+	}
+	return s.ownerDetail(caderno)
+}
+
+func (s *CadernoService) Delete(userID, id uuid.UUID) error {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(caderno.ID)
+}
+
+// AddQuestoes appends questions to a list caderno, up to maxCadernoQuestoes
+// in total.
+func (s *CadernoService) AddQuestoes(userID, id uuid.UUID, questaoIDs []int) (*model.CadernoDetail, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if caderno.Tipo != model.CadernoTipoLista {
+		return nil, ErrCadernoNotLista
+	}
+	ids, err := s.validQuestaoIDs(questaoIDs)
+	if err != nil {
+		return nil, err
+	}
+	added, err := s.repo.AddQuestoes(caderno.ID, ids, maxCadernoQuestoes)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrCadernoTooLarge
+	}
+	return s.ownerDetail(caderno)
+}
+
+func (s *CadernoService) RemoveQuestao(userID, id uuid.UUID, questaoID int) error {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return err
+	}
+	if caderno.Tipo != model.CadernoTipoLista {
+		return ErrCadernoNotLista
+	}
+	removed, err := s.repo.RemoveQuestao(caderno.ID, questaoID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrCadernoQuestaoNotInList
+	}
+	return nil
+}
+
+func (s *CadernoService) Questoes(userID, id uuid.UUID, page, pageSize int) (*model.QuestaoPage, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.questoes(caderno, page, pageSize)
+}
+
+// ResetProgress restarts the user's progress in the caderno from now.
+func (s *CadernoService) ResetProgress(userID, id uuid.UUID) (*model.CadernoProgressoResumo, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.repo.ResetProgress(caderno.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(caderno, progress)
+}
+
+// Share creates the caderno's share link, keeping the current one if it is
+// already shared.
+func (s *CadernoService) Share(userID, id uuid.UUID) (*model.CadernoDetail, error) {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if caderno.ShareToken == nil {
+		token, err := newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.SetShareToken(caderno.ID, &token); err != nil {
+			return nil, err
+		}
+		caderno.ShareToken = &token
+	}
+	return s.ownerDetail(caderno)
+}
+
+// Unshare revokes the share link; a new one can be created later.
+func (s *CadernoService) Unshare(userID, id uuid.UUID) error {
+	caderno, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.SetShareToken(caderno.ID, nil)
+}
+
+// GetShared opens a shared caderno read-only. Signed-in viewers get their own
+// progress in it.
+func (s *CadernoService) GetShared(token string, viewerID *uuid.UUID) (*model.CadernoDetail, error) {
+	caderno, err := s.repo.GetByShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+	detail := &model.CadernoDetail{Caderno: *caderno}
+	if viewerID != nil {
+		progress, err := s.repo.GetProgress(caderno.ID, *viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if detail.Progresso, err = s.progress(caderno, progress); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+func (s *CadernoService) SharedQuestoes(token string, page, pageSize int) (*model.QuestaoPage, error) {
+	caderno, err := s.repo.GetByShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.questoes(caderno, page, pageSize)
+}
+
+// apply validates req and copies it into caderno, returning the question ids
+// of a list caderno.
+func (s *CadernoService) apply(caderno *model.Caderno, req *model.CadernoRequest) ([]int, error) {
+	if req == nil {
+		return nil, errors.New("payload obrigatorio")
+	}
+	if (req.Filtros == nil) == (len(req.QuestaoIDs) == 0) {
+		return nil, ErrCadernoConteudo
+	}
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return nil, errors.New("nome obrigatorio")
+	}
+	caderno.Nome = nome
+	caderno.Descricao = trimStringPtr(req.Descricao)
+
+	if req.Filtros != nil {
+		if req.Filtros.Sort != "" && !s.questaoRepo.SupportsSort(req.Filtros.Sort) {
+			return nil, ErrInvalidQuestaoSort
+		}
+		raw, err := json.Marshal(req.Filtros)
+		if err != nil {
+			return nil, err
+		}
+		caderno.Tipo = model.CadernoTipoFiltro
+		caderno.Filtros = raw
+		return nil, nil
+	}
+
+	ids, err := s.validQuestaoIDs(req.QuestaoIDs)
+	if err != nil {
+		return nil, err
+	}
+	caderno.Tipo = model.CadernoTipoLista
+	caderno.Filtros = nil
+	return ids, nil
+}
+
+// validQuestaoIDs drops duplicates, keeping the first position, and checks
+// that every question exists.
+func (s *CadernoService) validQuestaoIDs(questaoIDs []int) ([]int, error) {
+	seen := make(map[int]bool, len(questaoIDs))
+	ids := make([]int, 0, len(questaoIDs))
+	for _, id := range questaoIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxCadernoQuestoes {
+		return nil, ErrCadernoTooLarge
+	}
+
+	found, err := s.questaoRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) != len(ids) {
+		exists := make(map[int]bool, len(found))
+		for _, q := range found {
+			exists[q.ID] = true
+		}
+		var missing []string
+		for _, id := range ids {
+			if !exists[id] {
+				missing = append(missing, fmt.Sprint(id))
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCadernoQuestaoNotFound, strings.Join(missing, ", "))
+	}
+	return ids, nil
+}
+
+func (s *CadernoService) ownerDetail(caderno *model.Caderno) (*model.CadernoDetail, error) {
+	detail := &model.CadernoDetail{Caderno: *caderno}
+	if caderno.ShareToken != nil {
+		link := s.frontendURL + "/cadernos/compartilhados/" + *caderno.ShareToken
+		detail.Link = &link
+	}
+	progress, err := s.repo.GetProgress(caderno.ID, caderno.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Progresso, err = s.progress(caderno, progress); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+func (s *CadernoService) progress(caderno *model.Caderno, progress *model.CadernoProgresso) (*model.CadernoProgressoResumo, error) {
+	filters, err := cadernoFilters(caderno)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.repo.Progress(caderno, filters, progress)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Total > 0 {
+		summary.Percentual = math.Round(float64(summary.Respondidas)/float64(summary.Total)*10000) / 100
+	}
+	return summary, nil
+}
+
+func (s *CadernoService) questoes(caderno *model.Caderno, page, pageSize int) (*model.QuestaoPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultQuestaoPageSize
+	}
+	if pageSize > maxQuestaoPageSize {
+		pageSize = maxQuestaoPageSize
+	}
+
+	var (
+		items []model.QuestaoResult
+		total int64
+	)
+	if caderno.Tipo == model.CadernoTipoFiltro {
+		filters, err := cadernoFilters(caderno)
+		if err != nil {
+			return nil, err
+		}
+		filters.Page, filters.PageSize = page, pageSize
+		if items, total, err = s.questaoRepo.Search(filters); err != nil {
+			return nil, err
+		}
+	} else {
+		questoes, count, err := s.repo.ListQuestoes(caderno.ID, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = make([]model.QuestaoResult, 0, len(questoes))
+		for _, q := range questoes {
+			items = append(items, model.QuestaoResult{Questao: q})
+		}
+		total = count
+	}
+
+	return &model.QuestaoPage{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+func cadernoFilters(caderno *model.Caderno) (*model.QuestaoFilters, error) {
+	filters := &model.QuestaoFilters{}
+	if caderno.Tipo != model.CadernoTipoFiltro || len(caderno.Filtros) == 0 {
+		return filters, nil
+	}
+	if err := json.Unmarshal(caderno.Filtros, filters); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/thepantheon/api/internal/model"
+)
+
+// dispositivoDiploma is a law the parser recognizes. texto matches how it is
+// written in questions (Go syntax, case-insensitive); diploma and excluir are
+// matched against the unaccented nomecodigo of the vade-mecum. Citations
+// without a law use the first diploma whose disciplina matches the
+// question's.
+type dispositivoDiploma struct {
+	texto        string
+	diploma      string
+	excluir      string
+	disciplina   *regexp.Regexp
+	constituicao bool
+}
+
+// dispositivoDiplomas is ordered so longer names come first: "Código Penal
+// Militar" must not be read as "Código Penal".
+var dispositivoDiplomas = []dispositivoDiploma{
+	{
+		texto:        `CF(?:\s*/\s*(?:19)?88)?|CRFB(?:\s*/\s*(?:19)?88)?|Constitui[çc][ãa]o(?:\s+(?:Federal|da\s+Rep[úu]blica(?:\s+Federativa\s+do\s+Brasil)?))?(?:\s+de\s+1988)?|Carta\s+Magna`,
+		disciplina:   regexp.MustCompile(`constitucional`),
+		constituicao: true,
+	},
+	{texto: `CPPM|C[óo]digo\s+de\s+Processo\s+Penal\s+Militar`, diploma: `^\s*codigo de processo penal militar`},
+	{texto: `CPM|C[óo]digo\s+Penal\s+Militar`, diploma: `^\s*codigo penal militar`},
+	{texto: `CPP|C[óo]digo\s+de\s+Processo\s+Penal`, diploma: `^\s*codigo de processo penal`, excluir: `militar`, disciplina: regexp.MustCompile(`process(o|ual) penal`)},
+	{texto: `CP|C[óo]digo\s+Penal`, diploma: `^\s*codigo penal`, excluir: `militar`, disciplina: regexp.MustCompile(`penal`)},
+	{texto: `N?CPC(?:\s*/\s*(?:20)?15)?|C[óo]digo\s+de\s+Processo\s+Civil`, diploma: `^\s*codigo de processo civil`, disciplina: regexp.MustCompile(`process(o|ual) civil`)},
+	{texto: `CC(?:\s*/\s*(?:20)?02)?|C[óo]digo\s+Civil`, diploma: `^\s*codigo civil`, disciplina: regexp.MustCompile(`civil`)},
+	{texto: `CTN|C[óo]digo\s+Tribut[áa]rio\s+Nacional`, diploma: `codigo tributario`, disciplina: regexp.MustCompile(`tribut`)},
+	{texto: `CLT|Consolida[çc][ãa]o\s+das\s+Leis\s+do\s+Trabalho`, diploma: `consolidacao das leis do trabalho|\mclt\M`, disciplina: regexp.MustCompile(`trabalh`)},
+	{texto: `CDC|C[óo]digo\s+de\s+Defesa\s+do\s+Consumidor`, diploma: `defesa do consumidor|\mcdc\M`, disciplina: regexp.MustCompile(`consumidor`)},
+	{texto: `CTB|C[óo]digo\s+de\s+Tr[âa]nsito\s+Brasileiro`, diploma: `codigo de transito`},
+	{texto: `C[óo]digo\s+Eleitoral`, diploma: `codigo eleitoral`, disciplina: regexp.MustCompile(`eleitoral`)},
+	{texto: `ECA|Estatuto\s+da\s+Crian[çc]a\s+e\s+do\s+Adolescente`, diploma: `crianca e do adolescente|\meca\M`},
+	{texto: `Estatuto\s+d[ao]\s+(?:Pessoa\s+)?Idos[ao]`, diploma: `estatuto d[ao] (pessoa )?idos`},
+	{texto: `EOAB|Estatuto\s+da\s+(?:Advocacia|OAB)`, diploma: `estatuto da (advocacia|oab)`},
+	{texto: `LINDB|LICC|Lei\s+de\s+Introdu[çc][ãa]o\s+[àa]s\s+Normas\s+do\s+Direito\s+Brasileiro`, diploma: `introducao as normas|\mlindb\M`},
+}
+
+// dispositivoFontesArtigo are the sources searched for articles of a código
+// or lei; where each law was imported varies.
+var dispositivoFontesArtigo = []string{
+	model.DispositivoFonteCodigo,
+	model.DispositivoFonteLei,
+	model.DispositivoFonteEstatuto,
+	model.DispositivoFonteOAB,
+}
+
+// dispositivoTribunais maps the courts of súmulas to how they appear in the
+// jurisprudência names.
+var dispositivoTribunais = map[string]string{
+	"STF": `\mstf\M|supremo tribunal federal`,
+	"STJ": `\mstj\M|superior tribunal de justica`,
+	"TST": `\mtst\M|superior do trabalho`,
+	"TSE": `\mtse\M|superior eleitoral`,
+	"TCU": `\mtcu\M|contas da uniao`,
+}
+
+const (
+	dispositivoNumero   = `(?:\d{1,3}(?:\.\d{3})+|\d{1,4})(?:\s*(?:º|°|ª|o\b))?(?:-[a-z]\b)?`
+	dispositivoArtigo   = `\b(?:arts?\.?|artigos?)\s*(?P<nums>` + dispositivoNumero + `(?:\s*(?:,|\be\b)\s*` + dispositivoNumero + `)*)`
+	dispositivoDetalhes = `(?:\s*,?\s*(?:\be\s+)?(?:§{1,2}\s*\d+\s*(?:º|°|o\b)?|par[áa]grafo\s+(?:[úu]nico|\d+\s*(?:º|°|o\b)?)|caput|inc(?:iso)?s?\.?\s*(?-i:[IVXLC]+)\b|(?-i:[IVXLC]+)\b|al[íi]nea\s+["“']?[a-z]["”']?|["“']?[a-z]["”']?\)))*`
+	dispositivoLei      = `(?P<lei>(?:Lei(?:\s+Complementar)?|LC|Decreto(?:-Lei)?)\s*(?:n\.?\s*[º°o]?\.?\s*)?(?P<leinum>\d{1,3}(?:\.\d{3})+|\d{1,5})(?:\s*/\s*\d{2,4})?)`
+)
+
+var (
+	dispositivoDiplomaAlts = dispositivoDiplomaPattern()
+	// "art. 37, § 6º, da CF" and "Lei 8.112/90, art. 41".
+	dispositivoCitacaoRe = regexp.MustCompile(`(?i)` + dispositivoArtigo + dispositivoDetalhes +
+		`\s*,?\s*\(?\s*(?:(?:d|n)[aoe]s?\s+)?(?:` + dispositivoDiplomaAlts + `)\b`)
+	dispositivoCitacaoInversaRe = regexp.MustCompile(`(?i)(?:` + dispositivoDiplomaAlts + `)\s*,?\s*(?:(?:em|n)\s*seu\s+|n[oa]\s+)?` + dispositivoArtigo)
+	dispositivoArtigoRe         = regexp.MustCompile(`(?i)` + dispositivoArtigo)
+	dispositivoNumeroRe         = regexp.MustCompile(`(?i)(\d{1,3}(?:\.\d{3})+|\d{1,4})(?:\s*(?:º|°|ª|o\b))?(?:-([a-z])\b)?`)
+	dispositivoSumulaRe         = regexp.MustCompile(`(?i)\b(?:s[úu]mula\s+(?P<vinculante>vinculante\s+)?|(?P<sv>SV)\s*)(?:n\.?\s*[º°o]?\.?\s*)?(?P<num>\d{1,4})\b(?:\s*,?\s*(?:d[oa]\s+)?(?P<tribunal>STF|STJ|TST|TSE|TCU)\b)?`)
+	dispositivoEstadualRe       = regexp.MustCompile(`(?i)^\s*(?:estadual|do\s+estado)`)
+	dispositivoTagRe            = regexp.MustCompile(`<[^>]*>`)
+	dispositivoEspacoRe         = regexp.MustCompile(`\s+`)
+)
+
+func dispositivoDiplomaPattern() string {
+	alts := make([]string, 0, len(dispositivoDiplomas)+1)
+	for i, d := range dispositivoDiplomas {
+		alts = append(alts, fmt.Sprintf("(?P<d%d>%s)", i, d.texto))
+	}
+	return strings.Join(append(alts, dispositivoLei), "|")
+}
+
+// dispositivoCitacao is a reference found in a question and how to find the
+// cited row in the vade-mecum.
+type dispositivoCitacao struct {
+	referencia string
+	busca      model.DispositivoBusca
+}
+
+// parseDispositivos finds the articles and súmulas cited in a question text,
+// which may be HTML. Articles cited without a law are taken from the law of
+// the disciplina, if any.
+func parseDispositivos(texto, disciplina string) []dispositivoCitacao {
+	texto = html.UnescapeString(dispositivoTagRe.ReplaceAllString(texto, " "))
+	texto = strings.TrimSpace(dispositivoEspacoRe.ReplaceAllString(strings.ReplaceAll(texto, "\u00a0", " "), " "))
+	if texto == "" {
+		return nil
+	}
+
+	var (
+		citacoes []dispositivoCitacao
+		vistas   = make(map[string]bool)
+		cobertos [][]int
+	)
+	add := func(chave, referencia string, busca model.DispositivoBusca) {
+		if vistas[chave] {
+			return
+		}
+		vistas[chave] = true
+		if runes := []rune(referencia); len(runes) > 200 {
+			referencia = strings.TrimSpace(string(runes[:200]))
+		}
+		citacoes = append(citacoes, dispositivoCitacao{referencia: referencia, busca: busca})
+	}
+	addArtigos := func(re *regexp.Regexp, match []int, diploma *dispositivoDiploma, lei string) {
+		referencia := texto[match[0]:match[1]]
+		nums := re.SubexpIndex("nums")
+		for _, num := range dispositivoNumeroRe.FindAllStringSubmatch(texto[match[2*nums]:match[2*nums+1]], -1) {
+			numero := strings.ReplaceAll(num[1], ".", "")
+			sufixo := strings.ToUpper(num[2])
+			switch {
+			case diploma != nil && diploma.constituicao:
+				add("cf:"+numero+sufixo, referencia, model.DispositivoBusca{
+					Fontes:      []string{model.DispositivoFonteConstituicao},
+					ArtigoTexto: dispositivoArtigoPattern(`^\s*art(igo)?\.?\s*`, numero, sufixo),
+				})
+			case diploma != nil:
+				add(diploma.diploma+":"+numero+sufixo, referencia, model.DispositivoBusca{
+					Fontes:  dispositivoFontesArtigo,
+					Diploma: diploma.diploma,
+					Excluir: diploma.excluir,
+					Artigo:  dispositivoArtigoPattern(`^\D*0*`, numero, sufixo),
+				})
+			case lei != "":
+				add("lei"+lei+":"+numero+sufixo, referencia, model.DispositivoBusca{
+					Fontes:  dispositivoFontesArtigo,
+					Diploma: `(^|[^0-9.])` + dispositivoNumeroPattern(lei) + `([^0-9]|$)`,
+					Artigo:  dispositivoArtigoPattern(`^\D*0*`, numero, sufixo),
+				})
+			}
+		}
+		cobertos = append(cobertos, match[:2])
+	}
+
+	for _, re := range []*regexp.Regexp{dispositivoCitacaoRe, dispositivoCitacaoInversaRe} {
+		nums := re.SubexpIndex("nums")
+		for _, match := range re.FindAllStringSubmatchIndex(texto, -1) {
+			// "art. 121 do CP, art. 5º da CF" has no citation of art. 5º of the CP.
+			if dispositivoCoberto(cobertos, match[2*nums]) {
+				continue
+			}
+			diploma, lei, fim := dispositivoDiplomaMatch(re, texto, match)
+			if diploma != nil && diploma.constituicao && dispositivoEstadualRe.MatchString(texto[fim:]) {
+				// Not linked, but no other diploma may claim the article.
+				cobertos = append(cobertos, match[:2])
+				continue
+			}
+			addArtigos(re, match, diploma, lei)
+		}
+	}
+
+	if padrao := dispositivoDisciplina(disciplina); padrao != nil {
+		for _, match := range dispositivoArtigoRe.FindAllStringSubmatchIndex(texto, -1) {
+			if !dispositivoCoberto(cobertos, match[0]) {
+				addArtigos(dispositivoArtigoRe, match, padrao, "")
+			}
+		}
+	}
+
+	vinculante := dispositivoSumulaRe.SubexpIndex("vinculante")
+	sv := dispositivoSumulaRe.SubexpIndex("sv")
+	num := dispositivoSumulaRe.SubexpIndex("num")
+	tribunal := dispositivoSumulaRe.SubexpIndex("tribunal")
+	for _, match := range dispositivoSumulaRe.FindAllStringSubmatchIndex(texto, -1) {
+		numero := texto[match[2*num]:match[2*num+1]]
+		busca := model.DispositivoBusca{
+			Fontes: []string{model.DispositivoFonteJurisprudencia},
+			Artigo: dispositivoArtigoPattern(`^\D*0*`, numero, ""),
+		}
+		chave := "sumula:" + numero
+		switch {
+		case match[2*vinculante] >= 0 || match[2*sv] >= 0:
+			busca.Diploma = `vinculante`
+			chave += ":sv"
+		case match[2*tribunal] >= 0:
+			sigla := strings.ToUpper(texto[match[2*tribunal]:match[2*tribunal+1]])
+			busca.Diploma = dispositivoTribunais[sigla]
+			busca.Excluir = `vinculante`
+			chave += ":" + sigla
+		default:
+			// Without the court the súmula is ambiguous.
+			continue
+		}
+		add(chave, texto[match[0]:match[1]], busca)
+	}
+
+	return citacoes
+}
+
+// dispositivoDiplomaMatch tells which law a citation matched: one of
+// dispositivoDiplomas or the number of a lei. It also returns where the law
+// name ends.
+func dispositivoDiplomaMatch(re *regexp.Regexp, texto string, match []int) (*dispositivoDiploma, string, int) {
+	for i := range dispositivoDiplomas {
+		if idx := re.SubexpIndex(fmt.Sprintf("d%d", i)); match[2*idx] >= 0 {
+			return &dispositivoDiplomas[i], "", match[2*idx+1]
+		}
+	}
+	lei := re.SubexpIndex("leinum")
+	return nil, strings.ReplaceAll(texto[match[2*lei]:match[2*lei+1]], ".", ""), match[2*re.SubexpIndex("lei")+1]
+}
+
+func dispositivoDisciplina(disciplina string) *dispositivoDiploma {
+	disciplina = strings.ToLower(disciplina)
+	if disciplina == "" {
+		return nil
+	}
+	for i, d := range dispositivoDiplomas {
+		if d.disciplina != nil && d.disciplina.MatchString(disciplina) {
+			return &dispositivoDiplomas[i]
+		}
+	}
+	return nil
+}
+
+func dispositivoCoberto(cobertos [][]int, pos int) bool {
+	for _, span := range cobertos {
+		if pos >= span[0] && pos < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// dispositivoNumeroPattern matches a number with or without the thousands
+// dot: "1228" matches "1.228".
+func dispositivoNumeroPattern(numero string) string {
+	if len(numero) <= 3 {
+		return numero
+	}
+	return numero[:len(numero)-3] + `\.?` + numero[len(numero)-3:]
+}
+
+// dispositivoArtigoPattern builds the PostgreSQL expression matching an
+// article number after prefix, with the ordinal sign and the letter of
+// inserted articles ("37-A"), without matching other articles that start
+// with the same digits.
+func dispositivoArtigoPattern(prefix, numero, sufixo string) string {
+	pattern := prefix + dispositivoNumeroPattern(numero) + `(?![0-9]|\.[0-9])\s*(º|°|o)?`
+	if sufixo != "" {
+		return pattern + `\s*-?\s*` + sufixo + `(?![0-9a-z])`
+	}
+	return pattern + `(?![a-z]|\s*-\s*[a-z]\M)`
+}
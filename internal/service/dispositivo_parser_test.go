@@ -0,0 +1,167 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thepantheon/api/internal/model"
+)
+
+func TestParseDispositivos(t *testing.T) {
+	constituicao := func(numero string) model.DispositivoBusca {
+		return model.DispositivoBusca{
+			Fontes:      []string{model.DispositivoFonteConstituicao},
+			ArtigoTexto: dispositivoArtigoPattern(`^\s*art(igo)?\.?\s*`, numero, ""),
+		}
+	}
+	codigo := func(diploma, excluir, numero, sufixo string) model.DispositivoBusca {
+		return model.DispositivoBusca{
+			Fontes:  dispositivoFontesArtigo,
+			Diploma: diploma,
+			Excluir: excluir,
+			Artigo:  dispositivoArtigoPattern(`^\D*0*`, numero, sufixo),
+		}
+	}
+	lei := func(diploma, numero, sufixo string) model.DispositivoBusca {
+		return codigo(diploma, "", numero, sufixo)
+	}
+	sumula := func(diploma, excluir, numero string) model.DispositivoBusca {
+		return model.DispositivoBusca{
+			Fontes:  []string{model.DispositivoFonteJurisprudencia},
+			Diploma: diploma,
+			Excluir: excluir,
+			Artigo:  dispositivoArtigoPattern(`^\D*0*`, numero, ""),
+		}
+	}
+	const (
+		penal   = `^\s*codigo penal`
+		civil   = `^\s*codigo civil`
+		lei8112 = `(^|[^0-9.])8\.?112([^0-9]|$)`
+	)
+
+	tests := []struct {
+		name       string
+		texto      string
+		disciplina string
+		want       []dispositivoCitacao
+	}{
+		{
+			name:  "article with paragraph",
+			texto: "Nos termos do art. 37, § 6º, da CF, a responsabilidade é objetiva.",
+			want:  []dispositivoCitacao{{"art. 37, § 6º, da CF", constituicao("37")}},
+		},
+		{
+			name:  "each article takes its own law",
+			texto: "art. 121 do CP, art. 5º da CF",
+			want: []dispositivoCitacao{
+				{"art. 121 do CP", codigo(penal, "militar", "121", "")},
+				{"art. 5º da CF", constituicao("5")},
+			},
+		},
+		{
+			name:  "inverse citation of a lei",
+			texto: "Segundo a Lei 8.112/90, art. 41, a remuneração é irredutível.",
+			want:  []dispositivoCitacao{{"Lei 8.112/90, art. 41", lei(lei8112, "41", "")}},
+		},
+		{
+			name:  "inserted article",
+			texto: "art. 37-A da Lei 8.112/90",
+			want:  []dispositivoCitacao{{"art. 37-A da Lei 8.112/90", lei(lei8112, "37", "A")}},
+		},
+		{
+			name:  "several articles",
+			texto: "arts. 1.228 e 1.229 do Código Civil",
+			want: []dispositivoCitacao{
+				{"arts. 1.228 e 1.229 do Código Civil", codigo(civil, "", "1228", "")},
+				{"arts. 1.228 e 1.229 do Código Civil", codigo(civil, "", "1229", "")},
+			},
+		},
+		{
+			name:  "longer diploma names win",
+			texto: "art. 9º do Código Penal Militar",
+			want:  []dispositivoCitacao{{"art. 9º do Código Penal Militar", codigo(`^\s*codigo penal militar`, "", "9", "")}},
+		},
+		{
+			name:       "state constitution is skipped without falling back to the disciplina",
+			texto:      "art. 25 da Constituição Estadual",
+			disciplina: "Direito Constitucional",
+		},
+		{
+			name:       "article without a law uses the disciplina",
+			texto:      "Conforme o art. 121, matar alguém é crime.",
+			disciplina: "Direito Penal",
+			want:       []dispositivoCitacao{{"art. 121", codigo(penal, "militar", "121", "")}},
+		},
+		{
+			name:  "article without a law or disciplina",
+			texto: "Conforme o art. 121, matar alguém é crime.",
+		},
+		{
+			name:  "súmula of a court",
+			texto: "Súmula 7 do STJ",
+			want:  []dispositivoCitacao{{"Súmula 7 do STJ", sumula(`\mstj\M|superior tribunal de justica`, "vinculante", "7")}},
+		},
+		{
+			name:  "binding súmula",
+			texto: "Súmula Vinculante 13 e SV 11",
+			want: []dispositivoCitacao{
+				{"Súmula Vinculante 13", sumula("vinculante", "", "13")},
+				{"SV 11", sumula("vinculante", "", "11")},
+			},
+		},
+		{
+			name:  "súmula with no court",
+			texto: "A Súmula 7 veda o reexame de provas.",
+		},
+		{
+			name:  "HTML and repeated citations",
+			texto: "<p>art.&nbsp;37 da CF</p><p>Ver art. 37 da Constituição Federal.</p>",
+			want:  []dispositivoCitacao{{"art. 37 da CF", constituicao("37")}},
+		},
+		{
+			name:  "empty",
+			texto: "<p> </p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDispositivos(tt.texto, tt.disciplina)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDispositivos(%q)\n got %+v\nwant %+v", tt.texto, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispositivoArtigoPattern(t *testing.T) {
+	tests := []struct {
+		prefix, numero, sufixo string
+		want                   string
+	}{
+		{`^\D*0*`, "5", "", `^\D*0*5(?![0-9]|\.[0-9])\s*(º|°|o)?(?![a-z]|\s*-\s*[a-z]\M)`},
+		{`^\D*0*`, "1228", "", `^\D*0*1\.?228(?![0-9]|\.[0-9])\s*(º|°|o)?(?![a-z]|\s*-\s*[a-z]\M)`},
+		{`^\D*0*`, "37", "A", `^\D*0*37(?![0-9]|\.[0-9])\s*(º|°|o)?\s*-?\s*A(?![0-9a-z])`},
+		{`^\s*art(igo)?\.?\s*`, "100", "", `^\s*art(igo)?\.?\s*100(?![0-9]|\.[0-9])\s*(º|°|o)?(?![a-z]|\s*-\s*[a-z]\M)`},
+	}
+	for _, tt := range tests {
+		if got := dispositivoArtigoPattern(tt.prefix, tt.numero, tt.sufixo); got != tt.want {
+			t.Errorf("dispositivoArtigoPattern(%q, %q, %q)\n got %s\nwant %s", tt.prefix, tt.numero, tt.sufixo, got, tt.want)
+		}
+	}
+}
+
+func TestDispositivoNumeroPattern(t *testing.T) {
+	for numero, want := range map[string]string{
+		"5":     "5",
+		"121":   "121",
+		"1228":  `1\.?228`,
+		"10406": `10\.?406`,
+	} {
+		if got := dispositivoNumeroPattern(numero); got != want {
+			t.Errorf("dispositivoNumeroPattern(%q) = %s, want %s", numero, got, want)
+		}
+	}
+}
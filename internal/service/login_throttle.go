@@ -0,0 +1,270 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+)
+
+var ErrTooManyAttempts = errors.New("too many failed attempts, try again later")
+
+// LoginThrottledError is returned while a key is backing off or locked out.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s (retry in %ds)", ErrTooManyAttempts.Error(), int(e.RetryAfter.Seconds()+0.5))
+}
+
+func (e *LoginThrottledError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// LoginAttemptStore keeps the failure counters used by LoginThrottle. Get
+// returns nil without error for unknown keys.
+type LoginAttemptStore interface {
+	Get(key string) (*model.LoginAttempt, error)
+	RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginAttempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	DeleteStale(before time.Time) error
+}
+
+// ThrottlePolicy describes how one kind of key is throttled: the first
+// FreeAttempts failures cost nothing, the next ones lock the key for an
+// exponentially growing delay (BaseDelay doubled each time, up to MaxDelay)
+// and reaching LockoutAfter failures locks it for LockoutDuration.
+type ThrottlePolicy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAfter    int
+	LockoutDuration time.Duration
+}
+
+func (p ThrottlePolicy) delay(failures int) (time.Duration, bool) {
+	if p.LockoutAfter > 0 && failures >= p.LockoutAfter {
+		return p.LockoutDuration, true
+	}
+	if failures <= p.FreeAttempts {
+		return 0, false
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay, false
+}
+
+// LoginThrottle protects credential checks (password login, second factor
+// and admin secret) with per-account and per-IP counters.
+type LoginThrottle struct {
+	store         LoginAttemptStore
+	audit         *AuditService
+	accountPolicy ThrottlePolicy
+	ipPolicy      ThrottlePolicy
+	window        time.Duration
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+func NewLoginThrottle(store LoginAttemptStore, audit *AuditService, accountPolicy, ipPolicy ThrottlePolicy, window time.Duration) *LoginThrottle {
+	return &LoginThrottle{
+		store:         store,
+		audit:         audit,
+		accountPolicy: accountPolicy,
+		ipPolicy:      ipPolicy,
+		window:        window,
+		lastCleanup:   time.Now(),
+	}
+}
+
+// Allow returns a *LoginThrottledError while the account or the IP is
+// locked. An empty account only checks the IP.
+func (t *LoginThrottle) Allow(account, ip string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, key := range t.keys(account, ip) {
+		attempt, err := t.store.Get(key)
+		if err != nil {
+			// Fail open: a store outage must not lock everybody out.
+			log.Printf("login throttle: failed to read %s: %v", key, err)
+			continue
+		}
+		if attempt != nil && attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			if wait := attempt.LockedUntil.Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RegisterFailure counts a failed attempt and applies backoff or lockout.
+// Lockouts are written to the audit log with the given action.
+func (t *LoginThrottle) RegisterFailure(account, ip, auditAction string) {
+	now := time.Now()
+	for _, key := range t.keys(account, ip) {
+		policy := t.ipPolicy
+		if strings.HasPrefix(key, "account:") {
+			policy = t.accountPolicy
+		}
+
+		attempt, err := t.store.RegisterFailure(key, now, t.window)
+		if err != nil {
+			log.Printf("login throttle: failed to register failure for %s: %v", key, err)
+			continue
+		}
+
+		delay, lockout := policy.delay(attempt.Failures)
+		if delay <= 0 {
+			continue
+		}
+		if err := t.store.Lock(key, now.Add(delay)); err != nil {
+			log.Printf("login throttle: failed to lock %s: %v", key, err)
+			continue
+		}
+		if lockout {
+			t.audit.Record(auditAction, nil, ip, map[string]interface{}{
+				"key":          key,
+				"failures":     attempt.Failures,
+				"locked_until": now.Add(delay).UTC().Format(time.RFC3339),
+			})
+		}
+	}
+
+	t.cleanup(now)
+}
+
+// RegisterSuccess clears the account counter. The IP counter is kept, so a
+// valid login cannot be used to reset the budget of an attacking address.
+func (t *LoginThrottle) RegisterSuccess(account string) {
+	if account == "" {
+		return
+	}
+	if err := t.store.Reset(accountKey(account)); err != nil {
+		log.Printf("login throttle: failed to reset %s: %v", accountKey(account), err)
+	}
+}
+
+func (t *LoginThrottle) keys(account, ip string) []string {
+	keys := make([]string, 0, 2)
+	if account != "" {
+		keys = append(keys, accountKey(account))
+	}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func (t *LoginThrottle) cleanup(now time.Time) {
+	t.mu.Lock()
+	if now.Sub(t.lastCleanup) < t.window {
+		t.mu.Unlock()
+		return
+	}
+	t.lastCleanup = now
+	t.mu.Unlock()
+
+	go func() {
+		if err := t.store.DeleteStale(now.Add(-t.window)); err != nil {
+			log.Printf("login throttle: cleanup failed: %v", err)
+		}
+	}()
+}
+
+func accountKey(account string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(account))
+}
+
+// MemoryLoginAttemptStore is an in-process LoginAttemptStore, suitable for a
+// single API instance and for development.
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*model.LoginAttempt
+}
+
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{attempts: make(map[string]*model.LoginAttempt)}
+}
+
+func (s *MemoryLoginAttemptStore) Get(key string) (*model.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *MemoryLoginAttemptStore) RegisterFailure(key string, now time.Time, window time.Duration) (*model.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		attempt = &model.LoginAttempt{Key: key}
+		s.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.Before(now.Add(-window)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	attempt.UpdatedAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *MemoryLoginAttemptStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[key]; ok {
+		attempt.LockedUntil = &until
+		attempt.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (s *MemoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+func (s *MemoryLoginAttemptStore) DeleteStale(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, attempt := range s.attempts {
+		locked := attempt.LockedUntil != nil && attempt.LockedUntil.After(now)
+		if attempt.LastFailureAt.Before(before) && !locked {
+			delete(s.attempts, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newDryRunDB returns a gorm handle that builds statements without ever
+// connecting, for services whose side effects the test does not check.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := sql.Open("pgx", "host=127.0.0.1 dbname=dry_run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestThrottlePolicyDelay(t *testing.T) {
+	policy := ThrottlePolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        8 * time.Second,
+		LockoutAfter:    10,
+		LockoutDuration: 15 * time.Minute,
+	}
+	tests := []struct {
+		failures    int
+		wantDelay   time.Duration
+		wantLockout bool
+	}{
+		{0, 0, false},
+		{1, 0, false},
+		{3, 0, false},
+		{4, time.Second, false},
+		{5, 2 * time.Second, false},
+		{6, 4 * time.Second, false},
+		{7, 8 * time.Second, false},
+		{9, 8 * time.Second, false},
+		{10, 15 * time.Minute, true},
+		{50, 15 * time.Minute, true},
+	}
+	for _, tt := range tests {
+		delay, lockout := policy.delay(tt.failures)
+		if delay != tt.wantDelay || lockout != tt.wantLockout {
+			t.Errorf("delay(%d) = (%s, %v), want (%s, %v)", tt.failures, delay, lockout, tt.wantDelay, tt.wantLockout)
+		}
+	}
+}
+
+func TestThrottlePolicyDelayWithoutLockout(t *testing.T) {
+	policy := ThrottlePolicy{FreeAttempts: 0, BaseDelay: time.Second, MaxDelay: time.Minute}
+	if delay, lockout := policy.delay(1000); delay != time.Minute || lockout {
+		t.Errorf("delay(1000) = (%s, %v), want (1m0s, false)", delay, lockout)
+	}
+}
+
+func TestMemoryLoginAttemptStoreCountsWithinWindow(t *testing.T) {
+	store := NewMemoryLoginAttemptStore()
+	now := time.Now()
+	window := 10 * time.Minute
+
+	for i := 1; i <= 3; i++ {
+		attempt, err := store.RegisterFailure("ip:1.2.3.4", now.Add(time.Duration(i)*time.Minute), window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempt.Failures != i {
+			t.Fatalf("failure %d counted as %d", i, attempt.Failures)
+		}
+	}
+
+	// A failure after a quiet window starts counting again.
+	attempt, err := store.RegisterFailure("ip:1.2.3.4", now.Add(3*time.Minute+window+time.Second), window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempt.Failures != 1 {
+		t.Errorf("failures after the window = %d, want 1", attempt.Failures)
+	}
+}
+
+func TestMemoryLoginAttemptStoreReturnsCopies(t *testing.T) {
+	store := NewMemoryLoginAttemptStore()
+	if attempt, err := store.Get("account:ana@example.com"); err != nil || attempt != nil {
+		t.Fatalf("Get of an unknown key = (%v, %v), want (nil, nil)", attempt, err)
+	}
+
+	attempt, _ := store.RegisterFailure("account:ana@example.com", time.Now(), time.Hour)
+	attempt.Failures = 100
+
+	stored, _ := store.Get("account:ana@example.com")
+	if stored.Failures != 1 {
+		t.Errorf("changing a returned attempt changed the store: failures = %d", stored.Failures)
+	}
+}
+
+func TestMemoryLoginAttemptStoreLockResetAndCleanup(t *testing.T) {
+	store := NewMemoryLoginAttemptStore()
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+
+	store.RegisterFailure("ip:stale", old, time.Hour)
+	store.RegisterFailure("ip:locked", old, time.Hour)
+	store.RegisterFailure("ip:recent", now, time.Hour)
+	store.RegisterFailure("account:reset", now, time.Hour)
+
+	until := now.Add(time.Hour)
+	if err := store.Lock("ip:locked", until); err != nil {
+		t.Fatal(err)
+	}
+	if locked, _ := store.Get("ip:locked"); locked.LockedUntil == nil || !locked.LockedUntil.Equal(until) {
+		t.Errorf("LockedUntil = %v, want %v", locked.LockedUntil, until)
+	}
+
+	if err := store.Reset("account:reset"); err != nil {
+		t.Fatal(err)
+	}
+	if attempt, _ := store.Get("account:reset"); attempt != nil {
+		t.Error("Reset kept the attempt")
+	}
+
+	if err := store.DeleteStale(now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]bool{"ip:stale": false, "ip:locked": true, "ip:recent": true} {
+		attempt, _ := store.Get(key)
+		if (attempt != nil) != want {
+			t.Errorf("after DeleteStale %s kept = %v, want %v", key, attempt != nil, want)
+		}
+	}
+}
+
+func newTestLoginThrottle(t *testing.T) *LoginThrottle {
+	audit := NewAuditService(repository.NewAuditLogRepository(newDryRunDB(t)))
+	accountPolicy := ThrottlePolicy{
+		FreeAttempts:    2,
+		BaseDelay:       time.Minute,
+		MaxDelay:        10 * time.Minute,
+		LockoutAfter:    4,
+		LockoutDuration: time.Hour,
+	}
+	ipPolicy := ThrottlePolicy{
+		FreeAttempts: 5,
+		BaseDelay:    time.Minute,
+		MaxDelay:     10 * time.Minute,
+	}
+	return NewLoginThrottle(NewMemoryLoginAttemptStore(), audit, accountPolicy, ipPolicy, time.Hour)
+}
+
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+	var throttled *LoginThrottledError
+	if !errors.As(err, &throttled) {
+		t.Fatalf("err = %v, want a *LoginThrottledError", err)
+	}
+	if !errors.Is(err, ErrTooManyAttempts) {
+		t.Error("LoginThrottledError does not match ErrTooManyAttempts")
+	}
+	return throttled.RetryAfter
+}
+
+func TestLoginThrottleBacksOffAndLocksOutTheAccount(t *testing.T) {
+	throttle := newTestLoginThrottle(t)
+
+	for i := 0; i < 2; i++ {
+		throttle.RegisterFailure("ana@example.com", "10.0.0.1", model.AuditLoginLockout)
+	}
+	if err := throttle.Allow("ana@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("free attempts must not throttle: %v", err)
+	}
+
+	throttle.RegisterFailure("ana@example.com", "10.0.0.1", model.AuditLoginLockout)
+	if wait := retryAfter(t, throttle.Allow("ana@example.com", "10.0.0.2")); wait <= 0 || wait > time.Minute {
+		t.Errorf("backoff = %s, want up to 1m", wait)
+	}
+
+	// Keys are normalized, so changing the case of the e-mail does not help.
+	throttle.RegisterFailure(" ANA@example.com ", "10.0.0.2", model.AuditLoginLockout)
+	if wait := retryAfter(t, throttle.Allow("ana@example.com", "10.0.0.3")); wait <= 10*time.Minute {
+		t.Errorf("lockout = %s, want about 1h", wait)
+	}
+
+	if err := throttle.Allow("bia@example.com", "10.0.0.3"); err != nil {
+		t.Errorf("other accounts must not be throttled: %v", err)
+	}
+}
+
+func TestLoginThrottleSuccessKeepsTheIPCounter(t *testing.T) {
+	throttle := newTestLoginThrottle(t)
+
+	// Spread over accounts, the failures only add up on the IP.
+	for i := 0; i < 6; i++ {
+		throttle.RegisterFailure(string(rune('a'+i))+"@example.com", "10.0.0.9", model.AuditLoginLockout)
+	}
+	retryAfter(t, throttle.Allow("", "10.0.0.9"))
+
+	throttle.RegisterSuccess("a@example.com")
+	retryAfter(t, throttle.Allow("a@example.com", "10.0.0.9"))
+
+	if err := throttle.Allow("a@example.com", "10.0.0.10"); err != nil {
+		t.Errorf("the account counter should have been reset: %v", err)
+	}
+}
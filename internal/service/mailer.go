@@ -0,0 +1,106 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MailMessage is a plain-text e-mail.
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional e-mails (password reset, verification...).
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+type SMTPMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     host + ":" + port,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (m *SMTPMailer) Send(msg MailMessage) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(m.addr, auth, envelopeAddress(m.from), []string{msg.To}, buildMIMEMessage(m.from, msg)); err != nil {
+		return fmt.Errorf("failed to send e-mail: %w", err)
+	}
+	return nil
+}
+
+// LogMailer writes e-mails to the application log and, when outputDir is set,
+// stores them as .eml files so they can be opened locally.
+type LogMailer struct {
+	outputDir string
+	from      string
+}
+
+func NewLogMailer(outputDir, from string) *LogMailer {
+	return &LogMailer{outputDir: outputDir, from: from}
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]`)
+
+func (m *LogMailer) Send(msg MailMessage) error {
+	log.Printf("mail to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+
+	if m.outputDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(m.outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000000"), unsafeFilenameChars.ReplaceAllString(msg.To, "_"))
+	if err := os.WriteFile(filepath.Join(m.outputDir, name), buildMIMEMessage(m.from, msg), 0o644); err != nil {
+		return fmt.Errorf("failed to write e-mail: %w", err)
+	}
+	return nil
+}
+
+func buildMIMEMessage(from string, msg MailMessage) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func envelopeAddress(from string) string {
+	if start := strings.Index(from, "<"); start >= 0 {
+		if end := strings.Index(from[start:], ">"); end > 0 {
+			return from[start+1 : start+end]
+		}
+	}
+	return from
+}
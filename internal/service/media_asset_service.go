@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+type MediaAssetService struct {
+	repo *repository.MediaAssetRepository
+}
+
+func NewMediaAssetService(repo *repository.MediaAssetRepository) *MediaAssetService {
+	return &MediaAssetService{repo: repo}
+}
+
+func (s *MediaAssetService) Create(kind, filename, contentType string, data []byte) (*model.MediaAsset, error) {
+	asset := &model.MediaAsset{
+		Kind:        kind,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Data:        data,
+	}
+	if err := s.repo.Create(asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (s *MediaAssetService) GetByID(id uuid.UUID) (*model.MediaAsset, error) {
+	return s.repo.GetByID(id.String())
+}
+
+func (s *MediaAssetService) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id.String())
+}
+
+// URL returns the public path that serves the asset.
+func (s *MediaAssetService) URL(id uuid.UUID) string {
+	return "/api/v1/media/" + id.String()
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	defaultNotificacaoLimit = 20
+	maxNotificacaoLimit     = 100
+)
+
+var ErrNotificacaoNotFound = errors.New("notificacao nao encontrada")
+
+// NotificacaoService keeps the users' in-app notifications and mirrors them
+// by e-mail.
+type NotificacaoService struct {
+	repo        *repository.NotificacaoRepository
+	userRepo    *repository.UserRepository
+	mailer      Mailer
+	frontendURL string
+}
+
+func NewNotificacaoService(repo *repository.NotificacaoRepository, userRepo *repository.UserRepository, mailer Mailer, frontendURL string) *NotificacaoService {
+	return &NotificacaoService{
+		repo:        repo,
+		userRepo:    userRepo,
+		mailer:      mailer,
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+// Notify stores a notification for the user and e-mails it. Like auditing,
+// notifying never fails the caller: errors are only logged.
+func (s *NotificacaoService) Notify(userID uuid.UUID, tipo, titulo, mensagem, link string) {
+	item := &model.Notificacao{
+		UserID:   userID,
+		Tipo:     tipo,
+		Titulo:   titulo,
+		Mensagem: mensagem,
+	}
+	if link != "" {
+		item.Link = &link
+	}
+	if err := s.repo.Create(item); err != nil {
+		log.Printf("failed to store notification %s for user %s: %v", tipo, userID, err)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		log.Printf("failed to load user %s to notify: %v", userID, err)
+		return
+	}
+	body := "Olá, " + user.FullName + "!\n\n" + mensagem
+	if link != "" {
+		body += "\n\n" + s.frontendURL + link
+	}
+	if err := s.mailer.Send(MailMessage{To: user.Email, Subject: titulo, Body: body}); err != nil {
+		log.Printf("failed to e-mail notification %s to %s: %v", tipo, user.Email, err)
+	}
+}
+
+// List returns the user's notifications, newest first. limit defaults to 20
+// and is capped at 100.
+func (s *NotificacaoService) List(userID uuid.UUID, naoLidas bool, limit, offset int) (*model.NotificacaoList, error) {
+	if limit <= 0 {
+		limit = defaultNotificacaoLimit
+	}
+	if limit > maxNotificacaoLimit {
+		limit = maxNotificacaoLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, total, unread, err := s.repo.ListByUser(userID, naoLidas, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.Notificacao{}
+	}
+	return &model.NotificacaoList{Data: items, Total: total, NaoLidas: unread, Limit: limit, Offset: offset}, nil
+}
+
+func (s *NotificacaoService) MarkRead(userID, id uuid.UUID) error {
+	found, err := s.repo.MarkRead(userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificacaoNotFound
+	}
+	return nil
+}
+
+func (s *NotificacaoService) MarkAllRead(userID uuid.UUID) (int64, error) {
+	return s.repo.MarkAllRead(userID, time.Now())
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/thepantheon/api/internal/model"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/facebook"
+	"golang.org/x/oauth2/google"
+)
+
+// OAuthProvider is a social login provider registered in SocialAuthService.
+type OAuthProvider interface {
+	Name() string
+	// Config returns the OAuth2 client configuration (endpoints may be
+	// resolved lazily, e.g. through OIDC discovery).
+	Config(ctx context.Context) (*oauth2.Config, error)
+	// AuthCodeOptions returns extra authorization parameters; nonce is bound
+	// to the current state and must be echoed back in OIDC ID tokens.
+	AuthCodeOptions(nonce string) []oauth2.AuthCodeOption
+	// UserInfoFromToken resolves the user after the authorization code exchange.
+	UserInfoFromToken(ctx context.Context, token *oauth2.Token, nonce string) (*model.SocialUserInfo, error)
+	// UserInfo resolves the user from tokens obtained by the client. OIDC
+	// providers require the ID token; the others ignore it.
+	UserInfo(ctx context.Context, accessToken, idToken string) (*model.SocialUserInfo, error)
+}
+
+type googleProvider struct {
+	config *oauth2.Config
+}
+
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) OAuthProvider {
+	return &googleProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL + "/auth/google/callback",
+			Scopes: []string{
+				"https://www.googleapis.com/auth/userinfo.email",
+				"https://www.googleapis.com/auth/userinfo.profile",
+			},
+			Endpoint: google.Endpoint,
+		},
+	}
+}
+
+func (p *googleProvider) Name() string {
+	return "google"
+}
+
+func (p *googleProvider) Config(ctx context.Context) (*oauth2.Config, error) {
+	return p.config, nil
+}
+
+func (p *googleProvider) AuthCodeOptions(nonce string) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{oauth2.AccessTypeOffline}
+}
+
+func (p *googleProvider) UserInfoFromToken(ctx context.Context, token *oauth2.Token, nonce string) (*model.SocialUserInfo, error) {
+	return p.UserInfo(ctx, token.AccessToken, "")
+}
+
+func (p *googleProvider) UserInfo(ctx context.Context, accessToken, idToken string) (*model.SocialUserInfo, error) {
+	resp, err := http.Get("https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + url.QueryEscape(accessToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("google API error: %s", string(body))
+	}
+
+	var data struct {
+		ID      string `json:"id"`
+		Email   string `json:"email"`
+		Name    string `json:"name"`
+		Picture string `json:"picture"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &model.SocialUserInfo{
+		ID:       data.ID,
+		Email:    data.Email,
+		Name:     data.Name,
+		Picture:  data.Picture,
+		Provider: "google",
+	}, nil
+}
+
+type facebookProvider struct {
+	config *oauth2.Config
+}
+
+func NewFacebookProvider(appID, appSecret, redirectURL string) OAuthProvider {
+	return &facebookProvider{
+		config: &oauth2.Config{
+			ClientID:     appID,
+			ClientSecret: appSecret,
+			RedirectURL:  redirectURL + "/auth/facebook/callback",
+			Scopes:       []string{"email", "public_profile"},
+			Endpoint:     facebook.Endpoint,
+		},
+	}
+}
+
+func (p *facebookProvider) Name() string {
+	return "facebook"
+}
+
+func (p *facebookProvider) Config(ctx context.Context) (*oauth2.Config, error) {
+	return p.config, nil
+}
+
+func (p *facebookProvider) AuthCodeOptions(nonce string) []oauth2.AuthCodeOption {
+	return nil
+}
+
+func (p *facebookProvider) UserInfoFromToken(ctx context.Context, token *oauth2.Token, nonce string) (*model.SocialUserInfo, error) {
+	return p.UserInfo(ctx, token.AccessToken, "")
+}
+
+func (p *facebookProvider) UserInfo(ctx context.Context, accessToken, idToken string) (*model.SocialUserInfo, error) {
+	endpoint := fmt.Sprintf("https://graph.facebook.com/me?fields=id,name,email,picture&access_token=%s", url.QueryEscape(accessToken))
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("facebook API error: %s", string(body))
+	}
+
+	var data struct {
+		ID      string `json:"id"`
+		Email   string `json:"email"`
+		Name    string `json:"name"`
+		Picture struct {
+			Data struct {
+				URL string `json:"url"`
+			} `json:"data"`
+		} `json:"picture"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &model.SocialUserInfo{
+		ID:       data.ID,
+		Email:    data.Email,
+		Name:     data.Name,
+		Picture:  data.Picture.Data.URL,
+		Provider: "facebook",
+	}, nil
+}
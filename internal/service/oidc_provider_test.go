@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fakeOIDCClientID    = "pantheon-web"
+	fakeOIDCCode        = "good-code"
+	fakeOIDCAccessToken = "access-123"
+	fakeOIDCKid         = "key-1"
+)
+
+var (
+	fakeOIDCKeyOnce sync.Once
+	fakeOIDCKey     *rsa.PrivateKey
+)
+
+// fakeIssuer is a local OpenID Connect issuer serving discovery, JWKS, token
+// and userinfo endpoints. Tests change its fields to misbehave.
+type fakeIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// advertisedIssuer is the issuer of the discovery document; empty means
+	// the server URL.
+	advertisedIssuer string
+	// idToken returns the claims of the ID token sent by the token endpoint.
+	idToken  func() jwt.MapClaims
+	userinfo map[string]interface{}
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	fakeOIDCKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		fakeOIDCKey = key
+	})
+
+	f := &fakeIssuer{t: t, key: fakeOIDCKey}
+	f.idToken = func() jwt.MapClaims { return f.claims("user-1", "nonce-1") }
+	f.userinfo = map[string]interface{}{
+		"sub":            "user-1",
+		"email":          "ana@example.com",
+		"email_verified": true,
+		"name":           "Ana",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := f.advertisedIssuer
+		if issuer == "" {
+			issuer = f.server.URL
+		}
+		writeJSON(w, map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": f.server.URL + "/authorize",
+			"token_endpoint":         f.server.URL + "/token",
+			"userinfo_endpoint":      f.server.URL + "/userinfo",
+			"jwks_uri":               f.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub := f.key.PublicKey
+		writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kid": fakeOIDCKid,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != fakeOIDCCode {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"access_token": fakeOIDCAccessToken,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     f.sign(f.idToken()),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+fakeOIDCAccessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, f.userinfo)
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+// claims returns valid ID token claims for this issuer and fakeOIDCClientID.
+func (f *fakeIssuer) claims(sub, nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"sub":            sub,
+		"aud":            fakeOIDCClientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          nonce,
+		"email":          "ana@example.com",
+		"email_verified": true,
+		"name":           "Ana",
+	}
+}
+
+func (f *fakeIssuer) sign(claims jwt.MapClaims) string {
+	f.t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = fakeOIDCKid
+	signed, err := token.SignedString(f.key)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return signed
+}
+
+func (f *fakeIssuer) provider() *oidcProvider {
+	return NewOIDCProvider("fake", f.server.URL, fakeOIDCClientID, "secret", nil, "http://localhost:8080/api/v1").(*oidcProvider)
+}
+
+// login runs the authorization code flow against the issuer.
+func (f *fakeIssuer) login(p *oidcProvider, nonce string) (string, error) {
+	ctx := context.Background()
+	config, err := p.Config(ctx)
+	if err != nil {
+		return "", err
+	}
+	token, err := config.Exchange(ctx, fakeOIDCCode)
+	if err != nil {
+		return "", err
+	}
+	info, err := p.UserInfoFromToken(ctx, token, nonce)
+	if err != nil {
+		return "", err
+	}
+	return info.ID + " " + info.Email, nil
+}
+
+func TestOIDCProviderCodeFlow(t *testing.T) {
+	f := newFakeIssuer(t)
+	p := f.provider()
+
+	got, err := f.login(p, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "user-1 ana@example.com" {
+		t.Errorf("got %q", got)
+	}
+
+	config, err := p.Config(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Endpoint.AuthURL != f.server.URL+"/authorize" || config.RedirectURL != "http://localhost:8080/api/v1/auth/fake/callback" {
+		t.Errorf("unexpected config: %+v", config)
+	}
+}
+
+func TestOIDCProviderCodeFlowFetchesUserinfoWithoutEmail(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.idToken = func() jwt.MapClaims {
+		claims := f.claims("user-1", "nonce-1")
+		delete(claims, "email")
+		return claims
+	}
+	f.userinfo["email"] = "userinfo@example.com"
+
+	got, err := f.login(f.provider(), "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "user-1 userinfo@example.com" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestOIDCProviderAccessTokenFlow(t *testing.T) {
+	f := newFakeIssuer(t)
+	p := f.provider()
+
+	info, err := p.UserInfo(context.Background(), fakeOIDCAccessToken, f.sign(f.claims("user-1", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != "user-1" || info.Email != "ana@example.com" || info.Name != "Ana" || info.Provider != "fake" {
+		t.Errorf("unexpected user info: %+v", info)
+	}
+}
+
+func TestOIDCProviderRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(f *fakeIssuer)
+		nonce string
+	}{
+		{
+			name:  "discovery issuer mismatch",
+			setup: func(f *fakeIssuer) { f.advertisedIssuer = "https://evil.example.com" },
+		},
+		{
+			name: "id token from another issuer",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					claims["iss"] = "https://evil.example.com"
+					return claims
+				}
+			},
+		},
+		{
+			name: "wrong audience",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					claims["aud"] = "another-client"
+					return claims
+				}
+			},
+		},
+		{
+			name: "azp of another client",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					claims["aud"] = []string{fakeOIDCClientID, "another-client"}
+					claims["azp"] = "another-client"
+					return claims
+				}
+			},
+		},
+		{
+			name: "expired",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					claims["exp"] = time.Now().Add(-time.Hour).Unix()
+					return claims
+				}
+			},
+		},
+		{
+			name:  "bad nonce",
+			nonce: "nonce-2",
+		},
+		{
+			name: "missing nonce",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims { return f.claims("user-1", "") }
+			},
+		},
+		{
+			name: "userinfo subject mismatch",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					delete(claims, "email")
+					return claims
+				}
+				f.userinfo["sub"] = "user-2"
+			},
+		},
+		{
+			name: "unverified email",
+			setup: func(f *fakeIssuer) {
+				f.idToken = func() jwt.MapClaims {
+					claims := f.claims("user-1", "nonce-1")
+					claims["email_verified"] = "false"
+					return claims
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeIssuer(t)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			nonce := tt.nonce
+			if nonce == "" {
+				nonce = "nonce-1"
+			}
+			if got, err := f.login(f.provider(), nonce); err == nil {
+				t.Errorf("login succeeded as %q", got)
+			}
+		})
+	}
+}
+
+func TestOIDCProviderAccessTokenFlowRejects(t *testing.T) {
+	f := newFakeIssuer(t)
+	p := f.provider()
+	ctx := context.Background()
+
+	if _, err := p.UserInfo(ctx, fakeOIDCAccessToken, ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("missing id token: err = %v", err)
+	}
+
+	// The access token resolves user-1, but the ID token names user-2.
+	if _, err := p.UserInfo(ctx, fakeOIDCAccessToken, f.sign(f.claims("user-2", ""))); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("subject mismatch: err = %v", err)
+	}
+
+	// An ID token issued to another client of the same provider.
+	claims := f.claims("user-1", "")
+	claims["aud"] = "another-client"
+	if _, err := p.UserInfo(ctx, fakeOIDCAccessToken, f.sign(claims)); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("wrong audience: err = %v", err)
+	}
+
+	// Only asymmetric algorithms are accepted.
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, f.claims("user-1", "")).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.UserInfo(ctx, fakeOIDCAccessToken, hmac); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("HS256: err = %v", err)
+	}
+
+	// A key the issuer never published.
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, f.claims("user-1", ""))
+	forged.Header["kid"] = fakeOIDCKid
+	signed, err := forged.SignedString(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.UserInfo(ctx, fakeOIDCAccessToken, signed); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("forged signature: err = %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+// staleExportAfter is how long an export may stay pending or processing
+// before it is considered lost (e.g. the instance building it restarted).
+const staleExportAfter = 30 * time.Minute
+
+var (
+	ErrDataExportNotReady = errors.New("data export is not ready")
+	ErrDataExportExpired  = errors.New("data export has expired")
+
+	// piiKeys are the fields removed from stored Asaas payloads on erasure,
+	// compared case-insensitively. Identifiers, values, dates and statuses
+	// are kept so the financial records remain usable.
+	piiKeys = map[string]bool{
+		"name":                 true,
+		"email":                true,
+		"additionalemails":     true,
+		"phone":                true,
+		"mobilephone":          true,
+		"cpfcnpj":              true,
+		"company":              true,
+		"address":              true,
+		"addressnumber":        true,
+		"complement":           true,
+		"province":             true,
+		"postalcode":           true,
+		"city":                 true,
+		"cityname":             true,
+		"state":                true,
+		"country":              true,
+		"observations":         true,
+		"remoteip":             true,
+		"creditcard":           true,
+		"creditcardtoken":      true,
+		"creditcardholderinfo": true,
+	}
+)
+
+// PrivacyService implements the LGPD data subject rights: data portability
+// (asynchronous ZIP export) and erasure.
+type PrivacyService struct {
+	repo       *repository.PrivacyRepository
+	exportRepo *repository.DataExportRepository
+	audit      *AuditService
+	exportTTL  time.Duration
+}
+
+func NewPrivacyService(repo *repository.PrivacyRepository, exportRepo *repository.DataExportRepository, audit *AuditService, exportTTL time.Duration) *PrivacyService {
+	return &PrivacyService{
+		repo:       repo,
+		exportRepo: exportRepo,
+		audit:      audit,
+		exportTTL:  exportTTL,
+	}
+}
+
+// RequestExport starts building the data export of the user in the
+// background. While an export is in progress it is returned instead of
+// starting a new one.
+func (s *PrivacyService) RequestExport(userID uuid.UUID, ipAddress string) (*model.DataExport, error) {
+	if err := s.exportRepo.DeleteExpired(time.Now()); err != nil {
+		log.Printf("failed to delete expired data exports: %v", err)
+	}
+
+	current, err := s.exportRepo.GetInProgress(userID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		if time.Since(current.UpdatedAt) < staleExportAfter {
+			return current, nil
+		}
+		if err := s.exportRepo.UpdateColumns(current.ID, map[string]interface{}{
+			"status": model.DataExportFailed,
+			"error":  "export interrupted",
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	export := &model.DataExport{UserID: userID, Status: model.DataExportPending}
+	if err := s.exportRepo.Create(export); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(model.AuditDataExportRequested, &userID, ipAddress, map[string]interface{}{
+		"export_id": export.ID.String(),
+	})
+
+	go s.buildExport(export.ID, userID)
+	return export, nil
+}
+
+func (s *PrivacyService) ListExports(userID uuid.UUID) ([]model.DataExport, error) {
+	return s.exportRepo.ListByUser(userID)
+}
+
+func (s *PrivacyService) GetExport(userID, exportID uuid.UUID) (*model.DataExport, error) {
+	return s.exportRepo.GetByIDAndUser(exportID, userID)
+}
+
+// DownloadExport returns a finished, unexpired export with its archive.
+func (s *PrivacyService) DownloadExport(userID, exportID uuid.UUID) (*model.DataExport, error) {
+	export, err := s.exportRepo.GetWithData(exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != model.DataExportDone {
+		return nil, ErrDataExportNotReady
+	}
+	if export.ExpiresAt != nil && export.ExpiresAt.Before(time.Now()) {
+		return nil, ErrDataExportExpired
+	}
+	return export, nil
+}
+
+func (s *PrivacyService) buildExport(exportID, userID uuid.UUID) {
+	if err := s.exportRepo.UpdateColumns(exportID, map[string]interface{}{"status": model.DataExportProcessing}); err != nil {
+		log.Printf("data export %s: %v", exportID, err)
+	}
+
+	data, err := s.buildArchive(userID)
+	if err != nil {
+		log.Printf("data export %s failed: %v", exportID, err)
+		if err := s.exportRepo.UpdateColumns(exportID, map[string]interface{}{
+			"status": model.DataExportFailed,
+			"error":  err.Error(),
+		}); err != nil {
+			log.Printf("data export %s: %v", exportID, err)
+		}
+		return
+	}
+
+	now := time.Now()
+	if err := s.exportRepo.UpdateColumns(exportID, map[string]interface{}{
+		"status":       model.DataExportDone,
+		"data":         data,
+		"size":         len(data),
+		"error":        "",
+		"completed_at": now,
+		"expires_at":   now.Add(s.exportTTL),
+	}); err != nil {
+		log.Printf("data export %s: %v", exportID, err)
+	}
+}
+
+// buildArchive writes one JSON file per kind of record, plus the avatar
+// image and a manifest describing the files.
+func (s *PrivacyService) buildArchive(userID uuid.UUID) ([]byte, error) {
+	snapshot, err := s.repo.Snapshot(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"perfil.json", snapshot.User},
+		{"sessoes.json", snapshot.Sessions},
+		{"identidades.json", snapshot.Identities},
+		{"desempenho.json", snapshot.Performances},
+		{"tentativas.json", snapshot.Attempts},
+		{"simulados.json", snapshot.Simulados},
+		{"cadernos.json", snapshot.Cadernos},
+		{"revisao/agenda.json", snapshot.RevisaoCards},
+		{"revisao/configuracoes.json", snapshot.RevisaoConfig},
+		{"perfil_estudo.json", snapshot.PerfilEstudo},
+		{"respostas_discursivas.json", snapshot.Discursivas},
+		{"reportes.json", snapshot.Reportes},
+		{"notificacoes.json", snapshot.Notificacoes},
+		{"cursos/categorias.json", snapshot.CourseCategories},
+		{"cursos/cursos.json", snapshot.Courses},
+		{"cursos/modulos.json", snapshot.CourseModules},
+		{"cursos/itens.json", snapshot.CourseItems},
+		{"pagamentos/clientes.json", snapshot.AsaasCustomers},
+		{"pagamentos/cobrancas.json", snapshot.AsaasPayments},
+		{"auditoria.json", snapshot.AuditLogs},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	names := make([]string, 0, len(files)+1)
+	for _, file := range files {
+		if err := writeJSONFile(archive, file.name, file.data); err != nil {
+			return nil, err
+		}
+		names = append(names, file.name)
+	}
+
+	if snapshot.Avatar != nil {
+		name := "avatar" + avatarExtension(snapshot.Avatar.ContentType)
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(snapshot.Avatar.Data); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err := writeJSONFile(archive, "manifest.json", map[string]interface{}{
+		"usuario_id": userID,
+		"gerado_em":  time.Now().UTC(),
+		"arquivos":   names,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EraseUser erases the account of another user on behalf of an
+// administrator, who cannot erase their own account this way.
+func (s *PrivacyService) EraseUser(actorID, userID uuid.UUID, ipAddress, reason string) (*model.ErasureReport, error) {
+	if actorID == userID {
+		return nil, ErrCannotChangeSelf
+	}
+	return s.EraseAccount(userID, &actorID, ipAddress, reason)
+}
+
+// EraseAccount anonymizes and deletes the data of a user (see
+// PrivacyRepository.Erase) and writes an audit entry. actorID is the user who
+// asked for the erasure: the subject or an administrator. The last active
+// administrator cannot be erased.
+func (s *PrivacyService) EraseAccount(userID uuid.UUID, actorID *uuid.UUID, ipAddress, reason string) (*model.ErasureReport, error) {
+	report, err := s.repo.Erase(userID, redactPII)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrLastAdmin
+	}
+
+	metadata := map[string]interface{}{
+		"reason":               reason,
+		"anonymized_customers": report.AnonymizedCustomers,
+		"retained_payments":    report.RedactedPayments,
+		"deleted":              report.Deleted,
+	}
+	if actorID != nil {
+		metadata["actor_id"] = actorID.String()
+	}
+	s.audit.Record(model.AuditAccountErased, &userID, ipAddress, metadata)
+
+	return report, nil
+}
+
+// redactPII removes piiKeys from a JSON payload at any depth. Payloads that
+// are not valid JSON cannot be redacted selectively and are dropped.
+func redactPII(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return raw
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return ""
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return ""
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if piiKeys[strings.ToLower(key)] {
+				delete(v, key)
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func writeJSONFile(archive *zip.Writer, name string, data interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+func avatarExtension(contentType string) string {
+	if contentType == "image/jpeg" {
+		return ".jpg"
+	}
+	if extensions, err := mime.ExtensionsByType(contentType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ""
+}
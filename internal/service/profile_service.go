@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	maxAvatarSize = 2 << 20
+
+	// recentLoginWindow is how old the login behind the current session may
+	// be for accounts without a password to change their e-mail or password
+	// or to delete themselves.
+	recentLoginWindow = 5 * time.Minute
+)
+
+var (
+	ErrInvalidCurrentPassword = errors.New("current password is invalid")
+	ErrRecentLoginRequired    = errors.New("sign in again to confirm this change")
+	ErrEmailInUse             = errors.New("email already in use")
+	ErrInvalidAvatar          = errors.New("avatar must be a JPEG, PNG, WebP or GIF image")
+	ErrAvatarTooLarge         = errors.New("avatar must be at most 2MB")
+
+	avatarContentTypes = map[string]bool{
+		"image/jpeg": true,
+		"image/png":  true,
+		"image/webp": true,
+		"image/gif":  true,
+	}
+)
+
+// ProfileService implements the self-service /me endpoints. Every method
+// works on the user taken from the access token.
+type ProfileService struct {
+	userRepo       *repository.UserRepository
+	sessionRepo    *repository.UserSessionRepository
+	mediaService   *MediaAssetService
+	accountService *AccountService
+	privacyService *PrivacyService
+}
+
+func NewProfileService(userRepo *repository.UserRepository, sessionRepo *repository.UserSessionRepository, mediaService *MediaAssetService, accountService *AccountService, privacyService *PrivacyService) *ProfileService {
+	return &ProfileService{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		mediaService:   mediaService,
+		accountService: accountService,
+		privacyService: privacyService,
+	}
+}
+
+func (s *ProfileService) GetProfile(userID uuid.UUID) (*model.User, error) {
+	return s.userRepo.GetByID(userID)
+}
+
+func (s *ProfileService) UpdateProfile(userID, sessionID uuid.UUID, req *model.UpdateProfileRequest) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if req.FullName != nil {
+		values["full_name"] = strings.TrimSpace(*req.FullName)
+	}
+
+	emailChanged := false
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if !strings.EqualFold(email, user.Email) {
+			if err := s.checkReauthentication(user, sessionID, req.CurrentPassword); err != nil {
+				return nil, err
+			}
+			exists, err := s.userRepo.Exists(email)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, ErrEmailInUse
+			}
+			values["email"] = email
+			values["email_verified_at"] = nil
+			emailChanged = true
+		}
+	}
+
+	if len(values) > 0 {
+		if err := s.userRepo.UpdateColumns(userID, values); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err = s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if emailChanged {
+		s.accountService.SendVerificationEmailAsync(user)
+	}
+	return user, nil
+}
+
+// ChangePassword stores a new password and revokes every other session of the
+// user; the session that made the change stays active.
+func (s *ProfileService) ChangePassword(userID, sessionID uuid.UUID, req *model.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkReauthentication(user, sessionID, req.CurrentPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateColumns(userID, map[string]interface{}{"password": string(hashedPassword)}); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeOthersByUser(userID, sessionID, "password_changed")
+}
+
+// UpdateAvatar stores the image as a MediaAsset and points the user avatar to
+// it. The previous uploaded avatar, if any, is removed.
+func (s *ProfileService) UpdateAvatar(userID uuid.UUID, filename string, data []byte) (*model.User, error) {
+	if len(data) > maxAvatarSize {
+		return nil, ErrAvatarTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if !avatarContentTypes[contentType] {
+		return nil, ErrInvalidAvatar
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.mediaService.Create("avatar", filename, contentType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateColumns(userID, map[string]interface{}{
+		"avatar":          s.mediaService.URL(asset.ID),
+		"avatar_asset_id": asset.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if user.AvatarAssetID != nil {
+		if err := s.mediaService.Delete(*user.AvatarAssetID); err != nil {
+			log.Printf("failed to delete previous avatar %s: %v", user.AvatarAssetID, err)
+		}
+	}
+
+	return s.userRepo.GetByID(userID)
+}
+
+// DeleteAccount confirms the user (see checkReauthentication) and erases the account through the
+// LGPD erasure flow: personal data is anonymized or deleted and the e-mail is
+// released so it can be registered again.
+func (s *ProfileService) DeleteAccount(userID, sessionID uuid.UUID, password, ipAddress string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkReauthentication(user, sessionID, password); err != nil {
+		return err
+	}
+
+	_, err = s.privacyService.EraseAccount(userID, &userID, ipAddress, "self_service")
+	return err
+}
+
+// checkReauthentication confirms that the user behind the access token is
+// present before a sensitive change. Accounts with a password confirm it;
+// social-only accounts have none, so the login that started the current
+// session must be at most recentLoginWindow old.
+func (s *ProfileService) checkReauthentication(user *model.User, sessionID uuid.UUID, password string) error {
+	if user.Password != "" {
+		if password == "" || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+			return ErrInvalidCurrentPassword
+		}
+		return nil
+	}
+
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != user.ID || time.Since(session.CreatedAt) > recentLoginWindow {
+		return ErrRecentLoginRequired
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+// questaoDispositivoBatch is how many questions a full detection reads at a
+// time.
+const questaoDispositivoBatch = 500
+
+var (
+	ErrInvalidDispositivoFonte    = errors.New("fonte invalida: use " + strings.Join(model.DispositivoFontes, ", "))
+	ErrDispositivoNotFound        = errors.New("dispositivo nao encontrado no vade-mecum")
+	ErrQuestaoDispositivoNotFound = errors.New("vinculo nao encontrado")
+	ErrDeteccaoEmAndamento        = errors.New("a deteccao de dispositivos ja esta em andamento")
+)
+
+// QuestaoDispositivoService links questions to the vade-mecum rows they
+// cite. Parser links follow the question text; admins curate them by adding
+// links and removing wrong ones.
+type QuestaoDispositivoService struct {
+	repo        *repository.QuestaoDispositivoRepository
+	questaoRepo *repository.QuestaoRepository
+
+	mu         sync.Mutex
+	detectando bool
+}
+
+func NewQuestaoDispositivoService(repo *repository.QuestaoDispositivoRepository, questaoRepo *repository.QuestaoRepository) *QuestaoDispositivoService {
+	return &QuestaoDispositivoService{repo: repo, questaoRepo: questaoRepo}
+}
+
+// Detect parses the statement and comment of a question and replaces its
+// parser links with the rows found.
+func (s *QuestaoDispositivoService) Detect(q *model.Questao) error {
+	texto := stringValue(q.Enunciado) + "\n" + stringValue(q.Comentario)
+
+	links := make([]model.QuestaoDispositivo, 0)
+	seen := make(map[model.DispositivoRef]bool)
+	for _, citacao := range parseDispositivos(texto, stringValue(q.Disciplina)) {
+		refs, err := s.repo.FindDispositivos(&citacao.busca)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			links = append(links, model.QuestaoDispositivo{
+				QuestaoID:     q.ID,
+				Fonte:         ref.Fonte,
+				DispositivoID: ref.DispositivoID,
+				Referencia:    citacao.referencia,
+				Origem:        model.QuestaoDispositivoOrigemParser,
+			})
+		}
+	}
+	return s.repo.ReplaceDetected(q.ID, links)
+}
+
+// DetectQuestao runs the detection for one question and returns its links.
+func (s *QuestaoDispositivoService) DetectQuestao(questaoID int) ([]model.QuestaoDispositivoItem, error) {
+	q, err := s.questaoRepo.GetByID(questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Detect(q); err != nil {
+		return nil, err
+	}
+	return s.List(questaoID)
+}
+
+// DetectAll starts, in the background, the detection over every question,
+// used after the vade-mecum or the parser change. Only one runs at a time.
+func (s *QuestaoDispositivoService) DetectAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.detectando {
+		return ErrDeteccaoEmAndamento
+	}
+	s.detectando = true
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			s.detectando = false
+			s.mu.Unlock()
+		}()
+
+		start := time.Now()
+		var total, falhas int
+		err := s.questaoRepo.EachBatch(&model.QuestaoFilters{}, questaoDispositivoBatch, func(batch []model.Questao) error {
+			for i := range batch {
+				total++
+				if err := s.Detect(&batch[i]); err != nil {
+					falhas++
+					log.Printf("dispositivos: failed to detect questao %d: %v", batch[i].ID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("dispositivos: detection stopped after %d questoes: %v", total, err)
+			return
+		}
+		log.Printf("dispositivos: detected %d questoes (%d failed) in %s", total, falhas, time.Since(start).Round(time.Second))
+	}()
+	return nil
+}
+
+// List returns the links of a question with a summary of each row. Links to
+// rows deleted from the vade-mecum are left out.
+func (s *QuestaoDispositivoService) List(questaoID int) ([]model.QuestaoDispositivoItem, error) {
+	if _, err := s.questaoRepo.GetByID(questaoID); err != nil {
+		return nil, err
+	}
+	links, err := s.repo.ListByQuestao(questaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string][]string)
+	for _, link := range links {
+		ids[link.Fonte] = append(ids[link.Fonte], link.DispositivoID)
+	}
+	resumos := make(map[string]map[string]model.DispositivoResumo, len(ids))
+	for fonte, fonteIDs := range ids {
+		if resumos[fonte], err = s.repo.Resumos(fonte, fonteIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]model.QuestaoDispositivoItem, 0, len(links))
+	for _, link := range links {
+		resumo, ok := resumos[link.Fonte][link.DispositivoID]
+		if !ok {
+			continue
+		}
+		items = append(items, model.QuestaoDispositivoItem{
+			QuestaoDispositivo: link,
+			Diploma:            resumo.Diploma,
+			Artigo:             resumo.Artigo,
+			Texto:              resumo.Texto,
+		})
+	}
+	return items, nil
+}
+
+// Add links a question to a vade-mecum row by hand.
+func (s *QuestaoDispositivoService) Add(adminID uuid.UUID, questaoID int, req *model.CreateQuestaoDispositivoRequest) (*model.QuestaoDispositivo, error) {
+	ref := model.DispositivoRef{
+		Fonte:         strings.ToLower(strings.TrimSpace(req.Fonte)),
+		DispositivoID: strings.TrimSpace(req.DispositivoID),
+	}
+	if !s.repo.SupportsFonte(ref.Fonte) {
+		return nil, ErrInvalidDispositivoFonte
+	}
+	if _, err := s.questaoRepo.GetByID(questaoID); err != nil {
+		return nil, err
+	}
+	exists, err := s.repo.DispositivoExists(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDispositivoNotFound
+	}
+
+	item := &model.QuestaoDispositivo{
+		QuestaoID:     questaoID,
+		Fonte:         ref.Fonte,
+		DispositivoID: ref.DispositivoID,
+		Referencia:    strings.TrimSpace(req.Referencia),
+		Origem:        model.QuestaoDispositivoOrigemManual,
+		UpdatedAt:     time.Now(),
+	}
+	if adminID != uuid.Nil {
+		item.CriadoPor = &adminID
+	}
+	if err := s.repo.AddManual(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Remove unlinks a row from a question. A parser link stays discarded when
+// the question is detected again.
+func (s *QuestaoDispositivoService) Remove(questaoID int, id uuid.UUID) error {
+	found, err := s.repo.Remove(questaoID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrQuestaoDispositivoNotFound
+	}
+	return nil
+}
+
+// Questoes returns one page of the questions linked to a vade-mecum row.
+func (s *QuestaoDispositivoService) Questoes(fonte, dispositivoID string, page, pageSize int) (*model.QuestaoPage, error) {
+	ref := model.DispositivoRef{Fonte: strings.ToLower(fonte), DispositivoID: dispositivoID}
+	if !s.repo.SupportsFonte(ref.Fonte) {
+		return nil, ErrInvalidDispositivoFonte
+	}
+	exists, err := s.repo.DispositivoExists(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrDispositivoNotFound
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultQuestaoPageSize
+	}
+	if pageSize > maxQuestaoPageSize {
+		pageSize = maxQuestaoPageSize
+	}
+
+	questoes, total, err := s.repo.ListQuestoes(ref, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]model.QuestaoResult, 0, len(questoes))
+	for _, q := range questoes {
+		items = append(items, model.QuestaoResult{Questao: q})
+	}
+	return &model.QuestaoPage{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
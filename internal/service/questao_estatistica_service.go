@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// questaoEstatisticaCompleta is how often the worker recomputes every
+	// question instead of only the changed ones. It catches answers deleted
+	// when an account is erased.
+	questaoEstatisticaCompleta = 24 * time.Hour
+	// questaoEstatisticaFolga overlaps consecutive runs so answers committed
+	// while a run was starting are not missed.
+	questaoEstatisticaFolga = time.Minute
+)
+
+var ErrRecalculoEmAndamento = errors.New("o recalculo das estatisticas ja esta em andamento")
+
+// QuestaoEstatisticaService keeps the platform statistics of the questions
+// up to date in the background. Each run recomputes the questions answered
+// or regraded since the previous one; the first run and one a day recompute
+// them all. Runs hold a database advisory lock, so when several API
+// instances run the worker only one recomputes at a time; the others skip
+// the run and cover its changes in their next one.
+type QuestaoEstatisticaService struct {
+	repo     *repository.QuestaoEstatisticaRepository
+	interval time.Duration
+
+	mu             sync.Mutex
+	recalculando   bool
+	desde          time.Time
+	ultimaCompleta time.Time
+}
+
+func NewQuestaoEstatisticaService(repo *repository.QuestaoEstatisticaRepository, interval time.Duration) *QuestaoEstatisticaService {
+	return &QuestaoEstatisticaService{repo: repo, interval: interval}
+}
+
+// Start launches the worker, which runs right away and then every interval.
+func (s *QuestaoEstatisticaService) Start() {
+	go func() {
+		s.run()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.run()
+		}
+	}()
+}
+
+// RecalcularTudo starts, in the background, a recompute of every question.
+func (s *QuestaoEstatisticaService) RecalcularTudo() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.recalculando {
+		return ErrRecalculoEmAndamento
+	}
+	s.desde = time.Time{}
+	go s.run()
+	return nil
+}
+
+func (s *QuestaoEstatisticaService) run() {
+	s.mu.Lock()
+	if s.recalculando {
+		s.mu.Unlock()
+		return
+	}
+	s.recalculando = true
+	desde := s.desde
+	completa := desde.IsZero() || time.Since(s.ultimaCompleta) >= questaoEstatisticaCompleta
+	s.mu.Unlock()
+
+	unlock, locked, err := s.repo.TryLock()
+	if err != nil || !locked {
+		s.mu.Lock()
+		s.recalculando = false
+		s.mu.Unlock()
+		if err != nil {
+			log.Printf("estatisticas: failed to take the recompute lock: %v", err)
+		}
+		return
+	}
+	defer unlock()
+
+	inicio := time.Now()
+	var total int64
+	if completa {
+		total, err = s.repo.RefreshAll()
+	} else {
+		var ids []int
+		if ids, err = s.repo.Changed(desde.Add(-questaoEstatisticaFolga)); err == nil {
+			total = int64(len(ids))
+			err = s.repo.Refresh(ids)
+		}
+	}
+
+	s.mu.Lock()
+	s.recalculando = false
+	if err == nil {
+		s.desde = inicio
+		if completa {
+			s.ultimaCompleta = inicio
+		}
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		log.Printf("estatisticas: failed to recompute questoes: %v", err)
+		return
+	}
+	if completa {
+		log.Printf("estatisticas: recomputed %d questoes in %s", total, time.Since(inicio).Round(time.Millisecond))
+	}
+}
+
+// Get returns the platform statistics of a question, or nil when nobody
+// answered it yet. Graded discursive answers count in the accuracy but have
+// no alternative to show in the distribution.
+func (s *QuestaoEstatisticaService) Get(questaoID int) (*model.QuestaoEstatisticasPlataforma, error) {
+	item, err := s.repo.GetByQuestao(questaoID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.QuestaoEstatisticasPlataforma{
+		Resolucoes:        item.Resolucoes,
+		Acertos:           item.Acertos,
+		AcertosPercentual: percentual(item.Acertos, item.Resolucoes),
+		Distribuicao:      make([]model.QuestaoRespostaDistribuicao, 0),
+		AtualizadoEm:      item.AtualizadoEm,
+	}
+	for resposta, total := range item.Respostas.Data() {
+		if resposta == model.RespostaDiscursivaTentativa {
+			continue
+		}
+		result.Distribuicao = append(result.Distribuicao, model.QuestaoRespostaDistribuicao{
+			Resposta:   resposta,
+			Total:      total,
+			Percentual: percentual(total, item.Resolucoes),
+		})
+	}
+	sort.Slice(result.Distribuicao, func(i, j int) bool {
+		return result.Distribuicao[i].Resposta < result.Distribuicao[j].Resposta
+	})
+	return result, nil
+}
+
+func percentual(parte, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(parte)/float64(total)*10000) / 100
+}
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/datatypes"
+)
+
+// Formats accepted by the bulk import and export of questions.
+const (
+	QuestaoFormatoXLSX  = "xlsx"
+	QuestaoFormatoCSV   = "csv"
+	QuestaoFormatoJSONL = "jsonl"
+)
+
+const (
+	questaoExportBatchSize = 500
+	// maxQuestaoXLSXRows is the worksheet row limit of Excel minus the header.
+	maxQuestaoXLSXRows = 1048575
+	// maxQuestaoJSONLLine bounds a JSON line; html_completo can be large.
+	maxQuestaoJSONLLine = 16 << 20
+)
+
+var (
+	ErrQuestaoImportFormato   = errors.New("formato invalido: use xlsx, csv ou jsonl")
+	ErrQuestaoImportArquivo   = errors.New("arquivo invalido")
+	ErrQuestaoExportFormato   = errors.New("formato invalido: use xlsx ou jsonl")
+	ErrQuestaoImportCabecalho = errors.New("o cabecalho precisa da coluna id_questao ou id_questao_original")
+	ErrQuestaoImportVazio     = errors.New("arquivo sem questoes")
+	ErrQuestaoExportXLSX      = fmt.Errorf("a exportacao em xlsx e limitada a %d questoes; use jsonl", maxQuestaoXLSXRows)
+)
+
+// questaoColumn is a spreadsheet column, named after the JSON field of
+// CreateQuestaoRequest it fills.
+type questaoColumn struct {
+	name string
+	typ  reflect.Type
+}
+
+var (
+	questaoImportColumns = requestColumns(reflect.TypeOf(model.CreateQuestaoRequest{}))
+	questaoExportColumns = append([]questaoColumn{{name: "id", typ: reflect.TypeOf(0)}}, questaoImportColumns...)
+	questaoFieldIndex    = jsonFieldIndex(reflect.TypeOf(model.Questao{}))
+
+	timeType = reflect.TypeOf(time.Time{})
+	jsonType = reflect.TypeOf(datatypes.JSON{})
+)
+
+func requestColumns(t reflect.Type) []questaoColumn {
+	columns := make([]questaoColumn, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		typ := field.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		columns = append(columns, questaoColumn{name: name, typ: typ})
+	}
+	return columns
+}
+
+func jsonFieldIndex(t reflect.Type) map[string]int {
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+// NormalizeQuestaoFormato maps a format name or file extension to one of the
+// QuestaoFormato constants, or "" when unknown.
+func NormalizeQuestaoFormato(value string) string {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), ".") {
+	case "xlsx":
+		return QuestaoFormatoXLSX
+	case "csv":
+		return QuestaoFormatoCSV
+	case "jsonl", "ndjson":
+		return QuestaoFormatoJSONL
+	}
+	return ""
+}
+
+// questaoImportRow is a row of the file as a JSON object, ready to be decoded
+// into the create and update requests. err holds a conversion failure, and
+// chave the row key to report it with.
+type questaoImportRow struct {
+	linha int
+	chave string
+	raw   []byte
+	err   error
+}
+
+// Import upserts the questions of an XLSX, CSV or JSONL file. Columns (or JSON
+// fields) are the fields of CreateQuestaoRequest; unknown ones are ignored and
+// empty cells leave the field untouched. A row updates the question with the
+// same id_questao or, failing that, id_questao_original, and is created
+// otherwise. Every row is saved through Create or Update on its own, so
+// failures are reported per row; updates are versioned under editorID.
+func (s *QuestaoService) Import(editorID uuid.UUID, r io.Reader, formato string) (*model.QuestaoImportReport, error) {
+	var rows []questaoImportRow
+	var err error
+	switch NormalizeQuestaoFormato(formato) {
+	case QuestaoFormatoXLSX:
+		rows, err = readQuestaoXLSX(r)
+	case QuestaoFormatoCSV:
+		rows, err = readQuestaoCSV(r)
+	case QuestaoFormatoJSONL:
+		rows, err = readQuestaoJSONL(r)
+	default:
+		return nil, ErrQuestaoImportFormato
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrQuestaoImportArquivo, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrQuestaoImportArquivo, ErrQuestaoImportVazio)
+	}
+
+	type pendingRow struct {
+		linha  int
+		create model.CreateQuestaoRequest
+		update model.UpdateQuestaoRequest
+	}
+	report := &model.QuestaoImportReport{Linhas: len(rows), Erros: []model.QuestaoImportErro{}}
+	pending := make([]pendingRow, 0, len(rows))
+	var idQuestao, idOriginal []string
+	for _, row := range rows {
+		if row.err != nil {
+			report.Erros = append(report.Erros, model.QuestaoImportErro{Linha: row.linha, Chave: row.chave, Erro: row.err.Error()})
+			continue
+		}
+		item := pendingRow{linha: row.linha}
+		if err := json.Unmarshal(row.raw, &item.create); err != nil {
+			report.Erros = append(report.Erros, model.QuestaoImportErro{Linha: row.linha, Erro: "JSON invalido: " + err.Error()})
+			continue
+		}
+		if err := json.Unmarshal(row.raw, &item.update); err != nil {
+			report.Erros = append(report.Erros, model.QuestaoImportErro{Linha: row.linha, Erro: "JSON invalido: " + err.Error()})
+			continue
+		}
+		id, original := stringValue(item.create.IDQuestao), stringValue(item.create.IDQuestaoOriginal)
+		if id == "" && original == "" {
+			report.Erros = append(report.Erros, model.QuestaoImportErro{Linha: row.linha, Erro: "id_questao ou id_questao_original obrigatorio"})
+			continue
+		}
+		if id != "" {
+			idQuestao = append(idQuestao, id)
+		}
+		if original != "" {
+			idOriginal = append(idOriginal, original)
+		}
+		pending = append(pending, item)
+	}
+
+	existing, err := s.repo.FindByExternalIDs(idQuestao, idOriginal)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]int, len(existing))
+	byOriginal := make(map[string]int, len(existing))
+	register := func(item *model.Questao) {
+		if id := stringValue(item.IDQuestao); id != "" {
+			byID[id] = item.ID
+		}
+		if original := stringValue(item.IDQuestaoOriginal); original != "" {
+			byOriginal[original] = item.ID
+		}
+	}
+	for i := range existing {
+		register(&existing[i])
+	}
+
+	for _, row := range pending {
+		id, original := stringValue(row.create.IDQuestao), stringValue(row.create.IDQuestaoOriginal)
+		chave := id
+		if chave == "" {
+			chave = original
+		}
+
+		questaoID, found := byID[id]
+		if !found && original != "" {
+			questaoID, found = byOriginal[original]
+		}
+
+		var item *model.Questao
+		if found {
+			item, err = s.Update(editorID, questaoID, &row.update)
+		} else {
+			item, err = s.Create(&row.create)
+		}
+		if err != nil {
+			report.Erros = append(report.Erros, model.QuestaoImportErro{Linha: row.linha, Chave: chave, Erro: err.Error()})
+			continue
+		}
+		register(item)
+		if found {
+			report.Atualizadas++
+		} else {
+			report.Criadas++
+		}
+	}
+
+	return report, nil
+}
+
+func readQuestaoXLSX(r io.Reader) ([]questaoImportRow, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir planilha: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("planilha sem abas")
+	}
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler linhas: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, ErrQuestaoImportVazio
+	}
+
+	columns, err := questaoImportHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+	var rows []questaoImportRow
+	for idx, record := range records[1:] {
+		if isRowEmpty(record) {
+			continue
+		}
+		rows = append(rows, tabularQuestaoRow(idx+2, columns, record))
+	}
+	return rows, nil
+}
+
+// readQuestaoCSV reads comma or semicolon separated files (the latter is what
+// spreadsheets save in pt-BR locales), picking the one found in the header.
+func readQuestaoCSV(r io.Reader) ([]questaoImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler arquivo: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrQuestaoImportVazio
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler cabecalho: %w", err)
+	}
+	columns, err := questaoImportHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []questaoImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler CSV: %w", err)
+		}
+		if isRowEmpty(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, tabularQuestaoRow(line, columns, record))
+	}
+	return rows, nil
+}
+
+func readQuestaoJSONL(r io.Reader) ([]questaoImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxQuestaoJSONLLine)
+
+	var rows []questaoImportRow
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if line == 1 {
+			raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		row := questaoImportRow{linha: line, raw: append([]byte(nil), raw...)}
+		if raw[0] != '{' {
+			row.err = errors.New("cada linha deve ser um objeto JSON")
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("falha ao ler arquivo: %w", err)
+	}
+	return rows, nil
+}
+
+// questaoImportHeader maps each header cell to its column; unknown headers
+// map to nil and are skipped.
+func questaoImportHeader(header []string) ([]*questaoColumn, error) {
+	columns := make([]*questaoColumn, len(header))
+	hasKey := false
+	for i, name := range normalizeHeader(header) {
+		name = strings.ToLower(name)
+		for j := range questaoImportColumns {
+			if questaoImportColumns[j].name == name {
+				columns[i] = &questaoImportColumns[j]
+				hasKey = hasKey || name == "id_questao" || name == "id_questao_original"
+				break
+			}
+		}
+	}
+	if !hasKey {
+		return nil, ErrQuestaoImportCabecalho
+	}
+	return columns, nil
+}
+
+func tabularQuestaoRow(linha int, columns []*questaoColumn, record []string) questaoImportRow {
+	row := questaoImportRow{linha: linha}
+	values := make(map[string]interface{}, len(columns))
+	var conversion error
+	for i, column := range columns {
+		value := getCellValue(record, i)
+		if column == nil || value == "" {
+			continue
+		}
+		if column.name == "id_questao" || (column.name == "id_questao_original" && row.chave == "") {
+			row.chave = value
+		}
+		parsed, err := parseQuestaoCell(column.typ, value)
+		if err != nil {
+			if conversion == nil {
+				conversion = fmt.Errorf("coluna %s: %w", column.name, err)
+			}
+			continue
+		}
+		values[column.name] = parsed
+	}
+	if conversion != nil {
+		row.err = conversion
+		return row
+	}
+
+	row.raw, row.err = json.Marshal(values)
+	return row
+}
+
+func parseQuestaoCell(typ reflect.Type, value string) (interface{}, error) {
+	switch typ {
+	case timeType:
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "02/01/2006 15:04:05", "02/01/2006"} {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed, nil
+			}
+		}
+		return nil, fmt.Errorf("data invalida %q", value)
+	case jsonType:
+		if !json.Valid([]byte(value)) {
+			return nil, errors.New("JSON invalido")
+		}
+		return json.RawMessage(value), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		if !json.Valid([]byte(value)) {
+			return nil, errors.New("JSON invalido")
+		}
+		return json.RawMessage(value), nil
+	case reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("numero inteiro invalido %q", value)
+		}
+		return parsed, nil
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			return nil, fmt.Errorf("numero invalido %q", value)
+		}
+		return parsed, nil
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "true", "1", "sim", "s", "verdadeiro":
+			return true, nil
+		case "false", "0", "nao", "não", "n", "falso":
+			return false, nil
+		}
+		return nil, fmt.Errorf("booleano invalido %q", value)
+	}
+	return value, nil
+}
+
+// Export validates the request and returns the function that writes the
+// questions matching filters, in id order, as XLSX or JSONL. JSONL carries
+// every field of the question; XLSX has one column per import field plus id,
+// and leaves empty the cells over the Excel limit of 32767 characters (an
+// empty cell does not overwrite the field when the file is imported back).
+func (s *QuestaoService) Export(filters *model.QuestaoFilters, formato string) (func(io.Writer) error, error) {
+	switch NormalizeQuestaoFormato(formato) {
+	case QuestaoFormatoJSONL:
+		return func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetEscapeHTML(false)
+			return s.repo.EachBatch(filters, questaoExportBatchSize, func(items []model.Questao) error {
+				for i := range items {
+					if err := encoder.Encode(&items[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}, nil
+	case QuestaoFormatoXLSX:
+		total, err := s.repo.Count(filters)
+		if err != nil {
+			return nil, err
+		}
+		if total > maxQuestaoXLSXRows {
+			return nil, ErrQuestaoExportXLSX
+		}
+		return s.exportXLSX(filters), nil
+	}
+	return nil, ErrQuestaoExportFormato
+}
+
+func (s *QuestaoService) exportXLSX(filters *model.QuestaoFilters) func(io.Writer) error {
+	return func(w io.Writer) error {
+		f := excelize.NewFile()
+		defer f.Close()
+
+		sheet := f.GetSheetName(0)
+		stream, err := f.NewStreamWriter(sheet)
+		if err != nil {
+			return err
+		}
+
+		header := make([]interface{}, len(questaoExportColumns))
+		for i, column := range questaoExportColumns {
+			header[i] = column.name
+		}
+		if err := stream.SetRow("A1", header); err != nil {
+			return err
+		}
+
+		row := 2
+		err = s.repo.EachBatch(filters, questaoExportBatchSize, func(items []model.Questao) error {
+			for i := range items {
+				cell, err := excelize.CoordinatesToCellName(1, row)
+				if err != nil {
+					return err
+				}
+				if err := stream.SetRow(cell, questaoXLSXRow(&items[i])); err != nil {
+					return err
+				}
+				row++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := stream.Flush(); err != nil {
+			return err
+		}
+		return f.Write(w)
+	}
+}
+
+func questaoXLSXRow(item *model.Questao) []interface{} {
+	value := reflect.ValueOf(item).Elem()
+	cells := make([]interface{}, len(questaoExportColumns))
+	for i, column := range questaoExportColumns {
+		index, ok := questaoFieldIndex[column.name]
+		if !ok {
+			continue
+		}
+		field := value.Field(index)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+
+		switch v := field.Interface().(type) {
+		case time.Time:
+			cells[i] = v.Format(time.RFC3339)
+		case datatypes.JSON:
+			if len(v) > 0 && len(v) <= excelize.TotalCellChars {
+				cells[i] = string(v)
+			}
+		case datatypes.JSONSlice[model.QuestaoRubricaItem]:
+			if raw, err := json.Marshal(v); err == nil && len(v) > 0 && len(raw) <= excelize.TotalCellChars {
+				cells[i] = string(raw)
+			}
+		case string:
+			if len([]rune(v)) <= excelize.TotalCellChars {
+				cells[i] = v
+			}
+		default:
+			cells[i] = v
+		}
+	}
+	return cells
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(*value)
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	defaultReporteFilaLimit = 20
+	maxReporteFilaLimit     = 100
+)
+
+var (
+	ErrInvalidReporteMotivo    = errors.New("motivo invalido: use " + strings.Join(model.QuestaoReporteMotivos, ", "))
+	ErrReporteDescricaoVazia   = errors.New("descreva o problema encontrado")
+	ErrReporteDuplicado        = errors.New("voce ja reportou esta questao e o reporte ainda esta em analise")
+	ErrInvalidReporteStatus    = errors.New("status invalido: use pendente, resolvido ou rejeitado")
+	ErrReporteRejeitadoComAcao = errors.New("reportes rejeitados nao alteram a questao")
+	ErrReportesPendentesVazio  = errors.New("nenhum reporte pendente para esta questao")
+)
+
+// QuestaoReporteService handles the error reports students send about
+// questions and their moderation. Questions are changed through
+// QuestaoService so the usual side effects of an edit apply.
+type QuestaoReporteService struct {
+	repo         *repository.QuestaoReporteRepository
+	questoes     *QuestaoService
+	notificacoes *NotificacaoService
+}
+
+func NewQuestaoReporteService(repo *repository.QuestaoReporteRepository, questoes *QuestaoService, notificacoes *NotificacaoService) *QuestaoReporteService {
+	return &QuestaoReporteService{repo: repo, questoes: questoes, notificacoes: notificacoes}
+}
+
+func (s *QuestaoReporteService) Report(userID uuid.UUID, questaoID int, req *model.ReportarQuestaoRequest) (*model.QuestaoReporte, error) {
+	motivo := strings.ToLower(strings.TrimSpace(req.Motivo))
+	if !validReporteMotivo(motivo) {
+		return nil, ErrInvalidReporteMotivo
+	}
+	descricao := strings.TrimSpace(req.Descricao)
+	if descricao == "" {
+		return nil, ErrReporteDescricaoVazia
+	}
+
+	if _, err := s.questoes.GetByID(questaoID); err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.HasPending(userID, questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrReporteDuplicado
+	}
+
+	item := &model.QuestaoReporte{
+		QuestaoID: questaoID,
+		UserID:    userID,
+		Motivo:    motivo,
+		Descricao: descricao,
+		Status:    model.QuestaoReportePendente,
+	}
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Fila lists the reported questions. Status defaults to pendente; limit
+// defaults to 20 and is capped at 100.
+func (s *QuestaoReporteService) Fila(filters *model.QuestaoReporteFilters) (*model.QuestaoReporteFila, error) {
+	if filters.Status == "" {
+		filters.Status = model.QuestaoReportePendente
+	}
+	if !validReporteStatus(filters.Status) {
+		return nil, ErrInvalidReporteStatus
+	}
+	if filters.Motivo != "" && !validReporteMotivo(filters.Motivo) {
+		return nil, ErrInvalidReporteMotivo
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultReporteFilaLimit
+	}
+	if filters.Limit > maxReporteFilaLimit {
+		filters.Limit = maxReporteFilaLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	items, total, err := s.repo.Fila(filters)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.QuestaoReporteFilaItem{}
+	}
+	return &model.QuestaoReporteFila{Data: items, Total: total, Limit: filters.Limit, Offset: filters.Offset}, nil
+}
+
+func (s *QuestaoReporteService) ListByQuestao(questaoID int) ([]model.QuestaoReporte, error) {
+	items, err := s.repo.ListByQuestao(questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.QuestaoReporte{}
+	}
+	return items, nil
+}
+
+// Resolve closes the pending reports of a question as resolvido or rejeitado,
+// applies the requested flags to the question and notifies each reporter of
+// the outcome. The reports are claimed first, so a moderator who finds them
+// already closed by someone else does not edit the question.
+func (s *QuestaoReporteService) Resolve(adminID uuid.UUID, questaoID int, req *model.ResolverReportesRequest) (*model.ResolverReportesResponse, error) {
+	status := strings.ToLower(strings.TrimSpace(req.Status))
+	if status != model.QuestaoReporteResolvido && status != model.QuestaoReporteRejeitado {
+		return nil, ErrInvalidReporteStatus
+	}
+	if status == model.QuestaoReporteRejeitado && (req.Anulada != nil || req.Desatualizada != nil) {
+		return nil, ErrReporteRejeitadoComAcao
+	}
+
+	questao, err := s.questoes.GetByID(questaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resposta *string
+	if trimmed := strings.TrimSpace(req.Resposta); trimmed != "" {
+		resposta = &trimmed
+	}
+	resolved, err := s.repo.Resolve(questaoID, req.ReporteIDs, status, resposta, adminID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(resolved) == 0 {
+		return nil, ErrReportesPendentesVazio
+	}
+
+	if req.Anulada != nil || req.Desatualizada != nil {
+		questao, err = s.questoes.Update(adminID, questaoID, &model.UpdateQuestaoRequest{
+			Anulada:       req.Anulada,
+			Desatualizada: req.Desatualizada,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	go s.notifyReporters(questao, resolved)
+
+	return &model.ResolverReportesResponse{Reportes: resolved, Questao: questao}, nil
+}
+
+func (s *QuestaoReporteService) notifyReporters(questao *model.Questao, reportes []model.QuestaoReporte) {
+	for _, reporte := range reportes {
+		var mensagem string
+		if reporte.Status == model.QuestaoReporteResolvido {
+			mensagem = fmt.Sprintf("Analisamos seu reporte sobre a questão #%d e a questão foi corrigida. Obrigado por ajudar!", questao.ID)
+			if questao.Anulada != nil && *questao.Anulada {
+				mensagem += "\nA questão agora está marcada como anulada."
+			}
+			if questao.Desatualizada != nil && *questao.Desatualizada {
+				mensagem += "\nA questão agora está marcada como desatualizada."
+			}
+		} else {
+			mensagem = fmt.Sprintf("Analisamos seu reporte sobre a questão #%d e não identificamos erro.", questao.ID)
+		}
+		if reporte.Resposta != nil {
+			mensagem += "\n\nResposta da equipe: " + *reporte.Resposta
+		}
+
+		s.notificacoes.Notify(
+			reporte.UserID,
+			model.NotificacaoReporteQuestao,
+			fmt.Sprintf("Seu reporte da questão #%d foi analisado", questao.ID),
+			mensagem,
+			fmt.Sprintf("/questoes/%d", questao.ID),
+		)
+	}
+}
+
+func validReporteMotivo(motivo string) bool {
+	for _, valid := range model.QuestaoReporteMotivos {
+		if motivo == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func validReporteStatus(status string) bool {
+	switch status {
+	case model.QuestaoReportePendente, model.QuestaoReporteResolvido, model.QuestaoReporteRejeitado:
+		return true
+	}
+	return false
+}
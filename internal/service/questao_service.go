@@ -1,23 +1,99 @@
 package service
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"log"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
 	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/datatypes"
 )
 
 type QuestaoService struct {
-	repo *repository.QuestaoRepository
+	repo         *repository.QuestaoRepository
+	dispositivos *QuestaoDispositivoService
+	facets       *questaoFacetCache
 }
 
-func NewQuestaoService(repo *repository.QuestaoRepository) *QuestaoService {
-	return &QuestaoService{repo: repo}
+// NewQuestaoService builds the service. facetsTTL is how long facet counts are
+// cached and the minimum interval between refreshes of the questao_facets
+// materialized view. Saved questions have their vade-mecum citations detected
+// by dispositivos.
+func NewQuestaoService(repo *repository.QuestaoRepository, dispositivos *QuestaoDispositivoService, facetsTTL time.Duration) *QuestaoService {
+	return &QuestaoService{
+		repo:         repo,
+		dispositivos: dispositivos,
+		facets:       &questaoFacetCache{ttl: facetsTTL, entries: make(map[string]questaoFacetEntry)},
+	}
+}
+
+// maxQuestaoFacetEntries bounds the facet cache; it is cleared when full.
+const maxQuestaoFacetEntries = 1000
+
+type questaoFacetEntry struct {
+	response  *model.QuestaoFiltersResponse
+	expiresAt time.Time
+}
+
+// questaoFacetCache keeps recent facet responses and tracks the state of the
+// questao_facets view, which is refreshed lazily once it is older than ttl.
+type questaoFacetCache struct {
+	ttl time.Duration
+
+	mu          sync.Mutex
+	entries     map[string]questaoFacetEntry
+	checkedAt   time.Time
+	viewReady   bool
+	refreshing  bool
+	refreshedAt time.Time
 }
 
-func (s *QuestaoService) GetAll(filters *model.QuestaoFilters) ([]model.Questao, error) {
-	return s.repo.GetAll(filters)
+const (
+	defaultQuestaoPageSize = 20
+	maxQuestaoPageSize     = 100
+)
+
+var (
+	ErrInvalidQuestaoSort    = errors.New("ordenacao invalida: use relevancia, id, ano, dificuldade, acertos_percentual ou quantidade_resolucoes")
+	ErrInvalidQuestaoRubrica = errors.New("rubrica invalida: cada item precisa de descricao e pontuacao maior que zero")
+	ErrInvalidLinhasMaximas  = errors.New("linhas_maximas deve ser maior que zero")
+)
+
+// Search returns one page of questions. Page starts at 1; page_size defaults
+// to 20 and is capped at 100.
+func (s *QuestaoService) Search(filters *model.QuestaoFilters) (*model.QuestaoPage, error) {
+	if filters.Sort != "" && !s.repo.SupportsSort(filters.Sort) {
+		return nil, ErrInvalidQuestaoSort
+	}
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PageSize < 1 {
+		filters.PageSize = defaultQuestaoPageSize
+	}
+	if filters.PageSize > maxQuestaoPageSize {
+		filters.PageSize = maxQuestaoPageSize
+	}
+
+	items, total, err := s.repo.Search(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.QuestaoPage{
+		Data:       items,
+		Total:      total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+		TotalPages: int((total + int64(filters.PageSize) - 1) / int64(filters.PageSize)),
+	}, nil
 }
 
 func (s *QuestaoService) GetByID(id int) (*model.Questao, error) {
@@ -35,6 +111,13 @@ func (s *QuestaoService) Create(req *model.CreateQuestaoRequest) (*model.Questao
 	if req == nil {
 		return nil, errors.New("payload obrigatorio")
 	}
+	rubrica, err := questaoRubrica(req.Rubrica)
+	if err != nil {
+		return nil, err
+	}
+	if req.LinhasMaximas != nil && *req.LinhasMaximas < 1 {
+		return nil, ErrInvalidLinhasMaximas
+	}
 
 	item := &model.Questao{
 		QuestaoID:                req.QuestaoID,
@@ -79,6 +162,8 @@ func (s *QuestaoService) Create(req *model.CreateQuestaoRequest) (*model.Questao
 		Concurso:                 trimStringPtr(req.Concurso),
 		FormatoQuestao:           trimStringPtr(req.FormatoQuestao),
 		TipoProva:                trimStringPtr(req.TipoProva),
+		Rubrica:                  rubrica,
+		LinhasMaximas:            req.LinhasMaximas,
 	}
 
 	if req.CamposJSON != nil {
@@ -88,11 +173,17 @@ func (s *QuestaoService) Create(req *model.CreateQuestaoRequest) (*model.Questao
 	if err := s.repo.Create(item); err != nil {
 		return nil, err
 	}
+	s.invalidateFacets()
+	s.detectDispositivos(item)
 
 	return item, nil
 }
 
-func (s *QuestaoService) Update(id int, req *model.UpdateQuestaoRequest) (*model.Questao, error) {
+// Update applies the non-nil fields of req and records the edit, made by
+// editorID, as a new version with the changed fields. When the answer key
+// (gabarito, numero_alternativa_correta) or anulada change, the recorded
+// attempts are graded again. An update that changes nothing is not saved.
+func (s *QuestaoService) Update(editorID uuid.UUID, id int, req *model.UpdateQuestaoRequest) (*model.Questao, error) {
 	if req == nil {
 		return nil, errors.New("payload obrigatorio")
 	}
@@ -100,11 +191,44 @@ func (s *QuestaoService) Update(id int, req *model.UpdateQuestaoRequest) (*model
 		return nil, errors.New("id invalido")
 	}
 
-	item, err := s.repo.GetByID(id)
+	var changes []model.QuestaoAlteracao
+	item, err := s.repo.UpdateVersioned(id, func(item *model.Questao) (*model.QuestaoVersao, *model.QuestaoRecorrecao, error) {
+		before := *item
+		if err := applyQuestaoUpdate(item, req); err != nil {
+			return nil, nil, err
+		}
+
+		changes = diffQuestao(&before, item)
+		if len(changes) == 0 {
+			return nil, nil, nil
+		}
+		versao := &model.QuestaoVersao{Alteracoes: changes}
+		if editorID != uuid.Nil {
+			versao.EditorID = &editorID
+		}
+
+		return versao, questaoRecorrecao(&before, item), nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	if len(changes) == 0 {
+		return item, nil
+	}
+
+	s.invalidateFacets()
+	for _, change := range changes {
+		if change.Campo == "enunciado" || change.Campo == "comentario" || change.Campo == "disciplina" {
+			s.detectDispositivos(item)
+			break
+		}
+	}
+
+	return item, nil
+}
 
+// applyQuestaoUpdate copies the non-nil fields of req to item.
+func applyQuestaoUpdate(item *model.Questao, req *model.UpdateQuestaoRequest) error {
 	if req.QuestaoID != nil {
 		item.QuestaoID = req.QuestaoID
 	}
@@ -234,23 +358,131 @@ func (s *QuestaoService) Update(id int, req *model.UpdateQuestaoRequest) (*model
 	if req.TipoProva != nil {
 		item.TipoProva = trimStringPtr(req.TipoProva)
 	}
+	if req.Rubrica != nil {
+		rubrica, err := questaoRubrica(req.Rubrica)
+		if err != nil {
+			return err
+		}
+		item.Rubrica = rubrica
+	}
+	if req.LinhasMaximas != nil {
+		if *req.LinhasMaximas < 1 {
+			return ErrInvalidLinhasMaximas
+		}
+		item.LinhasMaximas = req.LinhasMaximas
+	}
+
+	return nil
+}
 
-	if err := s.repo.Update(item); err != nil {
+// History lists the versions of a question, newest first.
+func (s *QuestaoService) History(id int) ([]model.QuestaoVersao, error) {
+	if _, err := s.GetByID(id); err != nil {
 		return nil, err
 	}
-
-	return item, nil
+	items, err := s.repo.ListVersoes(id)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.QuestaoVersao{}
+	}
+	return items, nil
 }
 
 func (s *QuestaoService) Delete(id int) error {
 	if id <= 0 {
 		return errors.New("id invalido")
 	}
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	s.invalidateFacets()
+	return nil
+}
+
+// GetFacets returns the filter options reachable from filters with their
+// question counts. Sort and pagination are ignored.
+func (s *QuestaoService) GetFacets(filters *model.QuestaoFilters) (*model.QuestaoFiltersResponse, error) {
+	scoped := *filters
+	scoped.Sort, scoped.Order, scoped.Page, scoped.PageSize = "", "", 0, 0
+	// QuestaoFilters only holds strings, slices and pointers to scalars, so
+	// its JSON form identifies the filters.
+	rawKey, err := json.Marshal(scoped)
+	if err != nil {
+		return nil, err
+	}
+	key := string(rawKey)
+	now := time.Now()
+
+	cache := s.facets
+	cache.mu.Lock()
+	if entry, ok := cache.entries[key]; ok && now.Before(entry.expiresAt) {
+		cache.mu.Unlock()
+		return entry.response, nil
+	}
+	if !cache.viewReady && now.Sub(cache.checkedAt) >= cache.ttl {
+		ready, err := s.repo.FacetViewReady()
+		if err != nil {
+			log.Printf("questoes: failed to check questao_facets: %v", err)
+		}
+		cache.checkedAt = now
+		cache.viewReady = ready
+		cache.refreshedAt = now
+	}
+	useView := cache.viewReady
+	if useView && !cache.refreshing && now.Sub(cache.refreshedAt) >= cache.ttl {
+		cache.refreshing = true
+		go s.refreshFacetView()
+	}
+	cache.mu.Unlock()
+
+	response, err := s.repo.GetFacets(&scoped, useView)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	if len(cache.entries) >= maxQuestaoFacetEntries {
+		cache.entries = make(map[string]questaoFacetEntry)
+	}
+	cache.entries[key] = questaoFacetEntry{response: response, expiresAt: now.Add(cache.ttl)}
+	cache.mu.Unlock()
+
+	return response, nil
+}
+
+func (s *QuestaoService) refreshFacetView() {
+	err := s.repo.RefreshFacetView()
+
+	cache := s.facets
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.refreshing = false
+	cache.refreshedAt = time.Now()
+	if err != nil {
+		log.Printf("questoes: failed to refresh questao_facets: %v", err)
+		return
+	}
+	cache.entries = make(map[string]questaoFacetEntry)
 }
 
-func (s *QuestaoService) GetFilterOptions() (*model.QuestaoFiltersResponse, error) {
-	return s.repo.GetFilterOptions()
+// invalidateFacets drops the cached counts and marks the view stale so the
+// next facet request refreshes it.
+func (s *QuestaoService) invalidateFacets() {
+	cache := s.facets
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries = make(map[string]questaoFacetEntry)
+	cache.refreshedAt = time.Time{}
+}
+
+// detectDispositivos refreshes the vade-mecum links found in the text of a
+// saved question. Failures are logged so they never fail the edit.
+func (s *QuestaoService) detectDispositivos(item *model.Questao) {
+	if err := s.dispositivos.Detect(item); err != nil {
+		log.Printf("questoes: failed to detect dispositivos of questao %d: %v", item.ID, err)
+	}
 }
 
 func trimStringPtr(value *string) *string {
@@ -260,3 +492,75 @@ func trimStringPtr(value *string) *string {
 	trimmed := strings.TrimSpace(*value)
 	return &trimmed
 }
+
+// questaoRubrica trims the rubric of a discursive question and checks that
+// every criterion is described and worth some points.
+func questaoRubrica(items []model.QuestaoRubricaItem) (datatypes.JSONSlice[model.QuestaoRubricaItem], error) {
+	rubrica := make(datatypes.JSONSlice[model.QuestaoRubricaItem], 0, len(items))
+	for _, item := range items {
+		item.Descricao = strings.TrimSpace(item.Descricao)
+		if item.Descricao == "" || item.Pontuacao <= 0 {
+			return nil, ErrInvalidQuestaoRubrica
+		}
+		rubrica = append(rubrica, item)
+	}
+	return rubrica, nil
+}
+
+// questaoRecorrecao returns how to regrade the attempts at a question edited
+// from before to after, or nil when its answer key and anulada are unchanged.
+func questaoRecorrecao(before, after *model.Questao) *model.QuestaoRecorrecao {
+	gabaritoAntes, _ := questaoGabarito(before)
+	gabarito, _ := questaoGabarito(after)
+	anulada := after.Anulada != nil && *after.Anulada
+	if gabarito == gabaritoAntes && anulada == (before.Anulada != nil && *before.Anulada) {
+		return nil
+	}
+	return &model.QuestaoRecorrecao{Gabarito: gabarito, Anulada: anulada}
+}
+
+// diffQuestao lists the fields that differ between before and after, named
+// and valued as in the JSON of Questao.
+func diffQuestao(before, after *model.Questao) []model.QuestaoAlteracao {
+	var changes []model.QuestaoAlteracao
+	oldValue, newValue := reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem()
+	for i := 0; i < oldValue.NumField(); i++ {
+		name := strings.Split(oldValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		anterior, novo := fieldValue(oldValue.Field(i)), fieldValue(newValue.Field(i))
+		if sameFieldValue(anterior, novo) {
+			continue
+		}
+		changes = append(changes, model.QuestaoAlteracao{Campo: name, Anterior: anterior, Novo: novo})
+	}
+	return changes
+}
+
+// fieldValue dereferences pointers; nil pointers and empty slices (JSON
+// included) become nil.
+func fieldValue(field reflect.Value) interface{} {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if field.Kind() == reflect.Slice && field.Len() == 0 {
+		return nil
+	}
+	return field.Interface()
+}
+
+func sameFieldValue(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	if ja, ok := a.(datatypes.JSON); ok {
+		jb, ok := b.(datatypes.JSON)
+		return ok && bytes.Equal(ja, jb)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thepantheon/api/internal/model"
+	"gorm.io/datatypes"
+)
+
+func TestQuestaoRecorrecao(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		before model.Questao
+		after  model.Questao
+		want   *model.QuestaoRecorrecao
+	}{
+		{
+			name:   "unchanged key",
+			before: model.Questao{Gabarito: str("A")},
+			after:  model.Questao{Gabarito: str("a")},
+		},
+		{
+			name:   "same key written as a number",
+			before: model.Questao{Gabarito: str("B")},
+			after:  model.Questao{NumeroAlternativaCorreta: num(2)},
+		},
+		{
+			name:   "new key",
+			before: model.Questao{Gabarito: str("A")},
+			after:  model.Questao{Gabarito: str("C")},
+			want:   &model.QuestaoRecorrecao{Gabarito: "C"},
+		},
+		{
+			name:   "annulled",
+			before: model.Questao{Gabarito: str("A"), Anulada: &no},
+			after:  model.Questao{Gabarito: str("A"), Anulada: &yes},
+			want:   &model.QuestaoRecorrecao{Gabarito: "A", Anulada: true},
+		},
+		{
+			name:   "annulment reverted",
+			before: model.Questao{Gabarito: str("A"), Anulada: &yes},
+			after:  model.Questao{Gabarito: str("A")},
+			want:   &model.QuestaoRecorrecao{Gabarito: "A"},
+		},
+		{
+			name:   "nil and false anulada are the same",
+			before: model.Questao{Gabarito: str("E")},
+			after:  model.Questao{Gabarito: str("E"), Anulada: &no},
+		},
+		{
+			name:   "key removed keeps the recorded keys",
+			before: model.Questao{Gabarito: str("D")},
+			after:  model.Questao{},
+			want:   &model.QuestaoRecorrecao{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := questaoRecorrecao(&tt.before, &tt.after)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("got %+v, want no regrading", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("got %v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffQuestao(t *testing.T) {
+	str := func(s string) *string { return &s }
+	captura := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	before := model.Questao{
+		ID:          7,
+		Enunciado:   str("Antes"),
+		Disciplina:  str("Direito Penal"),
+		DataCaptura: &captura,
+		CamposJSON:  datatypes.JSON(`{"a":1}`),
+		ErroCaptura: new(bool),
+	}
+	after := before
+	after.Enunciado = str("Depois")
+	after.Gabarito = str("B")
+	// Equal values behind different pointers or in other time zones are not
+	// changes; neither is an empty JSON replacing a nil one.
+	after.Disciplina = str("Direito Penal")
+	local := captura.In(time.FixedZone("BRT", -3*60*60))
+	after.DataCaptura = &local
+	after.CamposJSON = datatypes.JSON(`{"a":1}`)
+	after.Rubrica = datatypes.JSONSlice[model.QuestaoRubricaItem]{}
+	// Fields hidden from the JSON are not versioned.
+	erro := true
+	after.ErroCaptura = &erro
+
+	changes := diffQuestao(&before, &after)
+	want := map[string][2]interface{}{
+		"enunciado": {"Antes", "Depois"},
+		"gabarito":  {nil, "B"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for _, change := range changes {
+		values, ok := want[change.Campo]
+		if !ok {
+			t.Errorf("unexpected change of %s", change.Campo)
+			continue
+		}
+		if change.Anterior != values[0] || change.Novo != values[1] {
+			t.Errorf("%s: got %v -> %v, want %v -> %v", change.Campo, change.Anterior, change.Novo, values[0], values[1])
+		}
+	}
+}
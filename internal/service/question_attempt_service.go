@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+var (
+	ErrInvalidResposta    = errors.New("resposta invalida: use a letra da alternativa (A-E), certo ou errado")
+	ErrQuestaoSemGabarito = errors.New("questao sem gabarito cadastrado")
+	ErrQuestaoDiscursiva  = errors.New("questao discursiva: envie o texto em /questoes/{id}/discursivas")
+)
+
+const defaultAttemptListLimit = 50
+
+type QuestionAttemptService struct {
+	repo        *repository.QuestionAttemptRepository
+	questaoRepo *repository.QuestaoRepository
+	revisao     *RevisaoService
+}
+
+func NewQuestionAttemptService(repo *repository.QuestionAttemptRepository, questaoRepo *repository.QuestaoRepository, revisao *RevisaoService) *QuestionAttemptService {
+	return &QuestionAttemptService{repo: repo, questaoRepo: questaoRepo, revisao: revisao}
+}
+
+// Answer grades the answer against the question's answer key, records the
+// attempt and returns the result with the explanations.
+func (s *QuestionAttemptService) Answer(userID uuid.UUID, questaoID int, req *model.ResponderQuestaoRequest) (*model.ResponderQuestaoResponse, error) {
+	if req == nil {
+		return nil, errors.New("payload obrigatorio")
+	}
+	resposta, ok := normalizeResposta(req.Resposta)
+	if !ok {
+		return nil, ErrInvalidResposta
+	}
+
+	questao, err := s.questaoRepo.GetByID(questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if questao.Discursiva() {
+		return nil, ErrQuestaoDiscursiva
+	}
+	gabarito, ok := questaoGabarito(questao)
+	if !ok {
+		return nil, ErrQuestaoSemGabarito
+	}
+
+	anulada := questao.Anulada != nil && *questao.Anulada
+	attempt := &model.QuestionAttempt{
+		UserID:        userID,
+		QuestaoID:     questao.ID,
+		Resposta:      resposta,
+		Gabarito:      gabarito,
+		Correct:       anulada || resposta == gabarito,
+		TempoSegundos: req.TempoSegundos,
+	}
+	if err := s.repo.Record(attempt); err != nil {
+		return nil, err
+	}
+	if err := s.revisao.Record(userID, questao.ID, attempt.Correct, attempt.AnsweredAt); err != nil {
+		log.Printf("revisao: failed to schedule questao %d for user %s: %v", questao.ID, userID, err)
+	}
+
+	return &model.ResponderQuestaoResponse{
+		TentativaID:    attempt.ID,
+		Correta:        attempt.Correct,
+		Resposta:       resposta,
+		Gabarito:       gabarito,
+		Anulada:        anulada,
+		Comentario:     questao.Comentario,
+		ResolucaoBanca: questao.ResolucaoBanca,
+	}, nil
+}
+
+// ListAttempts returns the user's latest attempts at a question, newest first.
+func (s *QuestionAttemptService) ListAttempts(userID uuid.UUID, questaoID int) ([]model.QuestionAttempt, error) {
+	return s.repo.ListByUserAndQuestao(userID, questaoID, defaultAttemptListLimit)
+}
+
+// normalizeResposta maps an answer to the letter stored in the attempt;
+// certo/errado become C/E.
+func normalizeResposta(value string) (string, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	switch value {
+	case "A", "B", "C", "D", "E":
+		return value, true
+	case "CERTO":
+		return "C", true
+	case "ERRADO":
+		return "E", true
+	}
+	return "", false
+}
+
+// questaoGabarito resolves the answer key from Gabarito or, failing that, from
+// NumeroAlternativaCorreta (1 = A).
+func questaoGabarito(questao *model.Questao) (string, bool) {
+	if questao.Gabarito != nil {
+		if gabarito, ok := normalizeResposta(*questao.Gabarito); ok {
+			return gabarito, true
+		}
+	}
+	if n := questao.NumeroAlternativaCorreta; n != nil && *n >= 1 && *n <= 5 {
+		return string(rune('A' + *n - 1)), true
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/thepantheon/api/internal/model"
+)
+
+func TestNormalizeResposta(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{"A", "A", true},
+		{" b ", "B", true},
+		{"e", "E", true},
+		{"Certo", "C", true},
+		{"ERRADO", "E", true},
+		{"F", "", false},
+		{"AB", "", false},
+		{"", "", false},
+		{"verdadeiro", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeResposta(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("normalizeResposta(%q) = (%q, %v), want (%q, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestQuestaoGabarito(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		questao model.Questao
+		want    string
+		wantOK  bool
+	}{
+		{"letter", model.Questao{Gabarito: str("d")}, "D", true},
+		{"certo", model.Questao{Gabarito: str("Certo")}, "C", true},
+		{"errado", model.Questao{Gabarito: str("errado")}, "E", true},
+		{"alternative number", model.Questao{NumeroAlternativaCorreta: num(2)}, "B", true},
+		{"gabarito wins over the number", model.Questao{Gabarito: str("A"), NumeroAlternativaCorreta: num(5)}, "A", true},
+		{"invalid gabarito falls back to the number", model.Questao{Gabarito: str("?"), NumeroAlternativaCorreta: num(5)}, "E", true},
+		{"number out of range", model.Questao{NumeroAlternativaCorreta: num(6)}, "", false},
+		{"zero", model.Questao{NumeroAlternativaCorreta: num(0)}, "", false},
+		{"no key", model.Questao{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := questaoGabarito(&tt.questao)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("questaoGabarito = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
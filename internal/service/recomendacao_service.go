@@ -0,0 +1,208 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	defaultRecomendacaoLimit = 10
+	maxRecomendacaoLimit     = 50
+
+	// recomendacaoJanela bounds the answers that measure the weaknesses, so
+	// old mistakes stop weighing once the user improves.
+	recomendacaoJanela = 180 * 24 * time.Hour
+	// recomendacaoRecentes is how long an answered question stays out of the
+	// recommendations.
+	recomendacaoRecentes = 30 * 24 * time.Hour
+	// recomendacaoMaxFraquezas caps the assuntos recommended at once.
+	recomendacaoMaxFraquezas = 5
+	// recomendacaoConfianca damps the deficit of assuntos with few answers:
+	// it is scaled by n/(n+recomendacaoConfianca).
+	recomendacaoConfianca = 5
+	// recomendacaoFolga is how much easier than the user's current accuracy
+	// the recommended questions aim to be.
+	recomendacaoFolga = 10
+)
+
+// RecomendacaoService recommends the next questions to study: unseen
+// questions of the assuntos where the user does worst compared with how hard
+// the questions were, within the user's target profile.
+type RecomendacaoService struct {
+	repo *repository.RecomendacaoRepository
+}
+
+func NewRecomendacaoService(repo *repository.RecomendacaoRepository) *RecomendacaoService {
+	return &RecomendacaoService{repo: repo}
+}
+
+func (s *RecomendacaoService) GetPerfil(userID uuid.UUID) (*model.PerfilEstudo, error) {
+	return s.repo.GetPerfil(userID)
+}
+
+// UpdatePerfil replaces the user's target profile.
+func (s *RecomendacaoService) UpdatePerfil(userID uuid.UUID, req *model.UpdatePerfilEstudoRequest) (*model.PerfilEstudo, error) {
+	perfil := &model.PerfilEstudo{
+		UserID:    userID,
+		Bancas:    perfilValues(req.Bancas),
+		Concursos: perfilValues(req.Concursos),
+		Cargos:    perfilValues(req.Cargos),
+		Orgaos:    perfilValues(req.Orgaos),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.repo.SavePerfil(perfil); err != nil {
+		return nil, err
+	}
+	return perfil, nil
+}
+
+// perfilValues trims values and drops empty and repeated ones.
+func perfilValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// Recomendar picks up to limit questions, optionally within disciplinas.
+// Each weak assunto gets a share of limit proportional to its deficit; what
+// the weaknesses cannot fill comes from the profile alone.
+func (s *RecomendacaoService) Recomendar(userID uuid.UUID, limit int, disciplinas []string) (*model.QuestoesRecomendadas, error) {
+	if limit < 1 {
+		limit = defaultRecomendacaoLimit
+	}
+	if limit > maxRecomendacaoLimit {
+		limit = maxRecomendacaoLimit
+	}
+
+	perfil, err := s.repo.GetPerfil(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	desempenho, err := s.repo.Desempenho(userID, now.Add(-recomendacaoJanela), disciplinas)
+	if err != nil {
+		return nil, err
+	}
+	fraquezas, geral := rankFraquezas(desempenho)
+
+	notSet := false
+	base := model.QuestaoFilters{
+		Disciplina:    disciplinas,
+		Banca:         perfil.Bancas,
+		Concurso:      perfil.Concursos,
+		Cargo:         perfil.Cargos,
+		Orgao:         perfil.Orgaos,
+		Anulada:       &notSet,
+		Desatualizada: &notSet,
+		QuestaoOculta: &notSet,
+	}
+	recentes := now.Add(-recomendacaoRecentes)
+
+	result := &model.QuestoesRecomendadas{
+		Perfil:    *perfil,
+		Fraquezas: fraquezas,
+		Questoes:  make([]model.QuestaoRecomendada, 0, limit),
+	}
+	var ids []int
+	add := func(items []model.Questao, motivo string, fraqueza *model.AssuntoDesempenho) {
+		for _, item := range items {
+			ids = append(ids, item.ID)
+			result.Questoes = append(result.Questoes, model.QuestaoRecomendada{Questao: item, Motivo: motivo, Fraqueza: fraqueza})
+		}
+	}
+
+	var total float64
+	for _, f := range fraquezas {
+		total += f.Deficit
+	}
+	for i := range fraquezas {
+		fraqueza := &fraquezas[i]
+		restante := limit - len(result.Questoes)
+		if restante == 0 {
+			break
+		}
+		quota := min(restante, max(1, int(math.Round(float64(limit)*fraqueza.Deficit/total))))
+
+		filters := base
+		filters.Disciplina = []string{fraqueza.Disciplina}
+		if fraqueza.Assunto != "" {
+			filters.Assunto = []string{fraqueza.Assunto}
+		}
+		items, err := s.repo.Candidatas(&filters, userID, recentes, ids, recomendacaoAlvo(fraqueza.AcertoPercentual), quota)
+		if err != nil {
+			return nil, err
+		}
+		add(items, model.RecomendacaoMotivoFraqueza, fraqueza)
+	}
+
+	if restante := limit - len(result.Questoes); restante > 0 {
+		items, err := s.repo.Candidatas(&base, userID, recentes, ids, recomendacaoAlvo(geral), restante)
+		if err != nil {
+			return nil, err
+		}
+		add(items, model.RecomendacaoMotivoPerfil, nil)
+	}
+	return result, nil
+}
+
+// rankFraquezas returns the assuntos with a positive deficit, worst first and
+// at most recomendacaoMaxFraquezas, and the user's overall accuracy (or 50
+// without answers).
+func rankFraquezas(desempenho []model.AssuntoDesempenho) ([]model.AssuntoDesempenho, float64) {
+	var tentativas, acertos int64
+	fraquezas := make([]model.AssuntoDesempenho, 0, len(desempenho))
+	for _, d := range desempenho {
+		tentativas += d.Tentativas
+		acertos += d.Acertos
+		if d.Tentativas == 0 {
+			continue
+		}
+
+		d.AcertoPercentual = float64(d.Acertos) / float64(d.Tentativas) * 100
+		n := float64(d.Tentativas)
+		d.Deficit = (d.AcertoEsperado - d.AcertoPercentual) * n / (n + recomendacaoConfianca)
+		d.AcertoPercentual = math.Round(d.AcertoPercentual*100) / 100
+		d.AcertoEsperado = math.Round(d.AcertoEsperado*100) / 100
+		d.Deficit = math.Round(d.Deficit*100) / 100
+		if d.Deficit > 0 {
+			fraquezas = append(fraquezas, d)
+		}
+	}
+
+	sort.SliceStable(fraquezas, func(i, j int) bool {
+		if fraquezas[i].Deficit != fraquezas[j].Deficit {
+			return fraquezas[i].Deficit > fraquezas[j].Deficit
+		}
+		return fraquezas[i].Tentativas > fraquezas[j].Tentativas
+	})
+	if len(fraquezas) > recomendacaoMaxFraquezas {
+		fraquezas = fraquezas[:recomendacaoMaxFraquezas]
+	}
+
+	geral := 50.0
+	if tentativas > 0 {
+		geral = float64(acertos) / float64(tentativas) * 100
+	}
+	return fraquezas, geral
+}
+
+// recomendacaoAlvo is the expected accuracy the questions should have for a
+// user at the given accuracy: a little easier, so weak areas are rebuilt
+// instead of piling up mistakes.
+func recomendacaoAlvo(acerto float64) float64 {
+	return math.Min(85, math.Max(30, acerto+recomendacaoFolga))
+}
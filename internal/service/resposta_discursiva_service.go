@@ -0,0 +1,376 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRespostaDiscursivaLimit = 20
+	maxRespostaDiscursivaLimit     = 100
+
+	// respostaDiscursivaReserva is how long a corretor keeps an answer
+	// reserved; after that it goes back to the queue.
+	respostaDiscursivaReserva = 24 * time.Hour
+	// respostaDiscursivaAprovacao is the share of the points, in percent, a
+	// graded answer needs to count as correct, as in the second phase of the
+	// OAB exam (6 out of 10).
+	respostaDiscursivaAprovacao = 60
+	// respostaDiscursivaCaracteresPorLinha is how many characters fit in a
+	// line of the answer sheet.
+	respostaDiscursivaCaracteresPorLinha = 80
+)
+
+var (
+	ErrQuestaoNaoDiscursiva            = errors.New("questao nao e discursiva")
+	ErrQuestaoSemRubrica               = errors.New("questao discursiva sem rubrica de correcao cadastrada")
+	ErrRespostaDiscursivaVazia         = errors.New("escreva a resposta")
+	ErrRespostaDiscursivaLinhas        = errors.New("a resposta ultrapassa o limite de linhas da questao")
+	ErrRespostaDiscursivaAberta        = errors.New("voce ja enviou uma resposta a esta questao que ainda aguarda correcao")
+	ErrRespostaDiscursivaNotFound      = errors.New("resposta discursiva nao encontrada")
+	ErrInvalidRespostaDiscursivaStatus = errors.New("status invalido: use pendente, em_correcao ou corrigida")
+	ErrRespostaDiscursivaIndisponivel  = errors.New("resposta ja corrigida ou reservada por outro corretor")
+	ErrRespostaDiscursivaNaoReservada  = errors.New("a resposta nao esta reservada por voce")
+	ErrRubricaNotasInvalidas           = errors.New("informe uma nota entre zero e a pontuacao de cada item da rubrica, na ordem da rubrica")
+)
+
+// RespostaDiscursivaService handles the texts students send to discursive
+// questions and their correction by corretores. A graded answer is recorded
+// as an attempt at the question, so it counts in the daily performance and
+// in the review schedule like any other answer.
+type RespostaDiscursivaService struct {
+	repo         *repository.RespostaDiscursivaRepository
+	questaoRepo  *repository.QuestaoRepository
+	revisao      *RevisaoService
+	notificacoes *NotificacaoService
+}
+
+func NewRespostaDiscursivaService(repo *repository.RespostaDiscursivaRepository, questaoRepo *repository.QuestaoRepository, revisao *RevisaoService, notificacoes *NotificacaoService) *RespostaDiscursivaService {
+	return &RespostaDiscursivaService{repo: repo, questaoRepo: questaoRepo, revisao: revisao, notificacoes: notificacoes}
+}
+
+// Enviar sends the user's text to the correction queue with a copy of the
+// question's rubric. The text must fit in the question's linhas_maximas.
+func (s *RespostaDiscursivaService) Enviar(userID uuid.UUID, questaoID int, req *model.EnviarRespostaDiscursivaRequest) (*model.RespostaDiscursiva, error) {
+	texto := strings.TrimSpace(req.Texto)
+	if texto == "" {
+		return nil, ErrRespostaDiscursivaVazia
+	}
+
+	questao, err := s.questaoRepo.GetByID(questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if !questao.Discursiva() {
+		return nil, ErrQuestaoNaoDiscursiva
+	}
+	if len(questao.Rubrica) == 0 {
+		return nil, ErrQuestaoSemRubrica
+	}
+	linhas := respostaDiscursivaLinhas(texto)
+	if questao.LinhasMaximas != nil && linhas > *questao.LinhasMaximas {
+		return nil, fmt.Errorf("%w (%d de %d)", ErrRespostaDiscursivaLinhas, linhas, *questao.LinhasMaximas)
+	}
+
+	open, err := s.repo.HasOpen(userID, questaoID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, ErrRespostaDiscursivaAberta
+	}
+
+	item := &model.RespostaDiscursiva{
+		UserID:    userID,
+		QuestaoID: questaoID,
+		Texto:     texto,
+		Linhas:    linhas,
+		Status:    model.RespostaDiscursivaPendente,
+		Rubrica:   make(datatypes.JSONSlice[model.RubricaNota], len(questao.Rubrica)),
+	}
+	for i, criterio := range questao.Rubrica {
+		item.Rubrica[i] = model.RubricaNota{Descricao: criterio.Descricao, Pontuacao: criterio.Pontuacao}
+		item.NotaMaxima += criterio.Pontuacao
+	}
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+	item.Questao = questao
+	return item, nil
+}
+
+// ListMinhas returns the user's answers, newest first. Limit defaults to 20
+// and is capped at 100.
+func (s *RespostaDiscursivaService) ListMinhas(userID uuid.UUID, filters *model.RespostaDiscursivaFilters) (*model.RespostaDiscursivaList, error) {
+	if err := normalizeRespostaDiscursivaFilters(filters); err != nil {
+		return nil, err
+	}
+	items, total, err := s.repo.ListByUser(userID, filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(items, total, filters)
+}
+
+// GetMinha returns one of the user's answers with its question.
+func (s *RespostaDiscursivaService) GetMinha(userID, id uuid.UUID) (*model.RespostaDiscursiva, error) {
+	item, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, ErrRespostaDiscursivaNotFound
+	}
+	return item, nil
+}
+
+// Fila is the correction queue seen by corretorID; see
+// RespostaDiscursivaRepository.Fila for the statuses. Limit defaults to 20
+// and is capped at 100.
+func (s *RespostaDiscursivaService) Fila(corretorID uuid.UUID, filters *model.RespostaDiscursivaFilters) (*model.RespostaDiscursivaList, error) {
+	if err := normalizeRespostaDiscursivaFilters(filters); err != nil {
+		return nil, err
+	}
+	items, total, err := s.repo.Fila(corretorID, time.Now().Add(-respostaDiscursivaReserva), filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(items, total, filters)
+}
+
+// Get returns any answer with its question, for the corretores.
+func (s *RespostaDiscursivaService) Get(id uuid.UUID) (*model.RespostaDiscursiva, error) {
+	return s.get(id)
+}
+
+// Reservar reserves the answer to corretorID for respostaDiscursivaReserva,
+// so other corretores do not grade it at the same time. Reserving it again
+// renews the reservation.
+func (s *RespostaDiscursivaService) Reservar(corretorID, id uuid.UUID) (*model.RespostaDiscursiva, error) {
+	now := time.Now()
+	item, err := s.repo.Reservar(id, corretorID, now, now.Add(-respostaDiscursivaReserva))
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		if _, err := s.get(id); err != nil {
+			return nil, err
+		}
+		return nil, ErrRespostaDiscursivaIndisponivel
+	}
+	return item, s.fillQuestoes([]*model.RespostaDiscursiva{item})
+}
+
+// Liberar gives up the reservation of the answer.
+func (s *RespostaDiscursivaService) Liberar(corretorID, id uuid.UUID) error {
+	released, err := s.repo.Liberar(id, corretorID)
+	if err != nil {
+		return err
+	}
+	if !released {
+		if _, err := s.get(id); err != nil {
+			return err
+		}
+		return ErrRespostaDiscursivaNaoReservada
+	}
+	return nil
+}
+
+// Corrigir grades an answer reserved by corretorID with one score per rubric
+// criterion. The answer counts as correct when it gets at least
+// respostaDiscursivaAprovacao percent of the points (or the question was
+// annulled) and is recorded as an attempt made when it was sent. The student
+// is notified of the grade.
+func (s *RespostaDiscursivaService) Corrigir(corretorID, id uuid.UUID, req *model.CorrigirRespostaDiscursivaRequest) (*model.RespostaDiscursiva, error) {
+	item, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == model.RespostaDiscursivaCorrigida {
+		return nil, ErrRespostaDiscursivaIndisponivel
+	}
+	if item.Status != model.RespostaDiscursivaEmCorrecao || item.CorretorID == nil || *item.CorretorID != corretorID {
+		return nil, ErrRespostaDiscursivaNaoReservada
+	}
+	if len(req.Notas) != len(item.Rubrica) {
+		return nil, ErrRubricaNotasInvalidas
+	}
+
+	var nota float64
+	for i, n := range req.Notas {
+		if n.Nota == nil || *n.Nota < 0 || *n.Nota > item.Rubrica[i].Pontuacao {
+			return nil, ErrRubricaNotasInvalidas
+		}
+		valor := *n.Nota
+		item.Rubrica[i].Nota = &valor
+		item.Rubrica[i].Comentario = nil
+		if comentario := strings.TrimSpace(n.Comentario); comentario != "" {
+			item.Rubrica[i].Comentario = &comentario
+		}
+		nota += valor
+	}
+	nota = math.Round(nota*100) / 100
+
+	now := time.Now()
+	item.Status = model.RespostaDiscursivaCorrigida
+	item.Nota = &nota
+	item.Comentario = nil
+	if comentario := strings.TrimSpace(req.Comentario); comentario != "" {
+		item.Comentario = &comentario
+	}
+	item.CorrigidaEm = &now
+	item.UpdatedAt = now
+
+	anulada := item.Questao != nil && item.Questao.Anulada != nil && *item.Questao.Anulada
+	attempt := &model.QuestionAttempt{
+		UserID:     item.UserID,
+		QuestaoID:  item.QuestaoID,
+		Resposta:   model.RespostaDiscursivaTentativa,
+		Gabarito:   model.RespostaDiscursivaTentativa,
+		Correct:    anulada || nota >= item.NotaMaxima*respostaDiscursivaAprovacao/100,
+		AnsweredAt: item.CreatedAt,
+	}
+	corrigida, err := s.repo.Corrigir(item, corretorID, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if !corrigida {
+		return nil, ErrRespostaDiscursivaNaoReservada
+	}
+	if err := s.revisao.Record(item.UserID, item.QuestaoID, attempt.Correct, attempt.AnsweredAt); err != nil {
+		log.Printf("revisao: failed to schedule questao %d for user %s: %v", item.QuestaoID, item.UserID, err)
+	}
+
+	go s.notifyCorrecao(*item)
+
+	return item, nil
+}
+
+func (s *RespostaDiscursivaService) notifyCorrecao(item model.RespostaDiscursiva) {
+	mensagem := fmt.Sprintf("Sua resposta à questão #%d recebeu nota %s de %s.",
+		item.QuestaoID, formatNota(*item.Nota), formatNota(item.NotaMaxima))
+	if item.Comentario != nil {
+		mensagem += "\n\nComentário do corretor: " + *item.Comentario
+	}
+	s.notificacoes.Notify(
+		item.UserID,
+		model.NotificacaoRespostaDiscursiva,
+		fmt.Sprintf("Sua resposta à questão #%d foi corrigida", item.QuestaoID),
+		mensagem,
+		"/discursivas/"+item.ID.String(),
+	)
+}
+
+// Desempenho sums up the user's graded answers sent in the period, overall
+// and by disciplina.
+func (s *RespostaDiscursivaService) Desempenho(userID uuid.UUID, startDate, endDate *time.Time) (*model.DesempenhoDiscursivoResumo, error) {
+	disciplinas, err := s.repo.Desempenho(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	resumo := &model.DesempenhoDiscursivoResumo{Disciplinas: make([]model.DesempenhoDiscursivo, 0, len(disciplinas))}
+	var soma float64
+	for _, d := range disciplinas {
+		resumo.Geral.Corrigidas += d.Corrigidas
+		resumo.Geral.Aprovadas += d.Aprovadas
+		soma += d.PercentualMedio * float64(d.Corrigidas)
+		d.PercentualMedio = math.Round(d.PercentualMedio*100) / 100
+		resumo.Disciplinas = append(resumo.Disciplinas, d)
+	}
+	if resumo.Geral.Corrigidas > 0 {
+		resumo.Geral.PercentualMedio = math.Round(soma/float64(resumo.Geral.Corrigidas)*100) / 100
+	}
+	return resumo, nil
+}
+
+func (s *RespostaDiscursivaService) get(id uuid.UUID) (*model.RespostaDiscursiva, error) {
+	item, err := s.repo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRespostaDiscursivaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, s.fillQuestoes([]*model.RespostaDiscursiva{item})
+}
+
+func (s *RespostaDiscursivaService) list(items []model.RespostaDiscursiva, total int64, filters *model.RespostaDiscursivaFilters) (*model.RespostaDiscursivaList, error) {
+	if items == nil {
+		items = []model.RespostaDiscursiva{}
+	}
+	refs := make([]*model.RespostaDiscursiva, len(items))
+	for i := range items {
+		refs[i] = &items[i]
+	}
+	if err := s.fillQuestoes(refs); err != nil {
+		return nil, err
+	}
+	return &model.RespostaDiscursivaList{Data: items, Total: total, Limit: filters.Limit, Offset: filters.Offset}, nil
+}
+
+func (s *RespostaDiscursivaService) fillQuestoes(items []*model.RespostaDiscursiva) error {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.QuestaoID)
+	}
+	questoes, err := s.questaoRepo.GetByIDs(ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*model.Questao, len(questoes))
+	for i := range questoes {
+		byID[questoes[i].ID] = &questoes[i]
+	}
+	for _, item := range items {
+		item.Questao = byID[item.QuestaoID]
+	}
+	return nil
+}
+
+func normalizeRespostaDiscursivaFilters(filters *model.RespostaDiscursivaFilters) error {
+	switch filters.Status {
+	case "", model.RespostaDiscursivaPendente, model.RespostaDiscursivaEmCorrecao, model.RespostaDiscursivaCorrigida:
+	default:
+		return ErrInvalidRespostaDiscursivaStatus
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultRespostaDiscursivaLimit
+	}
+	if filters.Limit > maxRespostaDiscursivaLimit {
+		filters.Limit = maxRespostaDiscursivaLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	return nil
+}
+
+// respostaDiscursivaLinhas counts the lines a text takes on the answer sheet:
+// every line break starts a new line and long lines wrap every
+// respostaDiscursivaCaracteresPorLinha characters.
+func respostaDiscursivaLinhas(texto string) int {
+	linhas := 0
+	for _, linha := range strings.Split(texto, "\n") {
+		n := utf8.RuneCountInString(strings.TrimRight(linha, " \t\r"))
+		linhas += max(1, (n+respostaDiscursivaCaracteresPorLinha-1)/respostaDiscursivaCaracteresPorLinha)
+	}
+	return linhas
+}
+
+// formatNota writes a score the Brazilian way, with a decimal comma.
+func formatNota(nota float64) string {
+	return strings.Replace(strconv.FormatFloat(nota, 'f', -1, 64), ".", ",", 1)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRespostaDiscursivaLinhas(t *testing.T) {
+	tests := []struct {
+		name  string
+		texto string
+		want  int
+	}{
+		{"empty", "", 1},
+		{"one short line", "Resposta curta.", 1},
+		{"exactly one line", strings.Repeat("a", 80), 1},
+		{"wraps", strings.Repeat("a", 81), 2},
+		{"wraps twice", strings.Repeat("a", 161), 3},
+		{"line breaks", "um\ndois\ntres", 3},
+		{"blank lines count", "um\n\ndois", 3},
+		{"trailing spaces do not wrap", strings.Repeat("a", 80) + "   \t", 1},
+		{"CRLF", "um\r\ndois", 2},
+		// Accented letters are one character each, not one per byte.
+		{"runes", strings.Repeat("ã", 80), 1},
+		{"mixed", strings.Repeat("a", 100) + "\n" + "b", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := respostaDiscursivaLinhas(tt.texto); got != tt.want {
+				t.Errorf("respostaDiscursivaLinhas = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatNota(t *testing.T) {
+	tests := map[float64]string{
+		0:     "0",
+		7:     "7",
+		7.5:   "7,5",
+		8.25:  "8,25",
+		10.05: "10,05",
+	}
+	for nota, want := range tests {
+		if got := formatNota(nota); got != want {
+			t.Errorf("formatNota(%v) = %q, want %q", nota, got, want)
+		}
+	}
+}
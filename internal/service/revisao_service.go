@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	initialEaseFactor = 2.5
+	minEaseFactor     = 1.3
+	// SM-2 grades a correct answer as 4 and a wrong one as 1.
+	revisaoQualityCorrect = 4
+	revisaoQualityWrong   = 1
+)
+
+var (
+	ErrInvalidRevisaoConfig = errors.New("os intervalos devem ser crescentes: primeiro <= segundo <= maximo")
+	ErrRevisaoCardNotFound  = errors.New("questao nao esta na revisao")
+)
+
+type RevisaoService struct {
+	repo        *repository.RevisaoRepository
+	questaoRepo *repository.QuestaoRepository
+}
+
+func NewRevisaoService(repo *repository.RevisaoRepository, questaoRepo *repository.QuestaoRepository) *RevisaoService {
+	return &RevisaoService{repo: repo, questaoRepo: questaoRepo}
+}
+
+// Record feeds an answer into the user's review schedule: a wrong answer adds
+// the question to it and answers to due questions reschedule them.
+func (s *RevisaoService) Record(userID uuid.UUID, questaoID int, correct bool, at time.Time) error {
+	config, err := s.repo.GetConfig(userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateCard(userID, questaoID, func(card *model.RevisaoCard) *model.RevisaoCard {
+		return scheduleRevisao(card, correct, at, config)
+	})
+}
+
+// Today returns the questions due by the end of the day, up to what is left
+// of the daily limit.
+func (s *RevisaoService) Today(userID uuid.UUID) (*model.RevisaoHoje, error) {
+	config, err := s.repo.GetConfig(userID)
+	if err != nil {
+		return nil, err
+	}
+	start := startOfDay(time.Now())
+	end := start.AddDate(0, 0, 1)
+
+	reviewed, err := s.repo.CountReviewedSince(userID, start)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.CountDue(userID, end)
+	if err != nil {
+		return nil, err
+	}
+
+	today := &model.RevisaoHoje{
+		Limite:        config.LimiteDiario,
+		RevisadasHoje: reviewed,
+		Pendentes:     pending,
+		Questoes:      []model.RevisaoItem{},
+	}
+	remaining := config.LimiteDiario - int(reviewed)
+	if remaining <= 0 {
+		return today, nil
+	}
+
+	cards, err := s.repo.ListDue(userID, end, remaining)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.QuestaoID)
+	}
+	questoes, err := s.questaoRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*model.Questao, len(questoes))
+	for i := range questoes {
+		byID[questoes[i].ID] = &questoes[i]
+	}
+	for _, card := range cards {
+		today.Questoes = append(today.Questoes, model.RevisaoItem{RevisaoCard: card, Questao: byID[card.QuestaoID]})
+	}
+	return today, nil
+}
+
+func (s *RevisaoService) GetConfig(userID uuid.UUID) (*model.RevisaoConfig, error) {
+	return s.repo.GetConfig(userID)
+}
+
+// UpdateConfig saves the settings. They apply from the next reschedule on.
+func (s *RevisaoService) UpdateConfig(userID uuid.UUID, req *model.UpdateRevisaoConfigRequest) (*model.RevisaoConfig, error) {
+	if req == nil {
+		return nil, errors.New("payload obrigatorio")
+	}
+	if req.PrimeiroIntervaloDias > req.SegundoIntervaloDias || req.SegundoIntervaloDias > req.IntervaloMaximoDias {
+		return nil, ErrInvalidRevisaoConfig
+	}
+	config := &model.RevisaoConfig{
+		UserID:                userID,
+		LimiteDiario:          req.LimiteDiario,
+		PrimeiroIntervaloDias: req.PrimeiroIntervaloDias,
+		SegundoIntervaloDias:  req.SegundoIntervaloDias,
+		IntervaloMaximoDias:   req.IntervaloMaximoDias,
+	}
+	if err := s.repo.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Remove takes a question out of the user's reviews until it is missed again.
+func (s *RevisaoService) Remove(userID uuid.UUID, questaoID int) error {
+	removed, err := s.repo.DeleteCard(userID, questaoID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrRevisaoCardNotFound
+	}
+	return nil
+}
+
+// scheduleRevisao applies one answer to the card, returning the card to save
+// or nil to leave it unchanged. Correct answers given before the card is due
+// do not count as a review; wrong ones always start it over.
+func scheduleRevisao(card *model.RevisaoCard, correct bool, at time.Time, config *model.RevisaoConfig) *model.RevisaoCard {
+	if card == nil {
+		if correct {
+			return nil
+		}
+		return &model.RevisaoCard{
+			EaseFactor:   initialEaseFactor,
+			IntervalDays: config.PrimeiroIntervaloDias,
+			Lapses:       1,
+			DueAt:        at.AddDate(0, 0, config.PrimeiroIntervaloDias),
+		}
+	}
+
+	due := card.DueAt.Before(startOfDay(at).AddDate(0, 0, 1))
+	if !due && correct {
+		return nil
+	}
+	if due {
+		quality := float64(revisaoQualityWrong)
+		if correct {
+			quality = revisaoQualityCorrect
+		}
+		card.EaseFactor = math.Max(minEaseFactor, card.EaseFactor+0.1-(5-quality)*(0.08+(5-quality)*0.02))
+		card.Reviews++
+		card.LastReviewedAt = &at
+	}
+
+	if correct {
+		card.Repetitions++
+		switch card.Repetitions {
+		case 1:
+			card.IntervalDays = config.PrimeiroIntervaloDias
+		case 2:
+			card.IntervalDays = config.SegundoIntervaloDias
+		default:
+			card.IntervalDays = int(math.Round(float64(card.IntervalDays) * card.EaseFactor))
+		}
+	} else {
+		card.Repetitions = 0
+		card.Lapses++
+		card.IntervalDays = config.PrimeiroIntervaloDias
+	}
+	if card.IntervalDays > config.IntervaloMaximoDias {
+		card.IntervalDays = config.IntervaloMaximoDias
+	}
+	if card.IntervalDays < 1 {
+		card.IntervalDays = 1
+	}
+	card.DueAt = at.AddDate(0, 0, card.IntervalDays)
+	return card
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+)
+
+func testRevisaoConfig() *model.RevisaoConfig {
+	config := model.DefaultRevisaoConfig(uuid.New())
+	return &config
+}
+
+func TestScheduleRevisaoNewCard(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	if card := scheduleRevisao(nil, true, at, config); card != nil {
+		t.Errorf("a correct answer must not create a card: %+v", card)
+	}
+
+	card := scheduleRevisao(nil, false, at, config)
+	if card == nil {
+		t.Fatal("a wrong answer must create a card")
+	}
+	if card.EaseFactor != initialEaseFactor || card.IntervalDays != 1 || card.Lapses != 1 || card.Repetitions != 0 || card.Reviews != 0 {
+		t.Errorf("unexpected new card: %+v", card)
+	}
+	if want := at.AddDate(0, 0, 1); !card.DueAt.Equal(want) {
+		t.Errorf("DueAt = %s, want %s", card.DueAt, want)
+	}
+}
+
+func TestScheduleRevisaoCorrectReviewsFollowSM2(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	card := scheduleRevisao(nil, false, at, config)
+
+	// Quality 4 leaves the ease factor unchanged, so after the first two fixed
+	// intervals each one is the previous times 2.5.
+	wantIntervals := []int{1, 6, 15, 38, 95, 180, 180}
+	for i, want := range wantIntervals {
+		at = card.DueAt
+		card = scheduleRevisao(card, true, at, config)
+		if card == nil {
+			t.Fatalf("review %d: due card was not rescheduled", i+1)
+		}
+		if card.IntervalDays != want {
+			t.Errorf("review %d: interval = %d, want %d", i+1, card.IntervalDays, want)
+		}
+		if card.Repetitions != i+1 || card.Reviews != i+1 {
+			t.Errorf("review %d: repetitions = %d, reviews = %d", i+1, card.Repetitions, card.Reviews)
+		}
+		if math.Abs(card.EaseFactor-initialEaseFactor) > 1e-9 {
+			t.Errorf("review %d: ease factor = %f, want %f", i+1, card.EaseFactor, initialEaseFactor)
+		}
+		if want := at.AddDate(0, 0, card.IntervalDays); !card.DueAt.Equal(want) {
+			t.Errorf("review %d: DueAt = %s, want %s", i+1, card.DueAt, want)
+		}
+	}
+}
+
+func TestScheduleRevisaoWrongReviewStartsOver(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	card := &model.RevisaoCard{
+		EaseFactor:   2.5,
+		IntervalDays: 15,
+		Repetitions:  3,
+		Lapses:       1,
+		DueAt:        at,
+	}
+
+	card = scheduleRevisao(card, false, at, config)
+	if card.Repetitions != 0 || card.Lapses != 2 || card.IntervalDays != config.PrimeiroIntervaloDias || card.Reviews != 1 {
+		t.Errorf("unexpected card after a lapse: %+v", card)
+	}
+	// Quality 1: 2.5 + 0.1 - 4*(0.08 + 4*0.02) = 1.96.
+	if math.Abs(card.EaseFactor-1.96) > 1e-9 {
+		t.Errorf("ease factor = %f, want 1.96", card.EaseFactor)
+	}
+	if card.LastReviewedAt == nil || !card.LastReviewedAt.Equal(at) {
+		t.Errorf("LastReviewedAt = %v, want %s", card.LastReviewedAt, at)
+	}
+}
+
+func TestScheduleRevisaoEaseFactorFloor(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	card := &model.RevisaoCard{EaseFactor: 1.4, IntervalDays: 1, DueAt: at}
+
+	card = scheduleRevisao(card, false, at, config)
+	if card.EaseFactor != minEaseFactor {
+		t.Errorf("ease factor = %f, want %f", card.EaseFactor, minEaseFactor)
+	}
+}
+
+func TestScheduleRevisaoBeforeDue(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	card := &model.RevisaoCard{
+		EaseFactor:   2.5,
+		IntervalDays: 6,
+		Repetitions:  2,
+		DueAt:        at.AddDate(0, 0, 3),
+	}
+
+	if got := scheduleRevisao(card, true, at, config); got != nil {
+		t.Errorf("a correct answer before the due day must not count: %+v", got)
+	}
+
+	// A wrong answer still starts the card over, without counting as a review
+	// or changing the ease factor.
+	got := scheduleRevisao(card, false, at, config)
+	if got == nil {
+		t.Fatal("a wrong answer before the due day must reschedule")
+	}
+	if got.Repetitions != 0 || got.Lapses != 1 || got.Reviews != 0 || got.EaseFactor != 2.5 || got.IntervalDays != 1 {
+		t.Errorf("unexpected card: %+v", got)
+	}
+}
+
+func TestScheduleRevisaoDueLaterToday(t *testing.T) {
+	config := testRevisaoConfig()
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	card := &model.RevisaoCard{
+		EaseFactor:   2.5,
+		IntervalDays: 1,
+		Repetitions:  1,
+		DueAt:        time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC),
+	}
+
+	got := scheduleRevisao(card, true, at, config)
+	if got == nil || got.Repetitions != 2 || got.IntervalDays != config.SegundoIntervaloDias {
+		t.Errorf("a card due later today counts as due: %+v", got)
+	}
+}
+
+func TestScheduleRevisaoRespectsConfig(t *testing.T) {
+	config := &model.RevisaoConfig{PrimeiroIntervaloDias: 3, SegundoIntervaloDias: 10, IntervaloMaximoDias: 20}
+	at := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	card := scheduleRevisao(nil, false, at, config)
+	if card.IntervalDays != 3 {
+		t.Errorf("first interval = %d, want 3", card.IntervalDays)
+	}
+	for _, want := range []int{3, 10, 20} {
+		card = scheduleRevisao(card, true, card.DueAt, config)
+		if card.IntervalDays != want {
+			t.Errorf("interval = %d, want %d", card.IntervalDays, want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	maxSimuladoQuestoes   = 200
+	defaultSimuladoTitulo = "Simulado"
+	semDisciplina         = "Sem disciplina"
+)
+
+var (
+	ErrSimuladoTooLarge              = fmt.Errorf("o simulado pode ter no maximo %d questoes", maxSimuladoQuestoes)
+	ErrSimuladoQuestoesInsuficientes = errors.New("questoes insuficientes")
+	ErrSimuladoQuestaoNotFound       = errors.New("questao nao pertence ao simulado")
+	ErrSimuladoExpirado              = errors.New("tempo do simulado esgotado")
+	ErrSimuladoFinalizado            = errors.New("simulado ja finalizado")
+)
+
+type SimuladoService struct {
+	repo        *repository.SimuladoRepository
+	questaoRepo *repository.QuestaoRepository
+	revisao     *RevisaoService
+}
+
+func NewSimuladoService(repo *repository.SimuladoRepository, questaoRepo *repository.QuestaoRepository, revisao *RevisaoService) *SimuladoService {
+	return &SimuladoService{repo: repo, questaoRepo: questaoRepo, revisao: revisao}
+}
+
+// Create draws the questions of each block at random, skipping questions the
+// user already answered and annulled, outdated or hidden ones, and starts the
+// clock.
+func (s *SimuladoService) Create(userID uuid.UUID, req *model.CreateSimuladoRequest) (*model.SimuladoDetail, error) {
+	if req == nil {
+		return nil, errors.New("payload obrigatorio")
+	}
+	total := 0
+	for _, bloco := range req.Blocos {
+		total += bloco.Quantidade
+	}
+	if total > maxSimuladoQuestoes {
+		return nil, ErrSimuladoTooLarge
+	}
+
+	notSet := false
+	var ids []int
+	for i, bloco := range req.Blocos {
+		filters := &model.QuestaoFilters{
+			Disciplina:    trimValues(bloco.Disciplina),
+			Assunto:       trimValues(bloco.Assunto),
+			Banca:         trimValues(bloco.Banca),
+			Anulada:       &notSet,
+			Desatualizada: &notSet,
+			QuestaoOculta: &notSet,
+		}
+		picked, err := s.questaoRepo.SampleIDs(filters, userID, ids, bloco.Quantidade)
+		if err != nil {
+			return nil, err
+		}
+		if len(picked) < bloco.Quantidade {
+			return nil, fmt.Errorf("%w: o bloco %d tem %d de %d questoes disponiveis",
+				ErrSimuladoQuestoesInsuficientes, i+1, len(picked), bloco.Quantidade)
+		}
+		ids = append(ids, picked...)
+	}
+
+	titulo := strings.TrimSpace(req.Titulo)
+	if titulo == "" {
+		titulo = defaultSimuladoTitulo
+	}
+	now := time.Now()
+	simulado := &model.Simulado{
+		UserID:         userID,
+		Titulo:         titulo,
+		Status:         model.SimuladoEmAndamento,
+		DuracaoMinutos: req.DuracaoMinutos,
+		TotalQuestoes:  len(ids),
+		StartedAt:      now,
+		ExpiresAt:      now.Add(time.Duration(req.DuracaoMinutos) * time.Minute),
+	}
+	for i, id := range ids {
+		simulado.Questoes = append(simulado.Questoes, model.SimuladoQuestao{Ordem: i + 1, QuestaoID: id})
+	}
+	if err := s.repo.Create(simulado); err != nil {
+		return nil, err
+	}
+
+	return s.detail(simulado)
+}
+
+func (s *SimuladoService) List(userID uuid.UUID) ([]model.Simulado, error) {
+	return s.repo.ListByUser(userID)
+}
+
+func (s *SimuladoService) Get(userID, id uuid.UUID) (*model.SimuladoDetail, error) {
+	simulado, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(simulado)
+}
+
+// Answer saves or replaces the answer to the question at position ordem.
+// Answers after the deadline are rejected.
+func (s *SimuladoService) Answer(userID, id uuid.UUID, ordem int, req *model.ResponderSimuladoRequest) error {
+	if req == nil {
+		return errors.New("payload obrigatorio")
+	}
+	resposta, ok := normalizeResposta(req.Resposta)
+	if !ok {
+		return ErrInvalidResposta
+	}
+
+	simulado, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return err
+	}
+	if simulado.Status != model.SimuladoEmAndamento {
+		return ErrSimuladoFinalizado
+	}
+	now := time.Now()
+	if !now.Before(simulado.ExpiresAt) {
+		return ErrSimuladoExpirado
+	}
+	if ordem < 1 || ordem > len(simulado.Questoes) {
+		return ErrSimuladoQuestaoNotFound
+	}
+
+	saved, err := s.repo.SaveAnswer(simulado.ID, ordem, resposta, now)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return ErrSimuladoExpirado
+	}
+	return nil
+}
+
+// Finish grades the simulado, records every answered question as an attempt
+// and returns the report. Finishing an already finished simulado returns its
+// report again.
+func (s *SimuladoService) Finish(userID, id uuid.UUID) (*model.SimuladoReport, error) {
+	simulado, err := s.repo.GetByIDAndUser(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	questoes, err := s.questoesOf(simulado)
+	if err != nil {
+		return nil, err
+	}
+
+	if simulado.Status == model.SimuladoEmAndamento {
+		var attempts []model.QuestionAttempt
+		finished, err := s.repo.Finish(simulado, func(simulado *model.Simulado) []model.QuestionAttempt {
+			attempts = gradeSimulado(simulado, questoes, time.Now())
+			return attempts
+		})
+		if err != nil {
+			return nil, err
+		}
+		if finished {
+			for _, attempt := range attempts {
+				if err := s.revisao.Record(userID, attempt.QuestaoID, attempt.Correct, attempt.AnsweredAt); err != nil {
+					log.Printf("revisao: failed to schedule questao %d for user %s: %v", attempt.QuestaoID, userID, err)
+				}
+			}
+		} else {
+			// Finished concurrently; report what was stored.
+			if simulado, err = s.repo.GetByIDAndUser(id, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return buildSimuladoReport(simulado, questoes), nil
+}
+
+// gradeSimulado grades the answered questions, closes the simulado at now and
+// returns the attempts to record for them.
+func gradeSimulado(simulado *model.Simulado, questoes map[int]*model.Questao, now time.Time) []model.QuestionAttempt {
+	acertos := 0
+	var attempts []model.QuestionAttempt
+	for i := range simulado.Questoes {
+		q := &simulado.Questoes[i]
+		questao, ok := questoes[q.QuestaoID]
+		if !ok || q.Resposta == nil {
+			continue
+		}
+		gabarito, ok := questaoGabarito(questao)
+		if !ok {
+			continue
+		}
+		correct := (questao.Anulada != nil && *questao.Anulada) || *q.Resposta == gabarito
+		q.Correct = &correct
+		if correct {
+			acertos++
+		}
+		attempts = append(attempts, model.QuestionAttempt{
+			UserID:     simulado.UserID,
+			QuestaoID:  q.QuestaoID,
+			Resposta:   *q.Resposta,
+			Gabarito:   gabarito,
+			Correct:    correct,
+			AnsweredAt: *q.AnsweredAt,
+		})
+	}
+	simulado.Status = model.SimuladoFinalizado
+	simulado.Acertos = &acertos
+	simulado.FinishedAt = &now
+	return attempts
+}
+
+func (s *SimuladoService) questoesOf(simulado *model.Simulado) (map[int]*model.Questao, error) {
+	ids := make([]int, 0, len(simulado.Questoes))
+	for _, q := range simulado.Questoes {
+		ids = append(ids, q.QuestaoID)
+	}
+	items, err := s.questaoRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	questoes := make(map[int]*model.Questao, len(items))
+	for i := range items {
+		questoes[items[i].ID] = &items[i]
+	}
+	return questoes, nil
+}
+
+func (s *SimuladoService) detail(simulado *model.Simulado) (*model.SimuladoDetail, error) {
+	questoes, err := s.questoesOf(simulado)
+	if err != nil {
+		return nil, err
+	}
+	summary := *simulado
+	summary.Questoes = nil
+	return &model.SimuladoDetail{Simulado: summary, Questoes: simuladoQuestaoViews(simulado, questoes)}, nil
+}
+
+// simuladoQuestaoViews renders the questions in order, revealing the answer
+// key and explanations only for finished simulados.
+func simuladoQuestaoViews(simulado *model.Simulado, questoes map[int]*model.Questao) []model.SimuladoQuestaoView {
+	finished := simulado.Status == model.SimuladoFinalizado
+	views := make([]model.SimuladoQuestaoView, 0, len(simulado.Questoes))
+	for _, q := range simulado.Questoes {
+		view := model.SimuladoQuestaoView{
+			Ordem:        q.Ordem,
+			QuestaoID:    q.QuestaoID,
+			Resposta:     q.Resposta,
+			RespondidaEm: q.AnsweredAt,
+		}
+		if questao, ok := questoes[q.QuestaoID]; ok {
+			view.Titulo = questao.Titulo
+			view.Enunciado = questao.Enunciado
+			view.AlternativaA = questao.AlternativaA
+			view.AlternativaB = questao.AlternativaB
+			view.AlternativaC = questao.AlternativaC
+			view.AlternativaD = questao.AlternativaD
+			view.AlternativaE = questao.AlternativaE
+			view.TipoQuestao = questao.TipoQuestao
+			view.Disciplina = questao.Disciplina
+			view.Assunto = questao.Assunto
+			view.Banca = questao.Banca
+			view.Ano = questao.Ano
+			if finished {
+				if gabarito, ok := questaoGabarito(questao); ok {
+					view.Gabarito = &gabarito
+				}
+				view.Correta = q.Correct
+				view.Anulada = questao.Anulada
+				view.Comentario = questao.Comentario
+				view.ResolucaoBanca = questao.ResolucaoBanca
+			}
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func buildSimuladoReport(simulado *model.Simulado, questoes map[int]*model.Questao) *model.SimuladoReport {
+	summary := *simulado
+	summary.Questoes = nil
+	report := &model.SimuladoReport{
+		Simulado:      summary,
+		PorDisciplina: []model.SimuladoDisciplinaResultado{},
+		Questoes:      simuladoQuestaoViews(simulado, questoes),
+	}
+
+	index := make(map[string]int)
+	for _, q := range simulado.Questoes {
+		disciplina := semDisciplina
+		if questao, ok := questoes[q.QuestaoID]; ok && questao.Disciplina != nil && *questao.Disciplina != "" {
+			disciplina = *questao.Disciplina
+		}
+		i, ok := index[disciplina]
+		if !ok {
+			i = len(report.PorDisciplina)
+			index[disciplina] = i
+			report.PorDisciplina = append(report.PorDisciplina, model.SimuladoDisciplinaResultado{Disciplina: disciplina})
+		}
+		countSimuladoAnswer(&report.Geral, q)
+		countSimuladoAnswer(&report.PorDisciplina[i].SimuladoResultado, q)
+	}
+
+	finishSimuladoResultado(&report.Geral)
+	for i := range report.PorDisciplina {
+		finishSimuladoResultado(&report.PorDisciplina[i].SimuladoResultado)
+	}
+	return report
+}
+
+func countSimuladoAnswer(result *model.SimuladoResultado, q model.SimuladoQuestao) {
+	result.Total++
+	switch {
+	case q.Correct == nil:
+		result.EmBranco++
+	case *q.Correct:
+		result.Acertos++
+	default:
+		result.Erros++
+	}
+}
+
+func finishSimuladoResultado(result *model.SimuladoResultado) {
+	if result.Total > 0 {
+		result.Percentual = math.Round(float64(result.Acertos)/float64(result.Total)*10000) / 100
+	}
+}
+
+func trimValues(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
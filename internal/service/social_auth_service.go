@@ -2,96 +2,188 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"sort"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/facebook"
-	"golang.org/x/oauth2/google"
+)
+
+// OAuthFlowTTL is how long the user has to complete an OAuth flow.
+const OAuthFlowTTL = 10 * time.Minute
+
+var (
+	ErrUnsupportedProvider    = errors.New("unsupported provider")
+	ErrInvalidOAuthState      = errors.New("invalid or expired oauth state")
+	ErrSocialAccountExists    = errors.New("an account with this email already exists; sign in and link this provider from your profile")
+	ErrIdentityAlreadyLinked  = errors.New("this provider account is already linked to another user")
+	ErrProviderAlreadyLinked  = errors.New("provider already linked to this account")
+	ErrProviderNotLinked      = errors.New("provider not linked to this account")
+	ErrLastLoginMethodRemoval = errors.New("cannot unlink the only login method; set a password first")
 )
 
 type SocialAuthService struct {
-	userService    *UserService
-	googleConfig   *oauth2.Config
-	facebookConfig *oauth2.Config
+	userService  *UserService
+	identityRepo *repository.UserIdentityRepository
+	stateKey     []byte
+	providers    map[string]OAuthProvider
+}
+
+// oauthFlowClaims is the signed payload of the cookie set on the browser that
+// starts an OAuth flow. The state sent to the provider is a random value that
+// only means something together with this cookie, and the PKCE verifier and
+// OIDC nonce never leave it, so a callback URL handed to another browser is
+// useless.
+type oauthFlowClaims struct {
+	Provider string `json:"provider"`
+	Purpose  string `json:"purpose"`
+	UserID   string `json:"uid,omitempty"`
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// OAuthFlow is a started authorization: the provider URL to redirect to and
+// the cookie the browser must present on the callback.
+type OAuthFlow struct {
+	URL    string
+	Cookie string
 }
 
-func NewSocialAuthService(userService *UserService, googleClientID, googleClientSecret, facebookAppID, facebookAppSecret, redirectURL string) *SocialAuthService {
+// OAuthCallbackResult is the outcome of a provider callback: a login, or a
+// provider linked to the user that started the flow.
+type OAuthCallbackResult struct {
+	User     *model.User
+	Identity *model.UserIdentity
+	Purpose  string
+}
+
+func NewSocialAuthService(userService *UserService, identityRepo *repository.UserIdentityRepository, stateSecret string, providers ...OAuthProvider) *SocialAuthService {
+	// Use a key derived from the JWT secret so a flow cookie can never be
+	// replayed as an access token.
+	registry := make(map[string]OAuthProvider, len(providers))
+	for _, provider := range providers {
+		registry[provider.Name()] = provider
+	}
+
 	return &SocialAuthService{
-		userService: userService,
-		googleConfig: &oauth2.Config{
-			ClientID:     googleClientID,
-			ClientSecret: googleClientSecret,
-			RedirectURL:  redirectURL + "/auth/google/callback",
-			Scopes: []string{
-				"https://www.googleapis.com/auth/userinfo.email",
-				"https://www.googleapis.com/auth/userinfo.profile",
-			},
-			Endpoint: google.Endpoint,
-		},
-		facebookConfig: &oauth2.Config{
-			ClientID:     facebookAppID,
-			ClientSecret: facebookAppSecret,
-			RedirectURL:  redirectURL + "/auth/facebook/callback",
-			Scopes:       []string{"email", "public_profile"},
-			Endpoint:     facebook.Endpoint,
-		},
+		userService:  userService,
+		identityRepo: identityRepo,
+		stateKey:     deriveKey(stateSecret, "oauth-state"),
+		providers:    registry,
 	}
 }
 
-func (s *SocialAuthService) GetGoogleAuthURL(state string) string {
-	return s.googleConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
+// Providers returns the names of the registered providers.
+func (s *SocialAuthService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-func (s *SocialAuthService) GetFacebookAuthURL(state string) string {
-	return s.facebookConfig.AuthCodeURL(state)
+func (s *SocialAuthService) provider(name string) (OAuthProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, ErrUnsupportedProvider
+	}
+	return provider, nil
 }
 
-func (s *SocialAuthService) AuthenticateWithGoogle(code string) (*model.User, error) {
-	token, err := s.googleConfig.Exchange(context.Background(), code)
+// AuthURL starts an OAuth flow: it builds the provider authorization URL with
+// a random state and a PKCE S256 challenge, and the signed flow cookie that
+// holds them. For the link purpose the flow is bound to userID.
+func (s *SocialAuthService) AuthURL(provider, purpose string, userID uuid.UUID) (*OAuthFlow, error) {
+	p, err := s.provider(provider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
+		return nil, err
 	}
 
-	userInfo, err := s.getGoogleUserInfo(token.AccessToken)
+	cfg, err := p.Config(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	return s.findOrCreateSocialUser(userInfo)
+	claims, cookie, err := s.newFlow(provider, purpose, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(claims.Verifier)}
+	opts = append(opts, p.AuthCodeOptions(claims.Nonce)...)
+	return &OAuthFlow{URL: cfg.AuthCodeURL(claims.State, opts...), Cookie: cookie}, nil
 }
 
-func (s *SocialAuthService) AuthenticateWithFacebook(code string) (*model.User, error) {
-	token, err := s.facebookConfig.Exchange(context.Background(), code)
+// HandleCallback checks the state against the flow cookie of the browser,
+// exchanges the code with the PKCE verifier kept in the cookie and either
+// logs the user in or links the provider account. The caller must clear the
+// cookie, so each flow completes at most once.
+func (s *SocialAuthService) HandleCallback(provider, code, state, cookie string) (*OAuthCallbackResult, error) {
+	p, err := s.provider(provider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
+		return nil, err
 	}
 
-	userInfo, err := s.getFacebookUserInfo(token.AccessToken)
+	claims, err := s.parseFlow(cookie)
+	if err != nil || claims.Provider != provider ||
+		subtle.ConstantTimeCompare([]byte(claims.State), []byte(state)) != 1 {
+		return nil, ErrInvalidOAuthState
+	}
+
+	ctx := context.Background()
+	cfg, err := p.Config(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.findOrCreateSocialUser(userInfo)
-}
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(claims.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
 
-func (s *SocialAuthService) AuthenticateWithToken(provider, accessToken string) (*model.User, error) {
-	var userInfo *model.SocialUserInfo
-	var err error
+	userInfo, err := p.UserInfoFromToken(ctx, token, claims.Nonce)
+	if err != nil {
+		return nil, err
+	}
 
-	switch provider {
-	case "google":
-		userInfo, err = s.getGoogleUserInfo(accessToken)
-	case "facebook":
-		userInfo, err = s.getFacebookUserInfo(accessToken)
-	default:
-		return nil, errors.New("unsupported provider")
+	if claims.Purpose == model.OAuthPurposeLink {
+		userID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			return nil, ErrInvalidOAuthState
+		}
+		identity, err := s.linkIdentity(userID, userInfo)
+		if err != nil {
+			return nil, err
+		}
+		user, err := s.userService.GetUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return &OAuthCallbackResult{User: user, Identity: identity, Purpose: model.OAuthPurposeLink}, nil
+	}
+
+	user, err := s.findOrCreateSocialUser(userInfo)
+	if err != nil {
+		return nil, err
 	}
+	return &OAuthCallbackResult{User: user, Purpose: model.OAuthPurposeLogin}, nil
+}
 
+// AuthenticateWithToken logs in with tokens the client obtained from the
+// provider. idToken is required by OIDC providers.
+func (s *SocialAuthService) AuthenticateWithToken(provider, accessToken, idToken string) (*model.User, error) {
+	userInfo, err := s.fetchUserInfo(provider, accessToken, idToken)
 	if err != nil {
 		return nil, err
 	}
@@ -99,113 +191,78 @@ func (s *SocialAuthService) AuthenticateWithToken(provider, accessToken string)
 	return s.findOrCreateSocialUser(userInfo)
 }
 
-func (s *SocialAuthService) getGoogleUserInfo(accessToken string) (*model.SocialUserInfo, error) {
-	resp, err := http.Get("https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + accessToken)
+// LinkWithToken links the provider account that owns accessToken to userID.
+func (s *SocialAuthService) LinkWithToken(userID uuid.UUID, provider, accessToken, idToken string) (*model.UserIdentity, error) {
+	userInfo, err := s.fetchUserInfo(provider, accessToken, idToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return s.linkIdentity(userID, userInfo)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("google API error: %s", string(body))
+func (s *SocialAuthService) Unlink(userID uuid.UUID, provider string) error {
+	identity, err := s.identityRepo.GetByUserAndProvider(userID, provider)
+	if err != nil {
+		return ErrProviderNotLinked
 	}
 
-	var data struct {
-		ID      string `json:"id"`
-		Email   string `json:"email"`
-		Name    string `json:"name"`
-		Picture string `json:"picture"`
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		return err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if user.Password == "" {
+		count, err := s.identityRepo.CountByUser(userID)
+		if err != nil {
+			return err
+		}
+		if count <= 1 {
+			return ErrLastLoginMethodRemoval
+		}
 	}
 
-	return &model.SocialUserInfo{
-		ID:       data.ID,
-		Email:    data.Email,
-		Name:     data.Name,
-		Picture:  data.Picture,
-		Provider: "google",
-	}, nil
+	return s.identityRepo.Delete(identity.ID)
 }
 
-func (s *SocialAuthService) getFacebookUserInfo(accessToken string) (*model.SocialUserInfo, error) {
-	url := fmt.Sprintf("https://graph.facebook.com/me?fields=id,name,email,picture&access_token=%s", accessToken)
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
-	}
-	defer resp.Body.Close()
+func (s *SocialAuthService) GetIdentities(userID uuid.UUID) ([]model.UserIdentity, error) {
+	return s.identityRepo.GetByUser(userID)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("facebook API error: %s", string(body))
+func (s *SocialAuthService) fetchUserInfo(provider, accessToken, idToken string) (*model.SocialUserInfo, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return nil, err
 	}
+	return p.UserInfo(context.Background(), accessToken, idToken)
+}
 
-	var data struct {
-		ID      string `json:"id"`
-		Email   string `json:"email"`
-		Name    string `json:"name"`
-		Picture struct {
-			Data struct {
-				URL string `json:"url"`
-			} `json:"data"`
-		} `json:"picture"`
+// findOrCreateSocialUser logs in through an existing identity or creates a
+// new account. An existing local account with the same e-mail is never
+// merged implicitly: its owner has to sign in and link the provider.
+func (s *SocialAuthService) findOrCreateSocialUser(info *model.SocialUserInfo) (*model.User, error) {
+	if info.ID == "" {
+		return nil, errors.New("user id not provided by social provider")
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if identity, err := s.identityRepo.GetByProviderUserID(info.Provider, info.ID); err == nil {
+		return s.userService.GetUserByID(identity.UserID)
 	}
 
-	return &model.SocialUserInfo{
-		ID:       data.ID,
-		Email:    data.Email,
-		Name:     data.Name,
-		Picture:  data.Picture.Data.URL,
-		Provider: "facebook",
-	}, nil
-}
-
-func (s *SocialAuthService) findOrCreateSocialUser(info *model.SocialUserInfo) (*model.User, error) {
 	if info.Email == "" {
 		return nil, errors.New("email not provided by social provider")
 	}
 
-	// Buscar usuário existente por email
-	user, err := s.userService.GetUserByEmail(info.Email)
-	if err == nil {
-		// Usuário existe, atualizar informações sociais se necessário
-		needsUpdate := false
-
-		if user.Provider == "" || user.ProviderID == "" {
-			user.Provider = info.Provider
-			user.ProviderID = info.ID
-			needsUpdate = true
-		}
-
-		if user.Avatar == "" && info.Picture != "" {
-			user.Avatar = info.Picture
-			needsUpdate = true
-		}
-
-		if needsUpdate {
-			if err := s.userService.repo.Update(user.ID, user); err != nil {
-				return nil, fmt.Errorf("failed to update user: %w", err)
-			}
-		}
-
-		return user, nil
+	if _, err := s.userService.GetUserByEmail(info.Email); err == nil {
+		return nil, ErrSocialAccountExists
 	}
 
-	// Criar novo usuário
-	user = &model.User{
+	user := &model.User{
 		Email:      info.Email,
 		FullName:   info.Name,
 		Avatar:     info.Picture,
 		Provider:   info.Provider,
 		ProviderID: info.ID,
+		Role:       "user",
 		Active:     true,
 		Password:   "", // Não tem senha para login social
 	}
@@ -214,5 +271,84 @@ func (s *SocialAuthService) findOrCreateSocialUser(info *model.SocialUserInfo) (
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if err := s.identityRepo.Create(&model.UserIdentity{
+		UserID:         user.ID,
+		Provider:       info.Provider,
+		ProviderUserID: info.ID,
+		Email:          info.Email,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
 	return user, nil
 }
+
+func (s *SocialAuthService) linkIdentity(userID uuid.UUID, info *model.SocialUserInfo) (*model.UserIdentity, error) {
+	if info.ID == "" {
+		return nil, errors.New("user id not provided by social provider")
+	}
+
+	if existing, err := s.identityRepo.GetByProviderUserID(info.Provider, info.ID); err == nil {
+		if existing.UserID != userID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return existing, nil
+	}
+
+	if _, err := s.identityRepo.GetByUserAndProvider(userID, info.Provider); err == nil {
+		return nil, ErrProviderAlreadyLinked
+	}
+
+	identity := &model.UserIdentity{
+		UserID:         userID,
+		Provider:       info.Provider,
+		ProviderUserID: info.ID,
+		Email:          info.Email,
+	}
+	if err := s.identityRepo.Create(identity); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	return identity, nil
+}
+
+func (s *SocialAuthService) newFlow(provider, purpose string, userID uuid.UUID) (*oauthFlowClaims, string, error) {
+	now := time.Now()
+	claims := &oauthFlowClaims{
+		Provider: provider,
+		Purpose:  purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(OAuthFlowTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	if userID != uuid.Nil {
+		claims.UserID = userID.String()
+	}
+	for _, value := range []*string{&claims.State, &claims.Verifier, &claims.Nonce} {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, "", err
+		}
+		*value = base64.RawURLEncoding.EncodeToString(buf)
+	}
+
+	cookie, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.stateKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return claims, cookie, nil
+}
+
+func (s *SocialAuthService) parseFlow(cookie string) (*oauthFlowClaims, error) {
+	claims := &oauthFlowClaims{}
+	token, err := jwt.ParseWithClaims(cookie, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return s.stateKey, nil
+	})
+	if err != nil || !token.Valid || claims.State == "" || claims.Verifier == "" || claims.Nonce == "" {
+		return nil, ErrInvalidOAuthState
+	}
+	return claims, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; the 6-digit code is their last six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := totpCode(rfc6238Secret, tt.unix/totpPeriod)
+		if err != nil {
+			t.Fatalf("totpCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPCodeAcceptsLowercaseSecret(t *testing.T) {
+	got, err := totpCode(strings.ToLower(rfc6238Secret), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "287082" {
+		t.Errorf("got %s, want 287082", got)
+	}
+}
+
+func TestTOTPCodeRejectsInvalidSecret(t *testing.T) {
+	if _, err := totpCode("not base32!", 1); err == nil {
+		t.Error("expected an error for an invalid secret")
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+	code := func(step int64) string {
+		c, err := totpCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", code(current), current, true},
+		{"previous step within skew", code(current - 1), current - 1, true},
+		{"next step within skew", code(current + 1), current + 1, true},
+		{"step before skew", code(current - 2), 0, false},
+		{"step after skew", code(current + 2), 0, false},
+		{"spaces are ignored", " " + code(current)[:3] + " " + code(current)[3:] + " ", current, true},
+		{"too short", code(current)[:5], 0, false},
+		{"too long", code(current) + "0", 0, false},
+		{"empty", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := validateTOTP(rfc6238Secret, tt.code, now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("validateTOTP(%q) = (%d, %v), want (%d, %v)", tt.code, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+// Replay protection stores the accepted step and only accepts later ones, so
+// the step returned for a code must not change while the code is valid.
+func TestValidateTOTPReturnsTheStepOfTheCode(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix()/totpPeriod - 1
+	code, err := totpCode(rfc6238Secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, at := range []time.Time{now.Add(-totpPeriod * time.Second), now} {
+		got, ok := validateTOTP(rfc6238Secret, code, at)
+		if !ok || got != step {
+			t.Errorf("at %d: got (%d, %v), want (%d, true)", at.Unix(), got, ok, step)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	a, err := generateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := generateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("two generated secrets are equal")
+	}
+	key, err := totpEncoding.DecodeString(a)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", a, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("key has %d bytes, want 20", len(key))
+	}
+}
+
+func TestTOTPURL(t *testing.T) {
+	raw := totpURL("The Pantheon", "ana@example.com", rfc6238Secret)
+	if strings.Contains(raw, "+") {
+		t.Errorf("spaces must be encoded as %%20: %s", raw)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Errorf("unexpected scheme or type: %s", raw)
+	}
+	if u.Path != "/The Pantheon:ana@example.com" {
+		t.Errorf("label = %q", u.Path)
+	}
+	query := u.Query()
+	for key, want := range map[string]string{
+		"secret":    rfc6238Secret,
+		"issuer":    "The Pantheon",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	totpIssuer            = "Pantheon Concursos"
+	twoFactorChallengeTTL = 10 * time.Minute
+	recoveryCodeCount     = 10
+
+	twoFactorPurposeLogin = "login"
+	twoFactorPurposeSetup = "setup"
+)
+
+var (
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorToken   = errors.New("invalid or expired two-factor token")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorSetupMissing   = errors.New("two-factor setup was not started")
+	ErrTwoFactorMandatory      = errors.New("two-factor authentication is mandatory for this account")
+)
+
+// TwoFactorService manages TOTP enrollment, recovery codes and the
+// short-lived challenge issued between the password step and the code step.
+// Admins must use a second factor; other users can opt in.
+type TwoFactorService struct {
+	userRepo     *repository.UserRepository
+	recoveryRepo *repository.UserRecoveryCodeRepository
+	secretKey    []byte
+	challengeKey []byte
+}
+
+type twoFactorChallengeClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+func NewTwoFactorService(userRepo *repository.UserRepository, recoveryRepo *repository.UserRecoveryCodeRepository, secret string) *TwoFactorService {
+	return &TwoFactorService{
+		userRepo:     userRepo,
+		recoveryRepo: recoveryRepo,
+		secretKey:    deriveKey(secret, "two-factor-secret"),
+		challengeKey: deriveKey(secret, "two-factor-challenge"),
+	}
+}
+
+// Required reports whether the login of user needs a second step.
+func (s *TwoFactorService) Required(user *model.User) bool {
+	return user.Role == "admin" || user.TwoFactorEnabledAt != nil
+}
+
+// NewChallenge returns the partial login response sent after a valid
+// password. Admins without a second factor get a setup challenge instead.
+func (s *TwoFactorService) NewChallenge(user *model.User) (*model.LoginResponse, error) {
+	purpose := twoFactorPurposeLogin
+	if user.TwoFactorEnabledAt == nil {
+		purpose = twoFactorPurposeSetup
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(twoFactorChallengeTTL)
+	claims := &twoFactorChallengeClaims{
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.challengeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LoginResponse{
+		ExpiresIn:              expiresAt.Unix(),
+		TwoFactorRequired:      purpose == twoFactorPurposeLogin,
+		TwoFactorSetupRequired: purpose == twoFactorPurposeSetup,
+		TwoFactorToken:         token,
+	}, nil
+}
+
+// ParseChallenge validates a challenge token and returns its user ID and
+// purpose.
+func (s *TwoFactorService) ParseChallenge(token string) (uuid.UUID, string, error) {
+	claims := &twoFactorChallengeClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return s.challengeKey, nil
+	})
+	if err != nil || !parsed.Valid || claims.ExpiresAt == nil {
+		return uuid.Nil, "", ErrInvalidTwoFactorToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, "", ErrInvalidTwoFactorToken
+	}
+	return userID, claims.Purpose, nil
+}
+
+// SetupUser resolves the user of a setup challenge, used by admins that have
+// to enroll before they can get an access token.
+func (s *TwoFactorService) SetupUser(token string) (*model.User, error) {
+	userID, purpose, err := s.ParseChallenge(token)
+	if err != nil || purpose != twoFactorPurposeSetup {
+		return nil, ErrInvalidTwoFactorToken
+	}
+	return s.userRepo.GetByID(userID)
+}
+
+// Setup generates and stores a new pending TOTP secret. It only becomes
+// active after Enable confirms a code generated from it.
+func (s *TwoFactorService) Setup(user *model.User) (*model.TwoFactorSetupResponse, error) {
+	if user.TwoFactorEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateColumns(user.ID, map[string]interface{}{
+		"two_factor_secret":       encrypted,
+		"two_factor_last_counter": 0,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURL: totpURL(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// Enable confirms the pending secret with a TOTP code and returns the
+// recovery codes, which are only shown once.
+func (s *TwoFactorService) Enable(user *model.User, code string) ([]string, error) {
+	if user.TwoFactorEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, ErrTwoFactorSetupMissing
+	}
+
+	if err := s.verifyTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateColumns(user.ID, map[string]interface{}{"two_factor_enabled_at": time.Now()}); err != nil {
+		return nil, err
+	}
+	return s.newRecoveryCodes(user.ID)
+}
+
+// Verify accepts a TOTP code or an unused recovery code.
+func (s *TwoFactorService) Verify(user *model.User, code string) error {
+	if user.TwoFactorEnabledAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if err := s.verifyTOTP(user, code); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+
+	used, err := s.recoveryRepo.Consume(user.ID, hashOpaqueToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// Disable removes the second factor. It is refused for admins.
+func (s *TwoFactorService) Disable(user *model.User, code string) error {
+	if user.Role == "admin" {
+		return ErrTwoFactorMandatory
+	}
+	if err := s.Verify(user, code); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateColumns(user.ID, map[string]interface{}{
+		"two_factor_secret":       "",
+		"two_factor_enabled_at":   nil,
+		"two_factor_last_counter": 0,
+	}); err != nil {
+		return err
+	}
+	return s.recoveryRepo.DeleteByUser(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code after checking a
+// current code.
+func (s *TwoFactorService) RegenerateRecoveryCodes(user *model.User, code string) ([]string, error) {
+	if err := s.Verify(user, code); err != nil {
+		return nil, err
+	}
+	return s.newRecoveryCodes(user.ID)
+}
+
+func (s *TwoFactorService) Status(user *model.User) (*model.TwoFactorStatusResponse, error) {
+	remaining, err := s.recoveryRepo.CountUnused(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TwoFactorStatusResponse{
+		Enabled:                user.TwoFactorEnabledAt != nil,
+		Required:               user.Role == "admin",
+		EnabledAt:              user.TwoFactorEnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+func (s *TwoFactorService) verifyTOTP(user *model.User, code string) error {
+	secret, err := s.decryptSecret(user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := validateTOTP(secret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	fresh, err := s.userRepo.AdvanceTwoFactorCounter(user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (s *TwoFactorService) newRecoveryCodes(userID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashOpaqueToken(raw))
+	}
+
+	if err := s.recoveryRepo.Replace(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func (s *TwoFactorService) encryptSecret(secret string) (string, error) {
+	gcm, err := s.cipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *TwoFactorService) decryptSecret(encrypted string) (string, error) {
+	if encrypted == "" {
+		return "", ErrTwoFactorSetupMissing
+	}
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := s.cipher()
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("invalid two-factor secret")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func (s *TwoFactorService) cipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives a purpose-specific 256-bit key from the application
+// secret, so a value signed or encrypted for one purpose is useless for
+// another.
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
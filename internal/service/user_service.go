@@ -66,10 +66,6 @@ func (s *UserService) GetUserByEmail(email string) (*model.User, error) {
 	return s.repo.GetByEmail(email)
 }
 
-func (s *UserService) GetAllUsers(limit, offset int) ([]model.User, error) {
-	return s.repo.GetAll(limit, offset)
-}
-
 func (s *UserService) UpdateUser(id uuid.UUID, req *model.UpdateUserRequest) (*model.User, error) {
 	user, err := s.repo.GetByID(id)
 	if err != nil {
@@ -82,7 +78,6 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *model.UpdateUserRequest) (*m
 	if req.FullName != "" {
 		user.FullName = req.FullName
 	}
-	user.Active = req.Active
 
 	if err := s.repo.Update(id, user); err != nil {
 		return nil, err
@@ -91,10 +86,6 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *model.UpdateUserRequest) (*m
 	return user, nil
 }
 
-func (s *UserService) DeleteUser(id uuid.UUID) error {
-	return s.repo.Delete(id)
-}
-
 func (s *UserService) VerifyPassword(user *model.User, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 }
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/thepantheon/api/internal/model"
+	"github.com/thepantheon/api/internal/repository"
+)
+
+const (
+	defaultRankingLimit = 20
+	maxRankingLimit     = 100
+)
+
+// VadeMecumIncidenciaService ranks the vade-mecum rows by how many questions
+// cite them. The counts are kept up to date as links and questions change,
+// so reads are a sum over vade_mecum_incidencias.
+type VadeMecumIncidenciaService struct {
+	repo         *repository.VadeMecumIncidenciaRepository
+	dispositivos *repository.QuestaoDispositivoRepository
+}
+
+func NewVadeMecumIncidenciaService(repo *repository.VadeMecumIncidenciaRepository, dispositivos *repository.QuestaoDispositivoRepository) *VadeMecumIncidenciaService {
+	return &VadeMecumIncidenciaService{repo: repo, dispositivos: dispositivos}
+}
+
+// Totais returns how many questions matching filters cite each row of a
+// source, keyed by id.
+func (s *VadeMecumIncidenciaService) Totais(fonte string, filters *model.VadeMecumIncidenciaFilters) (map[string]int64, error) {
+	return s.repo.Totais(fonte, filters)
+}
+
+// Ranking returns one page of the most cited rows, with a summary of each.
+// Without a fonte every source is ranked together.
+func (s *VadeMecumIncidenciaService) Ranking(filters *model.VadeMecumIncidenciaFilters) (*model.VadeMecumRanking, error) {
+	filters.Fonte = strings.ToLower(strings.TrimSpace(filters.Fonte))
+	if filters.Fonte != "" && !s.dispositivos.SupportsFonte(filters.Fonte) {
+		return nil, ErrInvalidDispositivoFonte
+	}
+	if filters.Limit < 1 {
+		filters.Limit = defaultRankingLimit
+	}
+	if filters.Limit > maxRankingLimit {
+		filters.Limit = maxRankingLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	rows, total, err := s.repo.Ranking(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string][]string)
+	for _, row := range rows {
+		ids[row.Fonte] = append(ids[row.Fonte], row.DispositivoID)
+	}
+	resumos := make(map[string]map[string]model.DispositivoResumo, len(ids))
+	for fonte, fonteIDs := range ids {
+		if resumos[fonte], err = s.dispositivos.Resumos(fonte, fonteIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]model.VadeMecumRankingItem, 0, len(rows))
+	for _, row := range rows {
+		resumo, ok := resumos[row.Fonte][row.DispositivoID]
+		if !ok {
+			continue
+		}
+		row.Diploma, row.Artigo, row.Texto = resumo.Diploma, resumo.Artigo, resumo.Texto
+		items = append(items, row)
+	}
+	return &model.VadeMecumRanking{
+		Data:   items,
+		Total:  total,
+		Limit:  filters.Limit,
+		Offset: filters.Offset,
+	}, nil
+}
@@ -0,0 +1,32 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_subject ON user_identities(provider, provider_user_id);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+-- Existing social accounts keep working through their identity row.
+INSERT INTO user_identities (user_id, provider, provider_user_id, email)
+SELECT id, provider, provider_id, email
+FROM users
+WHERE provider IS NOT NULL AND provider <> '' AND provider <> 'local'
+  AND provider_id IS NOT NULL AND provider_id <> ''
+ON CONFLICT (provider, provider_user_id) DO NOTHING;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS user_identities;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS media_assets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(20) NOT NULL,
+    filename TEXT,
+    content_type TEXT,
+    size BIGINT,
+    data BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_assets_kind ON media_assets(kind);
+CREATE INDEX IF NOT EXISTS idx_media_assets_deleted_at ON media_assets(deleted_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_asset_id UUID;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_asset_id;
+DROP TABLE IF EXISTS media_assets;
+
+COMMIT;
@@ -0,0 +1,42 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    size BIGINT,
+    data BYTEA,
+    error TEXT,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports(status);
+CREATE INDEX IF NOT EXISTS idx_data_exports_expires_at ON data_exports(expires_at);
+
+ALTER TABLE asaas_customers ADD COLUMN IF NOT EXISTS user_id UUID;
+ALTER TABLE asaas_customers ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_asaas_customers_user_id ON asaas_customers(user_id);
+
+-- Link existing customers to their users by e-mail.
+UPDATE asaas_customers ac
+SET user_id = u.id
+FROM users u
+WHERE ac.user_id IS NULL
+  AND LOWER(ac.email) = LOWER(u.email);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP INDEX IF EXISTS idx_asaas_customers_user_id;
+ALTER TABLE asaas_customers DROP COLUMN IF EXISTS anonymized_at;
+ALTER TABLE asaas_customers DROP COLUMN IF EXISTS user_id;
+DROP TABLE IF EXISTS data_exports;
+
+COMMIT;
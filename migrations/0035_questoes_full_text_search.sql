@@ -0,0 +1,59 @@
+-- +goose Up
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Portuguese stemming over unaccented words, so "acao" matches "ação" and
+-- ts_headline still highlights the original text.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'pt_unaccent') THEN
+        CREATE TEXT SEARCH CONFIGURATION pt_unaccent (COPY = portuguese);
+        ALTER TEXT SEARCH CONFIGURATION pt_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- questoes is loaded by the importer and may not exist yet on a fresh
+-- database; AutoMigrate adds the column and index in that case.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questoes ADD COLUMN IF NOT EXISTS search_vector tsvector
+            GENERATED ALWAYS AS (
+                setweight(to_tsvector('pt_unaccent', coalesce(titulo, '')), 'A') ||
+                setweight(to_tsvector('pt_unaccent', coalesce(enunciado, '')), 'A') ||
+                setweight(to_tsvector('pt_unaccent',
+                    coalesce(alternativa_a, '') || ' ' || coalesce(alternativa_b, '') || ' ' ||
+                    coalesce(alternativa_c, '') || ' ' || coalesce(alternativa_d, '') || ' ' ||
+                    coalesce(alternativa_e, '')), 'B') ||
+                setweight(to_tsvector('pt_unaccent', coalesce(comentario, '')), 'C')
+            ) STORED;
+        CREATE INDEX IF NOT EXISTS idx_questoes_search_vector ON questoes USING GIN (search_vector);
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP INDEX IF EXISTS idx_questoes_search_vector;
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questoes DROP COLUMN IF EXISTS search_vector;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+DROP TEXT SEARCH CONFIGURATION IF EXISTS pt_unaccent;
+
+COMMIT;
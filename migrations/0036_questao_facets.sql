@@ -0,0 +1,43 @@
+-- +goose Up
+BEGIN;
+
+-- Question counts grouped by every filterable column, so the facet counts of
+-- /questoes/filtros aggregate a narrow view instead of scanning questoes. The
+-- API refreshes it concurrently, which needs the unique facet_key index.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        CREATE MATERIALIZED VIEW IF NOT EXISTS questao_facets AS
+        SELECT
+            md5(ROW(disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+                tipo_questao, nivel, dificuldade, ano,
+                COALESCE(anulada, false), COALESCE(desatualizada, false), COALESCE(questao_oculta, false))::text) AS facet_key,
+            disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+            tipo_questao, nivel, dificuldade, ano,
+            COALESCE(anulada, false) AS anulada,
+            COALESCE(desatualizada, false) AS desatualizada,
+            COALESCE(questao_oculta, false) AS questao_oculta,
+            COUNT(*) AS total
+        FROM questoes
+        WHERE erro_captura IS NULL OR erro_captura = false
+        GROUP BY disciplina, assunto, banca, orgao, cargo, concurso, area_conhecimento,
+            tipo_questao, nivel, dificuldade, ano,
+            COALESCE(anulada, false), COALESCE(desatualizada, false), COALESCE(questao_oculta, false);
+
+        CREATE UNIQUE INDEX IF NOT EXISTS idx_questao_facets_key ON questao_facets (facet_key);
+        CREATE INDEX IF NOT EXISTS idx_questao_facets_disciplina ON questao_facets (disciplina);
+        CREATE INDEX IF NOT EXISTS idx_questao_facets_banca ON questao_facets (banca);
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP MATERIALIZED VIEW IF EXISTS questao_facets;
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS question_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    questao_id INTEGER NOT NULL,
+    resposta VARCHAR(10) NOT NULL,
+    gabarito VARCHAR(10) NOT NULL,
+    correct BOOLEAN NOT NULL,
+    tempo_segundos INTEGER,
+    answered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_attempts_user_answered ON question_attempts(user_id, answered_at);
+CREATE INDEX IF NOT EXISTS idx_question_attempts_questao_id ON question_attempts(questao_id);
+
+-- questoes is loaded by the importer and may not exist yet.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE question_attempts DROP CONSTRAINT IF EXISTS fk_question_attempts_questao;
+        ALTER TABLE question_attempts ADD CONSTRAINT fk_question_attempts_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- user_performances now holds one row per user and day, rebuilt from
+-- question_attempts. Existing rows were self-reported and keep day NULL.
+ALTER TABLE user_performances ADD COLUMN IF NOT EXISTS day DATE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_performances_user_day ON user_performances(user_id, day);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP INDEX IF EXISTS idx_user_performances_user_day;
+DELETE FROM user_performances WHERE day IS NOT NULL;
+ALTER TABLE user_performances DROP COLUMN IF EXISTS day;
+DROP TABLE IF EXISTS question_attempts;
+
+COMMIT;
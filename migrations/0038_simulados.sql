@@ -0,0 +1,42 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS simulados (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    titulo VARCHAR(200) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'em_andamento',
+    duracao_minutos INTEGER NOT NULL,
+    total_questoes INTEGER NOT NULL,
+    acertos INTEGER,
+    started_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulados_user_id ON simulados(user_id);
+
+CREATE TABLE IF NOT EXISTS simulado_questoes (
+    simulado_id UUID NOT NULL REFERENCES simulados(id) ON DELETE CASCADE,
+    ordem INTEGER NOT NULL,
+    questao_id INTEGER NOT NULL,
+    resposta VARCHAR(10),
+    answered_at TIMESTAMPTZ,
+    correct BOOLEAN,
+    attempt_id UUID REFERENCES question_attempts(id) ON DELETE SET NULL,
+    PRIMARY KEY (simulado_id, ordem)
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulado_questoes_questao_id ON simulado_questoes(questao_id);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS simulado_questoes;
+DROP TABLE IF EXISTS simulados;
+
+COMMIT;
@@ -0,0 +1,46 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cadernos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nome VARCHAR(200) NOT NULL,
+    descricao TEXT,
+    tipo VARCHAR(20) NOT NULL,
+    filtros JSONB,
+    share_token VARCHAR(64),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cadernos_user_id ON cadernos(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cadernos_share_token ON cadernos(share_token);
+
+CREATE TABLE IF NOT EXISTS caderno_questoes (
+    caderno_id UUID NOT NULL REFERENCES cadernos(id) ON DELETE CASCADE,
+    questao_id INTEGER NOT NULL,
+    ordem INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (caderno_id, questao_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_caderno_questoes_ordem ON caderno_questoes(caderno_id, ordem);
+
+CREATE TABLE IF NOT EXISTS caderno_progressos (
+    caderno_id UUID NOT NULL REFERENCES cadernos(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (caderno_id, user_id)
+);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS caderno_progressos;
+DROP TABLE IF EXISTS caderno_questoes;
+DROP TABLE IF EXISTS cadernos;
+
+COMMIT;
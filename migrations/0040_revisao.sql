@@ -0,0 +1,49 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS revisao_cards (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    questao_id INTEGER NOT NULL,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    reviews INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMPTZ NOT NULL,
+    last_reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, questao_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_revisao_cards_user_due ON revisao_cards(user_id, due_at);
+
+CREATE TABLE IF NOT EXISTS revisao_configs (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    limite_diario INTEGER NOT NULL,
+    primeiro_intervalo_dias INTEGER NOT NULL,
+    segundo_intervalo_dias INTEGER NOT NULL,
+    intervalo_maximo_dias INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Seed the queue with the questions whose latest answer was wrong.
+INSERT INTO revisao_cards (user_id, questao_id, interval_days, lapses, due_at)
+SELECT user_id, questao_id, 1, 1, answered_at + INTERVAL '1 day'
+FROM (
+    SELECT DISTINCT ON (user_id, questao_id) user_id, questao_id, correct, answered_at
+    FROM question_attempts
+    ORDER BY user_id, questao_id, answered_at DESC
+) latest
+WHERE NOT correct
+ON CONFLICT DO NOTHING;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS revisao_configs;
+DROP TABLE IF EXISTS revisao_cards;
+
+COMMIT;
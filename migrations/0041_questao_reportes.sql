@@ -0,0 +1,56 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS questao_reportes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    questao_id INTEGER NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    motivo VARCHAR(30) NOT NULL,
+    descricao TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pendente',
+    resposta TEXT,
+    resolvido_por UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolvido_em TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_questao_reportes_questao_status ON questao_reportes(questao_id, status);
+CREATE INDEX IF NOT EXISTS idx_questao_reportes_user_id ON questao_reportes(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questao_reportes_pendente ON questao_reportes(user_id, questao_id) WHERE status = 'pendente';
+
+-- questoes is loaded by the importer and may not exist yet.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questao_reportes DROP CONSTRAINT IF EXISTS fk_questao_reportes_questao;
+        ALTER TABLE questao_reportes ADD CONSTRAINT fk_questao_reportes_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE IF NOT EXISTS notificacoes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tipo VARCHAR(50) NOT NULL,
+    titulo VARCHAR(200) NOT NULL,
+    mensagem TEXT NOT NULL,
+    link VARCHAR(500),
+    lida_em TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notificacoes_user_created ON notificacoes(user_id, created_at);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS notificacoes;
+DROP TABLE IF EXISTS questao_reportes;
+
+COMMIT;
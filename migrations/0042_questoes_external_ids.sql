@@ -0,0 +1,26 @@
+-- +goose Up
+BEGIN;
+
+-- Bulk imports upsert by id_questao and id_questao_original. questoes is
+-- loaded by the importer and may not exist yet; AutoMigrate creates the
+-- indexes in that case.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        CREATE INDEX IF NOT EXISTS idx_questoes_id_questao ON questoes(id_questao);
+        CREATE INDEX IF NOT EXISTS idx_questoes_id_questao_original ON questoes(id_questao_original);
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP INDEX IF EXISTS idx_questoes_id_questao_original;
+DROP INDEX IF EXISTS idx_questoes_id_questao;
+
+COMMIT;
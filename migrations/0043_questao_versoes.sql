@@ -0,0 +1,36 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS questao_versoes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    questao_id INTEGER NOT NULL,
+    versao INTEGER NOT NULL,
+    editor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    alteracoes JSONB NOT NULL,
+    tentativas_recorrigidas INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questao_versoes_questao_versao ON questao_versoes(questao_id, versao);
+
+-- questoes is loaded by the importer and may not exist yet.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questao_versoes DROP CONSTRAINT IF EXISTS fk_questao_versoes_questao;
+        ALTER TABLE questao_versoes ADD CONSTRAINT fk_questao_versoes_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS questao_versoes;
+
+COMMIT;
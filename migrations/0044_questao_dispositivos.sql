@@ -0,0 +1,40 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS questao_dispositivos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    questao_id INTEGER NOT NULL,
+    fonte VARCHAR(20) NOT NULL,
+    dispositivo_id TEXT NOT NULL,
+    referencia VARCHAR(200) NOT NULL,
+    origem VARCHAR(20) NOT NULL,
+    removido BOOLEAN NOT NULL DEFAULT FALSE,
+    criado_por UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questao_dispositivos_unico ON questao_dispositivos(questao_id, fonte, dispositivo_id);
+CREATE INDEX IF NOT EXISTS idx_questao_dispositivos_dispositivo ON questao_dispositivos(fonte, dispositivo_id);
+
+-- questoes is loaded by the importer and may not exist yet.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questao_dispositivos DROP CONSTRAINT IF EXISTS fk_questao_dispositivos_questao;
+        ALTER TABLE questao_dispositivos ADD CONSTRAINT fk_questao_dispositivos_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS questao_dispositivos;
+
+COMMIT;
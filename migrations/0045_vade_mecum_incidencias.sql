@@ -0,0 +1,38 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS vade_mecum_incidencias (
+    fonte VARCHAR(20) NOT NULL,
+    dispositivo_id TEXT NOT NULL,
+    banca VARCHAR(200) NOT NULL DEFAULT '',
+    cargo VARCHAR(200) NOT NULL DEFAULT '',
+    ano INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL,
+    PRIMARY KEY (fonte, dispositivo_id, banca, cargo, ano)
+);
+
+-- Count the links detected before this table existed.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        INSERT INTO vade_mecum_incidencias (fonte, dispositivo_id, banca, cargo, ano, total)
+        SELECT qd.fonte, qd.dispositivo_id, COALESCE(q.banca, ''), COALESCE(q.cargo, ''), COALESCE(q.ano, 0), COUNT(DISTINCT q.id)
+        FROM questao_dispositivos qd
+        JOIN questoes q ON q.id = qd.questao_id
+        WHERE NOT qd.removido AND (q.erro_captura IS NULL OR q.erro_captura = false)
+        GROUP BY 1, 2, 3, 4, 5
+        ON CONFLICT DO NOTHING;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS vade_mecum_incidencias;
+
+COMMIT;
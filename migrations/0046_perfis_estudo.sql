@@ -0,0 +1,20 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS perfis_estudo (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    bancas JSONB NOT NULL DEFAULT '[]',
+    concursos JSONB NOT NULL DEFAULT '[]',
+    cargos JSONB NOT NULL DEFAULT '[]',
+    orgaos JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS perfis_estudo;
+
+COMMIT;
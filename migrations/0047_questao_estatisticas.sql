@@ -0,0 +1,36 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS questao_estatisticas (
+    questao_id INTEGER PRIMARY KEY,
+    resolucoes INTEGER NOT NULL,
+    acertos INTEGER NOT NULL,
+    respostas JSONB NOT NULL,
+    atualizado_em TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The statistics worker looks up the questions answered since its last run.
+CREATE INDEX IF NOT EXISTS idx_question_attempts_created_at ON question_attempts(created_at);
+
+-- questoes is loaded by the importer and may not exist yet.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questao_estatisticas DROP CONSTRAINT IF EXISTS fk_questao_estatisticas_questao;
+        ALTER TABLE questao_estatisticas ADD CONSTRAINT fk_questao_estatisticas_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP INDEX IF EXISTS idx_question_attempts_created_at;
+DROP TABLE IF EXISTS questao_estatisticas;
+
+COMMIT;
@@ -0,0 +1,66 @@
+-- +goose Up
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS respostas_discursivas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    questao_id INTEGER NOT NULL,
+    texto TEXT NOT NULL,
+    linhas INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pendente',
+    corretor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reservada_em TIMESTAMPTZ,
+    rubrica JSONB NOT NULL DEFAULT '[]',
+    nota DOUBLE PRECISION,
+    nota_maxima DOUBLE PRECISION NOT NULL,
+    comentario TEXT,
+    attempt_id UUID REFERENCES question_attempts(id) ON DELETE SET NULL,
+    corrigida_em TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_respostas_discursivas_user_id ON respostas_discursivas(user_id);
+CREATE INDEX IF NOT EXISTS idx_respostas_discursivas_questao_id ON respostas_discursivas(questao_id);
+CREATE INDEX IF NOT EXISTS idx_respostas_discursivas_corretor_id ON respostas_discursivas(corretor_id);
+CREATE INDEX IF NOT EXISTS idx_respostas_discursivas_status_created ON respostas_discursivas(status, created_at);
+-- A user has at most one answer per question waiting for correction.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_respostas_discursivas_aberta
+    ON respostas_discursivas(user_id, questao_id) WHERE status <> 'corrigida';
+
+-- questoes is loaded by the importer and may not exist yet on a fresh
+-- database; AutoMigrate adds the columns in that case.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questoes
+            ADD COLUMN IF NOT EXISTS rubrica JSONB NOT NULL DEFAULT '[]',
+            ADD COLUMN IF NOT EXISTS linhas_maximas INTEGER;
+        ALTER TABLE respostas_discursivas DROP CONSTRAINT IF EXISTS fk_respostas_discursivas_questao;
+        ALTER TABLE respostas_discursivas ADD CONSTRAINT fk_respostas_discursivas_questao
+            FOREIGN KEY (questao_id) REFERENCES questoes(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DROP TABLE IF EXISTS respostas_discursivas;
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('public.questoes') IS NOT NULL THEN
+        ALTER TABLE questoes
+            DROP COLUMN IF EXISTS linhas_maximas,
+            DROP COLUMN IF EXISTS rubrica;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+COMMIT;
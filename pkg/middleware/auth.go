@@ -5,38 +5,124 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/thepantheon/api/internal/service"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+const (
+	ContextUserIDKey    = "user_id"
+	ContextRoleKey      = "role"
+	ContextSessionIDKey = "session_id"
+)
+
+type AuthMiddleware struct {
+	authService *service.AuthService
+}
+
+func NewAuthMiddleware(authService *service.AuthService) *AuthMiddleware {
+	return &AuthMiddleware{authService: authService}
+}
+
+// RequireAuth rejects requests without a valid Bearer token, or whose
+// session was revoked or user disabled, and stores the authenticated user ID,
+// current role and session in the gin context.
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authorization header"})
+		if !m.authenticate(c, true) {
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+// OptionalAuth authenticates the request when an Authorization header is
+// present and lets anonymous requests through.
+func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.authenticate(c, false) {
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		tokenString := parts[1]
-
-		// Validate token (you can use your AuthService here)
-		_, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte("your_jwt_secret"), nil
-		})
-
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+// RequireRole authenticates the request and only lets it through when the
+// user's current role matches one of the given roles.
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.authenticate(c, true) {
 			c.Abort()
 			return
 		}
 
-		c.Next()
+		role := c.GetString(ContextRoleKey)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+func (m *AuthMiddleware) authenticate(c *gin.Context, required bool) bool {
+	if _, exists := c.Get(ContextUserIDKey); exists {
+		return true
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		if !required {
+			return true
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authorization header"})
+		return false
+	}
+
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		return false
+	}
+
+	user, sessionID, err := m.authService.Authenticate(parts[1])
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return false
+	}
+
+	c.Set(ContextUserIDKey, user.ID)
+	c.Set(ContextRoleKey, user.Role)
+	c.Set(ContextSessionIDKey, sessionID)
+	return true
+}
+
+// GetUserID returns the authenticated user ID stored by the auth middlewares.
+func GetUserID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(ContextUserIDKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	userID, ok := value.(uuid.UUID)
+	return userID, ok
+}
+
+// GetRole returns the authenticated user role stored by the auth middlewares.
+func GetRole(c *gin.Context) string {
+	return c.GetString(ContextRoleKey)
+}
+
+// GetSessionID returns the session bound to the access token, if any.
+func GetSessionID(c *gin.Context) uuid.UUID {
+	value, exists := c.Get(ContextSessionIDKey)
+	if !exists {
+		return uuid.Nil
 	}
+	sessionID, _ := value.(uuid.UUID)
+	return sessionID
 }